@@ -17,6 +17,7 @@ import (
 	"grabarr/internal/notifications"
 	"grabarr/internal/queue"
 	"grabarr/internal/repository"
+	"grabarr/internal/rsync"
 	internalsync "grabarr/internal/sync"
 
 	"github.com/gorilla/mux"
@@ -49,7 +50,7 @@ func run() error {
 	setupLogging(cfg.GetLogging())
 
 	// Initialize database
-	repo, err := repository.New(cfg.GetDatabase().Path)
+	repo, err := repository.NewWithConfig(cfg.GetDatabase())
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -57,6 +58,11 @@ func run() error {
 
 	slog.Info("database initialized", "path", cfg.GetDatabase().Path)
 
+	// Apply runtime config overrides before anything reads the config
+	if err := api.RestoreConfigOverrides(cfg, repo); err != nil {
+		slog.Error("failed to restore config overrides", "error", err)
+	}
+
 	// Initialize gatekeeper
 	gk := gatekeeper.New(cfg)
 	if err := gk.Start(); err != nil {
@@ -107,7 +113,22 @@ func run() error {
 
 	// Setup API handlers
 	handlers := api.NewHandlers(jobQueue, gk, cfg, repo, scanner)
+	handlers.SetNotifier(notifier)
+	handlers.SetProgressSource(jobExecutor)
+	handlers.SetConfigStore(repo)
+	if remotes := cfg.GetRemotes(); len(remotes) > 0 {
+		client := rsync.NewClient(remotes[0].SSHHost, remotes[0].SSHUser, remotes[0].SSHKeyFile)
+		handlers.SetRemoteSizer(client)
+		handlers.SetRemotePathChecker(client)
+	}
+	for _, remote := range cfg.GetRemotes() {
+		handlers.SetRemoteTester(remote.Name, rsync.NewClient(remote.SSHHost, remote.SSHUser, remote.SSHKeyFile))
+	}
 	handlers.RegisterRoutes(router)
+	handlers.StartEventStream(ctx)
+
+	// The gatekeeper has made its first check and the queue is running
+	handlers.SetReady(true)
 
 	// Log registered routes for debugging
 	slog.Info("routes registered", "web_ui_available", "check /dashboard and /ui endpoints")
@@ -153,6 +174,7 @@ func run() error {
 	// Wait for shutdown signal
 	<-sigChan
 	slog.Info("shutdown signal received, initiating graceful shutdown")
+	handlers.SetReady(false)
 
 	// Create shutdown context with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), serverConfig.ShutdownTimeout)
@@ -163,7 +185,7 @@ func run() error {
 		slog.Error("HTTP server shutdown error", "error", err)
 	}
 
-	// Stop job queue (marks jobs as queued)
+	// Stop job queue (re-queues or drains active jobs per server.shutdown_mode)
 	if err := jobQueue.Stop(); err != nil {
 		slog.Error("job queue shutdown error", "error", err)
 	}
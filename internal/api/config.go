@@ -0,0 +1,198 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"grabarr/internal/config"
+	"grabarr/internal/repository"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ConfigStore persists runtime config overrides so they survive restarts.
+type ConfigStore interface {
+	GetConfig(key string) (string, error)
+	SetConfig(key, value string) error
+	DeleteConfig(key string) error
+}
+
+// system_config keys holding the runtime overrides, as YAML.
+const (
+	configOverrideJobsKey       = "config_override_jobs"
+	configOverrideGatekeeperKey = "config_override_gatekeeper"
+)
+
+// requireAdmin rejects requests without server.admin_token as a bearer token.
+// The endpoints it guards are disabled while no token is configured.
+func (h *Handlers) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := h.config.GetServer().AdminToken
+		if token == "" {
+			h.writeError(w, http.StatusForbidden, "Runtime config updates are disabled: server.admin_token is not set", nil)
+			return
+		}
+
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="grabarr"`)
+			h.writeError(w, http.StatusUnauthorized, "Invalid or missing admin token", nil)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// UpdateJobsConfig changes the jobs config section at runtime. Settings the
+// body leaves out keep their current values.
+func (h *Handlers) UpdateJobsConfig(w http.ResponseWriter, r *http.Request) {
+	jobs := h.config.GetJobs()
+	if !h.decodeConfigSection(w, r, &jobs) {
+		return
+	}
+
+	applied, err := h.config.OverrideJobs(jobs)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	slog.Info("jobs config overridden at runtime")
+
+	h.writeConfigSection(w, configOverrideJobsKey, applied)
+}
+
+// ClearJobsConfig drops the runtime jobs override, restoring the config file's values.
+func (h *Handlers) ClearJobsConfig(w http.ResponseWriter, r *http.Request) {
+	if h.configStore != nil {
+		if err := h.configStore.DeleteConfig(configOverrideJobsKey); err != nil {
+			h.writeError(w, http.StatusInternalServerError, "Failed to delete config override", err)
+			return
+		}
+	}
+	h.config.ClearJobsOverride()
+	slog.Info("jobs config override cleared")
+
+	h.writeSuccess(w, http.StatusOK, nil, "Jobs config restored from file")
+}
+
+// UpdateGatekeeperConfig changes the gatekeeper config section at runtime,
+// like UpdateJobsConfig.
+func (h *Handlers) UpdateGatekeeperConfig(w http.ResponseWriter, r *http.Request) {
+	gatekeeper := h.config.GetGatekeeper()
+	if !h.decodeConfigSection(w, r, &gatekeeper) {
+		return
+	}
+
+	applied, err := h.config.OverrideGatekeeper(gatekeeper)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	slog.Info("gatekeeper config overridden at runtime")
+
+	h.writeConfigSection(w, configOverrideGatekeeperKey, applied)
+}
+
+// ClearGatekeeperConfig drops the runtime gatekeeper override, restoring the
+// config file's values.
+func (h *Handlers) ClearGatekeeperConfig(w http.ResponseWriter, r *http.Request) {
+	if h.configStore != nil {
+		if err := h.configStore.DeleteConfig(configOverrideGatekeeperKey); err != nil {
+			h.writeError(w, http.StatusInternalServerError, "Failed to delete config override", err)
+			return
+		}
+	}
+	h.config.ClearGatekeeperOverride()
+	slog.Info("gatekeeper config override cleared")
+
+	h.writeSuccess(w, http.StatusOK, nil, "Gatekeeper config restored from file")
+}
+
+// RestoreConfigOverrides re-applies overrides saved by earlier config updates
+// to cfg. Call it before starting the components that read cfg, so they start
+// with the overrides in place. An override that no longer validates is skipped
+// with a warning.
+func RestoreConfigOverrides(cfg *config.Config, store ConfigStore) error {
+	restore := func(key string, apply func(data []byte) error) error {
+		value, err := store.GetConfig(key)
+		if errors.Is(err, repository.ErrConfigNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := apply([]byte(value)); err != nil {
+			slog.Warn("ignoring saved config override", "key", key, "error", err)
+			return nil
+		}
+		slog.Info("restored config override", "key", key)
+		return nil
+	}
+
+	if err := restore(configOverrideJobsKey, func(data []byte) error {
+		var jobs config.JobsConfig
+		if err := yaml.Unmarshal(data, &jobs); err != nil {
+			return err
+		}
+		_, err := cfg.OverrideJobs(jobs)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return restore(configOverrideGatekeeperKey, func(data []byte) error {
+		var gatekeeper config.GatekeeperConfig
+		if err := yaml.Unmarshal(data, &gatekeeper); err != nil {
+			return err
+		}
+		_, err := cfg.OverrideGatekeeper(gatekeeper)
+		return err
+	})
+}
+
+// decodeConfigSection decodes the request body onto section, so keys the body
+// leaves out keep section's values. The body is JSON using the same keys and
+// value formats as config.yaml (e.g. "stall_timeout": "10m"); unknown keys are
+// rejected.
+func (h *Handlers) decodeConfigSection(w http.ResponseWriter, r *http.Request, section interface{}) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeDecodeError(w, "Failed to read request body", err)
+		return false
+	}
+	if err := yaml.UnmarshalWithOptions(body, section, yaml.DisallowUnknownField()); err != nil {
+		summary, _, _ := strings.Cut(err.Error(), "\n")
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid config payload: %s", summary), err)
+		return false
+	}
+	return true
+}
+
+// writeConfigSection saves an applied override and responds with it, encoded
+// with config.yaml keys.
+func (h *Handlers) writeConfigSection(w http.ResponseWriter, key string, section interface{}) {
+	if h.configStore != nil {
+		saved, err := yaml.Marshal(section)
+		if err == nil {
+			err = h.configStore.SetConfig(key, string(saved))
+		}
+		if err != nil {
+			h.writeError(w, http.StatusInternalServerError, "Config applied but could not be saved; it will be lost on restart", err)
+			return
+		}
+	}
+
+	data, err := yaml.MarshalWithOptions(section, yaml.JSON())
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to encode config", err)
+		return
+	}
+	h.writeSuccess(w, http.StatusOK, json.RawMessage(data), "Config updated")
+}
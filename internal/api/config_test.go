@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+	"grabarr/internal/models"
+	"grabarr/internal/repository"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConfigStore map[string]string
+
+func (f fakeConfigStore) GetConfig(key string) (string, error) {
+	value, ok := f[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", repository.ErrConfigNotFound, key)
+	}
+	return value, nil
+}
+
+func (f fakeConfigStore) SetConfig(key, value string) error {
+	f[key] = value
+	return nil
+}
+
+func (f fakeConfigStore) DeleteConfig(key string) error {
+	delete(f, key)
+	return nil
+}
+
+func setupConfigHandlers(t *testing.T) (*Handlers, *mux.Router, fakeConfigStore) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, AdminToken: "s3cret"},
+		Jobs:   config.JobsConfig{MaxConcurrent: 2, MaxRetries: 3},
+		Gatekeeper: config.GatekeeperConfig{
+			Seedbox: config.SeedboxConfig{BandwidthLimitMbps: 100},
+		},
+	}
+	h := NewHandlers(mocks.NewMockJobQueue(t), nil, cfg, nil, nil)
+	store := fakeConfigStore{}
+	h.SetConfigStore(store)
+
+	router := mux.NewRouter()
+	h.RegisterRoutes(router)
+	return h, router, store
+}
+
+func configRequest(method, path, body, token string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestUpdateJobsConfig_Success(t *testing.T) {
+	h, router, store := setupConfigHandlers(t)
+	changes := h.config.WatchForChanges()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/jobs", `{"max_concurrent": 6, "stall_timeout": "10m"}`, "s3cret"))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, 6.0, response.Data["max_concurrent"])
+	assert.Equal(t, "10m0s", response.Data["stall_timeout"])
+
+	jobs := h.config.GetJobs()
+	assert.Equal(t, 6, jobs.MaxConcurrent)
+	assert.Equal(t, 10*time.Minute, jobs.StallTimeout)
+	assert.Equal(t, 3, jobs.MaxRetries)
+
+	select {
+	case <-changes:
+	default:
+		t.Fatal("config watchers were not notified")
+	}
+
+	assert.Contains(t, store[configOverrideJobsKey], "max_concurrent: 6")
+}
+
+func TestUpdateConfig_PartialBodyKeepsOtherSettings(t *testing.T) {
+	h, router, store := setupConfigHandlers(t)
+	h.config.Jobs = config.JobsConfig{
+		MaxConcurrent:     2,
+		MaxRetries:        4,
+		StallTimeout:      15 * time.Minute,
+		NameTemplate:      "{{.Base}}",
+		RetryableFailures: []models.FailureCategory{models.FailureCategoryNetwork, models.FailureCategoryDisk},
+	}
+	h.config.Gatekeeper.CacheDisk = config.CacheDiskConfig{
+		Path:            "/cache",
+		MaxUsagePercent: 85,
+		Eviction:        config.CacheEvictionConfig{Enabled: true, ThresholdPercent: 80, MinAge: 12 * time.Hour},
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/jobs", `{"max_concurrent": 3}`, "s3cret"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	jobs := h.config.GetJobs()
+	assert.Equal(t, 3, jobs.MaxConcurrent)
+	assert.Equal(t, 4, jobs.MaxRetries)
+	assert.Equal(t, 15*time.Minute, jobs.StallTimeout)
+	assert.Equal(t, "{{.Base}}", jobs.NameTemplate)
+	assert.Equal(t, []models.FailureCategory{models.FailureCategoryNetwork, models.FailureCategoryDisk}, jobs.RetryableFailures)
+	// The saved override holds the whole section, not just the change
+	assert.Contains(t, store[configOverrideJobsKey], "max_retries: 4")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/gatekeeper", `{"cache_disk": {"max_usage_percent": 90}}`, "s3cret"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	disk := h.config.GetGatekeeper().CacheDisk
+	assert.Equal(t, 90, disk.MaxUsagePercent)
+	assert.Equal(t, "/cache", disk.Path)
+	assert.Equal(t, config.CacheEvictionConfig{Enabled: true, ThresholdPercent: 80, MinAge: 12 * time.Hour}, disk.Eviction)
+	assert.Equal(t, 100, h.config.GetGatekeeper().Seedbox.BandwidthLimitMbps)
+}
+
+func TestUpdateGatekeeperConfig_Success(t *testing.T) {
+	h, router, store := setupConfigHandlers(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/gatekeeper",
+		`{"seedbox": {"bandwidth_limit_mbps": 250}, "cache_disk": {"max_usage_percent": 90, "min_free_bytes": "20GB"}}`, "s3cret"))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	gk := h.config.GetGatekeeper()
+	assert.Equal(t, 250, gk.Seedbox.BandwidthLimitMbps)
+	assert.Equal(t, 90, gk.CacheDisk.MaxUsagePercent)
+	assert.Equal(t, config.ByteSize(20<<30), gk.CacheDisk.MinFreeBytes)
+	// Omitted intervals get their defaults
+	assert.Equal(t, 30*time.Second, gk.Seedbox.CheckInterval)
+	assert.Contains(t, store, configOverrideGatekeeperKey)
+}
+
+func TestUpdateConfig_ValidationRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		wantErr string
+	}{
+		{"negative retries", "/api/v1/config/jobs", `{"max_retries": -1}`, "max_retries cannot be negative"},
+		{"invalid recovery mode", "/api/v1/config/jobs", `{"recovery_mode": "panic"}`, "invalid recovery_mode: panic"},
+		{"unknown field", "/api/v1/config/jobs", `{"max_concurent": 4}`, `Invalid config payload: [1:2] unknown field "max_concurent"`},
+		{"malformed duration", "/api/v1/config/jobs", `{"stall_timeout": "soon"}`, "Invalid config payload"},
+		{"usage over 100", "/api/v1/config/gatekeeper", `{"cache_disk": {"eviction": {"enabled": true, "threshold_percent": 120}}}`, "eviction threshold_percent must be between 0 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, router, store := setupConfigHandlers(t)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, configRequest("PUT", tt.path, tt.body, "s3cret"))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var response APIResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Contains(t, response.Error, tt.wantErr)
+
+			// Nothing was applied or saved
+			assert.Equal(t, 2, h.config.GetJobs().MaxConcurrent)
+			assert.Equal(t, 3, h.config.GetJobs().MaxRetries)
+			assert.Equal(t, 100, h.config.GetGatekeeper().Seedbox.BandwidthLimitMbps)
+			assert.Empty(t, store)
+		})
+	}
+}
+
+func TestUpdateConfig_RequiresAdminToken(t *testing.T) {
+	_, router, _ := setupConfigHandlers(t)
+
+	for _, token := range []string{"", "wrong"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/jobs", `{"max_concurrent": 6}`, token))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, "token %q", token)
+		assert.Equal(t, `Bearer realm="grabarr"`, rec.Header().Get("WWW-Authenticate"))
+	}
+}
+
+func TestUpdateConfig_DisabledWithoutAdminToken(t *testing.T) {
+	h, router, _ := setupConfigHandlers(t)
+	h.config.Server.AdminToken = ""
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/jobs", `{"max_concurrent": 6}`, "s3cret"))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, 2, h.config.GetJobs().MaxConcurrent)
+}
+
+func TestClearJobsConfig(t *testing.T) {
+	h, router, store := setupConfigHandlers(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("PUT", "/api/v1/config/jobs", `{"max_concurrent": 6}`, "s3cret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, configRequest("DELETE", "/api/v1/config/jobs", "", "s3cret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, 2, h.config.GetJobs().MaxConcurrent)
+	assert.Equal(t, 3, h.config.GetJobs().MaxRetries)
+	assert.NotContains(t, store, configOverrideJobsKey)
+}
+
+func TestRestoreConfigOverrides(t *testing.T) {
+	h, _, store := setupConfigHandlers(t)
+	store[configOverrideJobsKey] = "max_concurrent: 8\n"
+	// An override that no longer validates is skipped
+	store[configOverrideGatekeeperKey] = "cache_disk:\n  min_free_bytes: -1\n"
+
+	require.NoError(t, RestoreConfigOverrides(h.config, store))
+
+	assert.Equal(t, 8, h.config.GetJobs().MaxConcurrent)
+	assert.Equal(t, 100, h.config.GetGatekeeper().Seedbox.BandwidthLimitMbps)
+}
+
+type failingConfigStore struct{ fakeConfigStore }
+
+func (failingConfigStore) GetConfig(key string) (string, error) {
+	return "", errors.New("database is locked")
+}
+
+func TestRestoreConfigOverrides_StoreError(t *testing.T) {
+	h, _, _ := setupConfigHandlers(t)
+
+	assert.Error(t, RestoreConfigOverrides(h.config, failingConfigStore{fakeConfigStore{}}))
+}
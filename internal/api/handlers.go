@@ -1,9 +1,16 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"grabarr/internal/config"
 	"grabarr/internal/interfaces"
@@ -13,19 +20,31 @@ import (
 )
 
 type Handlers struct {
-	queue          interfaces.JobQueue
-	gatekeeper     interfaces.Gatekeeper
-	config         *config.Config
-	remoteFileRepo RemoteFileRepo
-	scanner        *sync.Scanner
+	queue             interfaces.JobQueue
+	gatekeeper        interfaces.Gatekeeper
+	config            *config.Config
+	remoteFileRepo    RemoteFileRepo
+	scanner           *sync.Scanner
+	notifier          interfaces.Notifier
+	rateLimiter       *rateLimiter
+	statFile          func(name string) (os.FileInfo, error)
+	remoteSizer       RemoteSizer
+	remoteSizeTimeout time.Duration
+	remoteTesters     map[string]RemoteTester
+	remotePathChecker RemotePathChecker
+	progressSource    ProgressSource
+	configStore       ConfigStore
+	wsHub             *wsHub
+	ready             atomic.Bool
 }
 
 type APIResponse struct {
-	Success    bool            `json:"success"`
-	Data       interface{}     `json:"data,omitempty"`
-	Error      string          `json:"error,omitempty"`
-	Message    string          `json:"message,omitempty"`
-	Pagination *PaginationMeta `json:"pagination,omitempty"`
+	Success     bool              `json:"success"`
+	Data        interface{}       `json:"data,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	FieldErrors map[string]string `json:"field_errors,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Pagination  *PaginationMeta   `json:"pagination,omitempty"`
 }
 
 type PaginationMeta struct {
@@ -34,32 +53,99 @@ type PaginationMeta struct {
 	Offset     int `json:"offset"`
 	TotalPages int `json:"total_pages"`
 	Page       int `json:"page"`
+	// NextCursor, when set, is passed as `after` to fetch the following page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func NewHandlers(jobQueue interfaces.JobQueue, gatekeeper interfaces.Gatekeeper, cfg *config.Config, remoteFileRepo RemoteFileRepo, scanner *sync.Scanner) *Handlers {
-	return &Handlers{
-		queue:          jobQueue,
-		gatekeeper:     gatekeeper,
-		config:         cfg,
-		remoteFileRepo: remoteFileRepo,
-		scanner:        scanner,
+	h := &Handlers{
+		queue:             jobQueue,
+		gatekeeper:        gatekeeper,
+		config:            cfg,
+		remoteFileRepo:    remoteFileRepo,
+		scanner:           scanner,
+		statFile:          os.Stat,
+		remoteSizeTimeout: defaultRemoteSizeTimeout,
+		wsHub:             newWSHub(),
 	}
+
+	if rl := cfg.GetServer().RateLimit; rl.Enabled {
+		h.rateLimiter = newRateLimiter(rl.RequestsPerSecond, rl.Burst, rl.TrustForwardedFor)
+	}
+
+	return h
+}
+
+// SetNotifier enables the notification endpoints.
+func (h *Handlers) SetNotifier(notifier interfaces.Notifier) {
+	h.notifier = notifier
+}
+
+// SetRemoteSizer enables the remote size preview endpoint.
+func (h *Handlers) SetRemoteSizer(sizer RemoteSizer) {
+	h.remoteSizer = sizer
+}
+
+// SetRemoteTester enables the connectivity test endpoint for the named remote.
+func (h *Handlers) SetRemoteTester(name string, tester RemoteTester) {
+	if h.remoteTesters == nil {
+		h.remoteTesters = make(map[string]RemoteTester)
+	}
+	h.remoteTesters[name] = tester
+}
+
+// SetRemotePathChecker enables jobs.validate_remote_path, which rejects jobs
+// whose remote_path doesn't exist on the seedbox.
+func (h *Handlers) SetRemotePathChecker(checker RemotePathChecker) {
+	h.remotePathChecker = checker
+}
+
+// SetProgressSource makes job reads report live transfer progress instead of
+// the last progress written to the database.
+func (h *Handlers) SetProgressSource(source ProgressSource) {
+	h.progressSource = source
+}
+
+// SetConfigStore persists runtime config overrides made through the API.
+func (h *Handlers) SetConfigStore(store ConfigStore) {
+	h.configStore = store
+}
+
+// SetReady marks whether startup has finished, which /readyz and /ready report to
+// orchestrators deciding whether to route traffic here.
+func (h *Handlers) SetReady(ready bool) {
+	h.ready.Store(ready)
 }
 
 func (h *Handlers) RegisterRoutes(r *mux.Router) {
 	// Web UI routes (serve before API to avoid conflicts)
 	h.registerWebRoutes(r)
 
+	// Orchestrator probes live at the root, outside the versioned API
+	r.HandleFunc("/healthz", h.Liveness).Methods("GET")
+	r.HandleFunc("/readyz", h.ReadinessCheck).Methods("GET")
+
 	api := r.PathPrefix("/api/v1").Subrouter()
 
 	// Job management endpoints
 	api.HandleFunc("/jobs", h.CreateJob).Methods("POST")
-	api.HandleFunc("/jobs", h.GetJobs).Methods("GET")
+	api.HandleFunc("/jobs", h.GetJobs).Methods("GET", "HEAD")
+	api.HandleFunc("/jobs/export", h.ExportJobs).Methods("GET")
 	api.HandleFunc("/jobs/{id:[0-9]+}", h.GetJob).Methods("GET")
 	api.HandleFunc("/jobs/{id:[0-9]+}", h.DeleteJob).Methods("DELETE")
 	api.HandleFunc("/jobs/{id:[0-9]+}/cancel", h.CancelJob).Methods("POST")
+	api.HandleFunc("/jobs/by-hash/{hash:[0-9a-fA-F]+}/cancel", h.CancelJobByHash).Methods("POST")
+	api.HandleFunc("/jobs/cancel-by-path", h.CancelJobByPath).Methods("POST")
 	api.HandleFunc("/jobs/{id:[0-9]+}/retry", h.RetryJob).Methods("POST")
-	api.HandleFunc("/jobs/summary", h.GetJobSummary).Methods("GET")
+	api.HandleFunc("/jobs/{id:[0-9]+}/force-start", h.ForceStartJob).Methods("POST")
+	api.HandleFunc("/jobs/{id:[0-9]+}/restore", h.RestoreJob).Methods("POST")
+	api.HandleFunc("/jobs/{id:[0-9]+}/attempts", h.GetJobAttempts).Methods("GET")
+	api.HandleFunc("/jobs/{id:[0-9]+}/audit", h.GetJobAudit).Methods("GET")
+	api.HandleFunc("/jobs/purge", h.PurgeJobs).Methods("POST")
+	api.HandleFunc("/jobs/status", h.GetJobsStatus).Methods("POST")
+	api.HandleFunc("/jobs/summary", h.GetJobSummary).Methods("GET", "HEAD")
+	api.HandleFunc("/jobs/by-category", h.GetJobsByCategory).Methods("GET")
+	api.HandleFunc("/groups/{groupId}", h.GetJobGroup).Methods("GET")
 
 	// Remote files (seedbox scanner) endpoints
 	api.HandleFunc("/remote-files", h.ListRemoteFiles).Methods("GET")
@@ -70,16 +156,60 @@ func (h *Handlers) RegisterRoutes(r *mux.Router) {
 	api.HandleFunc("/remote-files/{id:[0-9]+}/restore", h.RestoreRemoteFile).Methods("POST")
 	api.HandleFunc("/sync/scan", h.TriggerScan).Methods("POST")
 	api.HandleFunc("/sync/status", h.GetSyncStatus).Methods("GET")
+	api.HandleFunc("/remote/size", h.GetRemoteSize).Methods("GET")
+	api.HandleFunc("/remote/test", h.TestRemote).Methods("POST")
+
+	// Notification endpoints
+	api.HandleFunc("/notifications/test", h.TestNotification).Methods("POST")
 
 	// System endpoints
 	api.HandleFunc("/health", h.HealthCheck).Methods("GET")
+	api.HandleFunc("/ready", h.ReadinessCheck).Methods("GET")
+	api.HandleFunc("/version", h.GetVersion).Methods("GET")
 	api.HandleFunc("/metrics", h.GetMetrics).Methods("GET")
 	api.HandleFunc("/status", h.GetStatus).Methods("GET")
+	api.HandleFunc("/stats", h.GetTransferStats).Methods("GET")
+	api.HandleFunc("/gatekeeper/check", h.CheckGatekeeper).Methods("GET")
+	api.HandleFunc("/gatekeeper/refresh", h.RefreshGatekeeper).Methods("POST")
+	api.HandleFunc("/maintenance/vacuum", h.VacuumDatabase).Methods("POST")
+	api.HandleFunc("/system/disk", h.GetDiskUsage).Methods("GET")
+
+	// Runtime config overrides, guarded by server.admin_token
+	api.HandleFunc("/config/jobs", h.requireAdmin(h.UpdateJobsConfig)).Methods("PUT")
+	api.HandleFunc("/config/jobs", h.requireAdmin(h.ClearJobsConfig)).Methods("DELETE")
+	api.HandleFunc("/config/gatekeeper", h.requireAdmin(h.UpdateGatekeeperConfig)).Methods("PUT")
+	api.HandleFunc("/config/gatekeeper", h.requireAdmin(h.ClearGatekeeperConfig)).Methods("DELETE")
+
+	// Live updates
+	api.HandleFunc("/ws", h.ServeWebSocket).Methods("GET")
 
 	// Add CORS middleware
 	api.Use(corsMiddleware)
 	api.Use(loggingMiddleware)
+	if h.config.GetServer().EnableGzip {
+		api.Use(gzipMiddleware)
+	}
 	api.Use(jsonContentTypeMiddleware)
+	api.Use(bodyLimitMiddleware(h.maxRequestBodyBytes()))
+	if h.rateLimiter != nil {
+		api.Use(h.rateLimiter.middleware)
+	}
+}
+
+func (h *Handlers) maxRequestBodyBytes() int64 {
+	if limit := h.config.GetServer().MaxRequestBodyBytes; limit > 0 {
+		return limit
+	}
+	return defaultMaxRequestBodyBytes
+}
+
+// remoteRoot returns the directory API remote paths are resolved under. Jobs
+// run against the first configured remote, so its remote_root applies.
+func (h *Handlers) remoteRoot() string {
+	if remotes := h.config.GetRemotes(); len(remotes) > 0 {
+		return remotes[0].RemoteRoot
+	}
+	return ""
 }
 
 func (h *Handlers) writeSuccess(w http.ResponseWriter, statusCode int, data interface{}, message string) {
@@ -109,6 +239,51 @@ func (h *Handlers) writeSuccessWithPagination(w http.ResponseWriter, statusCode
 	}
 }
 
+// writeCacheableSuccess writes a 200 success response with a weak ETag computed
+// from the body, replying 304 Not Modified when If-None-Match already has it.
+// Cache-Control: no-cache lets clients store the response but revalidate on reuse.
+func (h *Handlers) writeCacheableSuccess(w http.ResponseWriter, r *http.Request, data interface{}, pagination *PaginationMeta) {
+	body, err := json.Marshal(APIResponse{
+		Success:    true,
+		Data:       data,
+		Pagination: pagination,
+	})
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to encode response", err)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		slog.Error("failed to write response", "error", err)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using the
+// weak comparison RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handlers) writeError(w http.ResponseWriter, statusCode int, message string, err error) {
 	w.WriteHeader(statusCode)
 	response := APIResponse{
@@ -126,3 +301,44 @@ func (h *Handlers) writeError(w http.ResponseWriter, statusCode int, message str
 		slog.Error("failed to encode error response", "error", jsonErr)
 	}
 }
+
+// writeDecodeError reports a request body decode failure, using 413 when the
+// body exceeded the size limit and 400 otherwise.
+func (h *Handlers) writeDecodeError(w http.ResponseWriter, message string, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		h.writeError(w, http.StatusRequestEntityTooLarge, "Request body too large", err)
+		return
+	}
+	h.writeError(w, http.StatusBadRequest, message, err)
+}
+
+// queueFullRetryAfter is the Retry-After hint, in seconds, sent when the job queue is full.
+const queueFullRetryAfter = "30"
+
+// writeEnqueueError reports an Enqueue failure, using 503 with Retry-After when
+// the queue is at capacity and 500 otherwise.
+func (h *Handlers) writeEnqueueError(w http.ResponseWriter, message string, err error) {
+	if errors.Is(err, interfaces.ErrQueueFull) {
+		w.Header().Set("Retry-After", queueFullRetryAfter)
+		h.writeError(w, http.StatusServiceUnavailable, "Job queue is full, try again later", err)
+		return
+	}
+	h.writeError(w, http.StatusInternalServerError, message, err)
+}
+
+// writeValidationError writes a 400 with a human-readable summary plus per-field errors.
+func (h *Handlers) writeValidationError(w http.ResponseWriter, summary string, fieldErrors map[string]string) {
+	w.WriteHeader(http.StatusBadRequest)
+	response := APIResponse{
+		Success:     false,
+		Error:       summary,
+		FieldErrors: fieldErrors,
+	}
+
+	slog.Warn("API validation error", "message", summary, "fields", fieldErrors)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to encode error response", "error", err)
+	}
+}
@@ -1,14 +1,25 @@
 package api
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"grabarr/internal/config"
+	"grabarr/internal/interfaces"
 	"grabarr/internal/models"
 
 	"github.com/gorilla/mux"
@@ -17,111 +28,229 @@ import (
 type CreateJobRequest struct {
 	Name           string                 `json:"name"`
 	RemotePath     string                 `json:"remote_path"`
+	SourceURL      string                 `json:"source_url,omitempty"` // HTTP(S) URL to download instead of remote_path
 	LocalPath      string                 `json:"local_path"`
 	Priority       int                    `json:"priority,omitempty"`
 	MaxRetries     int                    `json:"max_retries,omitempty"`
 	FileSize       int64                  `json:"file_size,omitempty"`
+	GroupID        string                 `json:"group_id,omitempty"`
 	Metadata       models.JobMetadata     `json:"metadata,omitempty"`
 	DownloadConfig *models.DownloadConfig `json:"download_config,omitempty"`
 }
 
-func (h *Handlers) CreateJob(w http.ResponseWriter, r *http.Request) {
-	var req CreateJobRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid JSON payload", err)
-		return
-	}
+// ProgressSource reports the live progress of running transfers, which can be
+// ahead of the throttled progress stored in the database.
+type ProgressSource interface {
+	LatestProgress(jobID int64) (models.JobProgress, bool)
+}
 
-	// Validate required fields
-	if req.Name == "" {
-		h.writeError(w, http.StatusBadRequest, "job name is required", nil)
+// applyLiveProgress overwrites the stored progress of running jobs with the
+// live progress from the executor, when one is set.
+func (h *Handlers) applyLiveProgress(jobs ...*models.Job) {
+	if h.progressSource == nil {
 		return
 	}
-	if req.RemotePath == "" {
-		h.writeError(w, http.StatusBadRequest, "remote_path is required", nil)
-		return
-	}
-	if req.LocalPath == "" {
-		h.writeError(w, http.StatusBadRequest, "local_path is required", nil)
-		return
+	for _, job := range jobs {
+		if job.Status != models.JobStatusRunning {
+			continue
+		}
+		if progress, ok := h.progressSource.LatestProgress(job.ID); ok {
+			job.Progress = progress
+			job.TransferredBytes = progress.TransferredBytes
+			job.TransferSpeed = progress.TransferSpeed
+		}
 	}
+}
 
-	// Validate local_path doesn't try to escape base directory
-	if filepath.IsAbs(req.LocalPath) {
-		h.writeError(w, http.StatusBadRequest, "local_path must be a relative path", nil)
+func (h *Handlers) CreateJob(w http.ResponseWriter, r *http.Request) {
+	var req CreateJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeDecodeError(w, "Invalid JSON payload", err)
 		return
 	}
-	cleanPath := filepath.Clean(req.LocalPath)
-	if strings.HasPrefix(cleanPath, "..") || strings.Contains(cleanPath, "/../") {
-		h.writeError(w, http.StatusBadRequest, "local_path cannot escape base directory", nil)
+
+	downloadsConfig := h.config.GetDownloads()
+
+	remotePath, fieldErrors, summary := validateCreateJobRequest(&req, downloadsConfig.AllowedCategories, h.remoteRoot(), h.config.GetJobs())
+	if len(fieldErrors) > 0 {
+		h.writeValidationError(w, summary, fieldErrors)
 		return
 	}
 
-	// Check category filtering
-	downloadsConfig := h.config.GetDownloads()
-	if len(downloadsConfig.AllowedCategories) > 0 {
-		category := req.Metadata.Category
-		if category == "" || !contains(downloadsConfig.AllowedCategories, category) {
-			h.writeError(w, http.StatusBadRequest,
-				fmt.Sprintf("category '%s' not allowed. Allowed categories: %v",
-					category, downloadsConfig.AllowedCategories), nil)
-			return
-		}
+	if req.SourceURL == "" && !h.remotePathExists(r.Context(), remotePath) {
+		h.writeValidationError(w, "remote path not found", map[string]string{"remote_path": "remote path not found"})
+		return
 	}
 
-	// Combine base download path with relative local path
-	fullLocalPath := filepath.Join(downloadsConfig.LocalPath, req.LocalPath)
+	// Combine base download path (routed by category) with relative local path
+	basePath := downloadsConfig.BasePathForCategory(req.Metadata.Category)
+	fullLocalPath := filepath.Join(basePath, req.LocalPath)
 
 	// Create job model
 	job := &models.Job{
 		Name:           req.Name,
-		RemotePath:     req.RemotePath,
+		RemotePath:     remotePath,
+		SourceURL:      req.SourceURL,
 		LocalPath:      fullLocalPath,
 		Priority:       req.Priority,
 		MaxRetries:     req.MaxRetries,
 		FileSize:       req.FileSize,
+		GroupID:        req.GroupID,
 		Metadata:       req.Metadata,
 		DownloadConfig: req.DownloadConfig,
 		Status:         models.JobStatusQueued,
+		CreatedAt:      time.Now(),
 		Progress: models.JobProgress{
 			LastUpdateTime: time.Now(),
 		},
 	}
 
+	// Resolve the dest_template now, so the skip_existing check below and the
+	// transfer agree on the directory the file goes into
+	if err := job.ApplyDestTemplate(downloadsConfig.DestTemplateForJob(job)); err != nil {
+		h.writeValidationError(w, err.Error(), map[string]string{"download_config.dest_template": err.Error()})
+		return
+	}
+
+	message := "Job created successfully"
+	if downloadsConfig.SkipExisting {
+		if existing, ok := alreadyPresent(h.statFile, job.LocalPath, remotePath, req.SourceURL, req.FileSize); ok {
+			job.MarkCompleted()
+			job.TransferredBytes = req.FileSize
+			if job.Metadata.ExtraFields == nil {
+				job.Metadata.ExtraFields = make(map[string]interface{})
+			}
+			job.Metadata.ExtraFields["skip_reason"] = "already present locally"
+			message = "Job skipped: file already present locally"
+			slog.Info("skipping job, file already present", "name", job.Name, "path", existing)
+		}
+	}
+
 	// Enqueue the job
 	if err := h.queue.Enqueue(job); err != nil {
-		h.writeError(w, http.StatusInternalServerError, "Failed to enqueue job", err)
+		h.writeEnqueueError(w, "Failed to enqueue job", err)
 		return
 	}
 
-	h.writeSuccess(w, http.StatusCreated, job, "Job created successfully")
+	h.writeSuccess(w, http.StatusCreated, job, message)
 }
 
-func (h *Handlers) GetJobs(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
+// remotePathExists checks remotePath on the seedbox when jobs.validate_remote_path
+// is enabled. Only a definite "not found" rejects the job: if the seedbox can't
+// be reached within the timeout the job is accepted and left to fail or retry
+// like any other transfer.
+func (h *Handlers) remotePathExists(ctx context.Context, remotePath string) bool {
+	jobsConfig := h.config.GetJobs()
+	if !jobsConfig.ValidateRemotePath || h.remotePathChecker == nil {
+		return true
+	}
 
-	filter := models.JobFilter{}
+	ctx, cancel := context.WithTimeout(ctx, jobsConfig.ValidateRemotePathTimeout)
+	defer cancel()
 
-	// Parse status filter
-	if statusStr := query.Get("status"); statusStr != "" {
-		filter.Status = []models.JobStatus{models.JobStatus(statusStr)}
+	exists, err := h.remotePathChecker.Stat(ctx, remotePath)
+	if err != nil {
+		slog.Warn("remote path check failed, accepting job", "remote_path", remotePath, "error", err)
+		return true
 	}
+	return exists
+}
 
-	// Parse category filter
-	if category := query.Get("category"); category != "" {
-		filter.Category = category
+// alreadyPresent reports whether the file a job would download already exists
+// in localDir with the expected size. The file is named after remotePath, or
+// after sourceURL for copyurl jobs. Directories and jobs without a known size
+// are never considered present.
+func alreadyPresent(stat func(name string) (os.FileInfo, error), localDir, remotePath, sourceURL string, size int64) (string, bool) {
+	if size <= 0 {
+		return "", false
 	}
 
-	// Parse priority filters
-	if minPriorityStr := query.Get("min_priority"); minPriorityStr != "" {
-		if minPriority, err := strconv.Atoi(minPriorityStr); err == nil {
-			filter.MinPriority = &minPriority
+	name := path.Base(remotePath)
+	if sourceURL != "" {
+		var err error
+		if name, err = models.SourceURLFileName(sourceURL); err != nil {
+			return "", false
 		}
+	} else if strings.HasSuffix(remotePath, "/") {
+		return "", false
 	}
-	if maxPriorityStr := query.Get("max_priority"); maxPriorityStr != "" {
-		if maxPriority, err := strconv.Atoi(maxPriorityStr); err == nil {
-			filter.MaxPriority = &maxPriority
-		}
+
+	target := filepath.Join(localDir, name)
+	info, err := stat(target)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != size {
+		return "", false
+	}
+
+	return target, true
+}
+
+// encodeJobCursor returns the opaque `after` cursor resuming a job listing
+// at cursor. The sort is part of the cursor so it can't be replayed against a
+// differently ordered listing, and the sort key is tagged with its type so it
+// decodes to the value the database returned.
+func encodeJobCursor(cursor *models.JobCursor, sortBy, sortOrder string) string {
+	var key string
+	switch v := cursor.SortKey.(type) {
+	case int64:
+		key = "i:" + strconv.FormatInt(v, 10)
+	case float64:
+		key = "f:" + strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		key = "s:" + v
+	case []byte:
+		key = "s:" + string(v)
+	default:
+		key = "n:"
+	}
+	raw := fmt.Sprintf("%s:%s:%d:%s", sortBy, strings.ToLower(sortOrder), cursor.ID, key)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeJobCursor parses a cursor from encodeJobCursor, checking it was issued
+// for the same sort.
+func decodeJobCursor(cursor, sortBy, sortOrder string) (*models.JobCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	// The sort key comes last since string keys may contain colons
+	parts := strings.SplitN(string(raw), ":", 5)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	if parts[0] != sortBy || parts[1] != strings.ToLower(sortOrder) {
+		return nil, fmt.Errorf("cursor was issued for a different sort order")
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	decoded := &models.JobCursor{ID: id}
+	switch parts[3] {
+	case "i":
+		decoded.SortKey, err = strconv.ParseInt(parts[4], 10, 64)
+	case "f":
+		decoded.SortKey, err = strconv.ParseFloat(parts[4], 64)
+	case "s":
+		decoded.SortKey = parts[4]
+	case "n":
+	default:
+		err = fmt.Errorf("unknown sort key type")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return decoded, nil
+}
+
+func (h *Handlers) GetJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter, err := jobFilterFromQuery(query)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
 	}
 
 	// Parse pagination
@@ -141,22 +270,33 @@ func (h *Handlers) GetJobs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Parse sorting
-	if sortBy := query.Get("sort_by"); sortBy != "" {
-		filter.SortBy = sortBy
+	// Cursors are tied to the effective sort, defaults included
+	cursorSortBy, cursorSortOrder := filter.SortBy, filter.SortOrder
+	if cursorSortBy == "" {
+		cursorSortBy = "created_at"
 	}
-	if sortOrder := query.Get("sort_order"); sortOrder != "" {
-		filter.SortOrder = sortOrder
+	if cursorSortOrder == "" {
+		cursorSortOrder = "desc"
+	}
+	if after := query.Get("after"); after != "" {
+		cursor, err := decodeJobCursor(after, cursorSortBy, cursorSortOrder)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid after cursor", err)
+			return
+		}
+		filter.After = cursor
+		filter.Offset = 0
 	}
 
-	jobs, err := h.queue.GetJobs(filter)
+	jobs, err := h.queue.GetJobsContext(r.Context(), filter)
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "Failed to get jobs", err)
 		return
 	}
+	h.applyLiveProgress(jobs...)
 
 	// Get total count for pagination
-	totalCount, err := h.queue.CountJobs(filter)
+	totalCount, err := h.queue.CountJobsContext(r.Context(), filter)
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "Failed to count jobs", err)
 		return
@@ -179,8 +319,145 @@ func (h *Handlers) GetJobs(w http.ResponseWriter, r *http.Request) {
 		TotalPages: totalPages,
 		Page:       currentPage,
 	}
+	if len(jobs) > 0 && len(jobs) == filter.Limit {
+		pagination.NextCursor = encodeJobCursor(jobs[len(jobs)-1].Cursor(), cursorSortBy, cursorSortOrder)
+	}
+
+	h.writeCacheableSuccess(w, r, jobs, pagination)
+}
+
+// jobFilterFromQuery parses the filter and sort parameters shared by the job
+// list and export endpoints. Pagination is left to the caller.
+func jobFilterFromQuery(query url.Values) (models.JobFilter, error) {
+	filter := models.JobFilter{}
+
+	// Parse status filter
+	if statusStr := query.Get("status"); statusStr != "" {
+		filter.Status = []models.JobStatus{models.JobStatus(statusStr)}
+	}
+
+	// Parse category filter; repeat the parameter to match any of several
+	for _, category := range query["category"] {
+		if category != "" {
+			filter.Categories = append(filter.Categories, category)
+		}
+	}
+
+	// Parse group filter
+	if groupID := query.Get("group_id"); groupID != "" {
+		filter.GroupID = groupID
+	}
+
+	if hash := query.Get("hash"); hash != "" {
+		filter.Hash = hash
+	}
+
+	filter.IncludeDeleted = query.Get("include_deleted") == "true"
+
+	// Parse priority filters
+	if minPriorityStr := query.Get("min_priority"); minPriorityStr != "" {
+		if minPriority, err := strconv.Atoi(minPriorityStr); err == nil {
+			filter.MinPriority = &minPriority
+		}
+	}
+	if maxPriorityStr := query.Get("max_priority"); maxPriorityStr != "" {
+		if maxPriority, err := strconv.Atoi(maxPriorityStr); err == nil {
+			filter.MaxPriority = &maxPriority
+		}
+	}
+
+	// Parse sorting
+	if sortBy := query.Get("sort_by"); sortBy != "" {
+		if !models.IsValidJobSortField(sortBy) {
+			return filter, fmt.Errorf("invalid sort_by '%s'. Allowed: %s", sortBy, strings.Join(models.JobSortFields, ", "))
+		}
+		filter.SortBy = sortBy
+	}
+	if sortOrder := query.Get("sort_order"); sortOrder != "" {
+		if !models.IsValidSortOrder(sortOrder) {
+			return filter, errors.New("invalid sort_order. Allowed: asc, desc")
+		}
+		filter.SortOrder = sortOrder
+	}
+
+	return filter, nil
+}
+
+// exportBatchSize is how many jobs the CSV export loads per query.
+const exportBatchSize = 500
+
+// jobExportColumns is the CSV header row written by ExportJobs.
+var jobExportColumns = []string{"id", "name", "remote_path", "status", "size_bytes", "speed_bytes_per_sec", "created_at", "completed_at", "error"}
+
+// ExportJobs streams every job matching the GetJobs filters as CSV. Jobs are
+// loaded in keyset-paginated batches and flushed as they are written, so large
+// exports never sit in memory; limit, offset and after are ignored.
+func (h *Handlers) ExportJobs(w http.ResponseWriter, r *http.Request) {
+	filter, err := jobFilterFromQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	filter.Limit = exportBatchSize
+
+	// Load the first batch before committing to a CSV response, so a failing
+	// query still gets a JSON error
+	jobs, err := h.queue.GetJobsContext(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get jobs", err)
+		return
+	}
+
+	filename := fmt.Sprintf("grabarr-jobs-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	cw := csv.NewWriter(w)
+	cw.Write(jobExportColumns)
+
+	for {
+		for _, job := range jobs {
+			cw.Write(jobExportRow(job))
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			slog.Warn("job export aborted", "error", err)
+			return
+		}
+		rc.Flush()
+
+		if len(jobs) < exportBatchSize {
+			return
+		}
+		filter.After = jobs[len(jobs)-1].Cursor()
+
+		jobs, err = h.queue.GetJobsContext(r.Context(), filter)
+		if err != nil {
+			// Headers are already sent; a truncated file is all we can signal
+			slog.Error("job export failed mid-stream", "after_id", filter.After.ID, "error", err)
+			return
+		}
+	}
+}
 
-	h.writeSuccessWithPagination(w, http.StatusOK, jobs, pagination, "")
+func jobExportRow(job *models.Job) []string {
+	completedAt := ""
+	if job.CompletedAt != nil {
+		completedAt = job.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(job.ID, 10),
+		job.Name,
+		job.RemotePath,
+		string(job.Status),
+		strconv.FormatInt(job.FileSize, 10),
+		strconv.FormatInt(job.TransferSpeed, 10),
+		job.CreatedAt.UTC().Format(time.RFC3339),
+		completedAt,
+		job.ErrorMessage,
+	}
 }
 
 func (h *Handlers) GetJob(w http.ResponseWriter, r *http.Request) {
@@ -191,15 +468,68 @@ func (h *Handlers) GetJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	job, err := h.queue.GetJob(id)
+	job, err := h.queue.GetJobContext(r.Context(), id)
 	if err != nil {
 		h.writeError(w, http.StatusNotFound, "Job not found", err)
 		return
 	}
+	h.applyLiveProgress(job)
 
 	h.writeSuccess(w, http.StatusOK, job, "")
 }
 
+// GetJobAttempts returns a job's execution attempts, newest first.
+func (h *Handlers) GetJobAttempts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	if _, err := h.queue.GetJob(id); err != nil {
+		h.writeError(w, http.StatusNotFound, "Job not found", err)
+		return
+	}
+
+	attempts, err := h.queue.GetJobAttempts(id)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get job attempts", err)
+		return
+	}
+	if attempts == nil {
+		attempts = []*models.JobAttempt{}
+	}
+
+	h.writeSuccess(w, http.StatusOK, attempts, "")
+}
+
+// GetJobAudit returns a job's audit log of state transitions, oldest first.
+func (h *Handlers) GetJobAudit(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	if _, err := h.queue.GetJob(id); err != nil {
+		h.writeError(w, http.StatusNotFound, "Job not found", err)
+		return
+	}
+
+	entries, err := h.queue.GetJobAudit(id)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get job audit log", err)
+		return
+	}
+	if entries == nil {
+		entries = []*models.AuditEntry{}
+	}
+
+	h.writeSuccess(w, http.StatusOK, entries, "")
+}
+
 func (h *Handlers) DeleteJob(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
@@ -216,6 +546,161 @@ func (h *Handlers) DeleteJob(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, http.StatusOK, nil, "Job deleted successfully")
 }
 
+// RestoreJob brings back a deleted job that cleanup hasn't purged yet.
+func (h *Handlers) RestoreJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	if err := h.queue.RestoreJob(id); err != nil {
+		h.writeError(w, http.StatusNotFound, "Deleted job not found", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, nil, "Job restored successfully")
+}
+
+// maxBatchStatusIDs caps how many jobs one batch status request may ask for.
+const maxBatchStatusIDs = 500
+
+type BatchJobStatusRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type BatchJobStatusResponse struct {
+	Jobs    []*models.Job `json:"jobs"`    // found jobs, in request order
+	Missing []int64       `json:"missing"` // requested IDs with no job
+}
+
+// GetJobsStatus returns the current records of several jobs in one call.
+func (h *Handlers) GetJobsStatus(w http.ResponseWriter, r *http.Request) {
+	var req BatchJobStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeDecodeError(w, "Invalid JSON payload", err)
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		h.writeValidationError(w, "ids is required", map[string]string{"ids": "ids is required"})
+		return
+	}
+	if len(req.IDs) > maxBatchStatusIDs {
+		message := fmt.Sprintf("at most %d ids can be requested at once", maxBatchStatusIDs)
+		h.writeValidationError(w, message, map[string]string{"ids": message})
+		return
+	}
+
+	// Drop duplicates, keeping the first occurrence's position
+	ids := make([]int64, 0, len(req.IDs))
+	seen := make(map[int64]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	jobs, err := h.queue.GetJobsByIDs(ids)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get jobs", err)
+		return
+	}
+
+	byID := make(map[int64]*models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	resp := BatchJobStatusResponse{Jobs: []*models.Job{}, Missing: []int64{}}
+	for _, id := range ids {
+		if job, ok := byID[id]; ok {
+			resp.Jobs = append(resp.Jobs, job)
+		} else {
+			resp.Missing = append(resp.Missing, id)
+		}
+	}
+
+	h.writeSuccess(w, http.StatusOK, resp, "")
+}
+
+// PurgeJobsRequest selects finished jobs to delete in bulk. OlderThan is either
+// a duration ("168h", measured back from now) or an RFC3339 timestamp.
+type PurgeJobsRequest struct {
+	Status    []models.JobStatus `json:"status"`
+	OlderThan string             `json:"older_than"`
+}
+
+// purgeableStatuses are the statuses PurgeJobs may delete; active jobs are never purged.
+var purgeableStatuses = map[models.JobStatus]bool{
+	models.JobStatusCompleted: true,
+	models.JobStatusFailed:    true,
+	models.JobStatusCancelled: true,
+}
+
+// PurgeJobs deletes finished jobs with the given statuses older than a cutoff.
+func (h *Handlers) PurgeJobs(w http.ResponseWriter, r *http.Request) {
+	var req PurgeJobsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeDecodeError(w, "Invalid JSON payload", err)
+		return
+	}
+
+	before, fieldErrors, summary := validatePurgeJobsRequest(&req, time.Now())
+	if len(fieldErrors) > 0 {
+		h.writeValidationError(w, summary, fieldErrors)
+		return
+	}
+
+	count, err := h.queue.PurgeJobs(req.Status, before)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to purge jobs", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, map[string]int{"deleted": count}, fmt.Sprintf("Purged %d jobs", count))
+}
+
+// validatePurgeJobsRequest checks a purge request and returns the cutoff time.
+func validatePurgeJobsRequest(req *PurgeJobsRequest, now time.Time) (time.Time, map[string]string, string) {
+	fieldErrors := make(map[string]string)
+	var summary string
+	addError := func(field, message string) {
+		fieldErrors[field] = message
+		if summary == "" {
+			summary = message
+		}
+	}
+
+	if len(req.Status) == 0 {
+		addError("status", "at least one status is required")
+	}
+	for _, status := range req.Status {
+		if !purgeableStatuses[status] {
+			addError("status", fmt.Sprintf("cannot purge jobs with status %q; only completed, failed, and cancelled jobs can be purged", status))
+			break
+		}
+	}
+
+	var before time.Time
+	if req.OlderThan == "" {
+		addError("older_than", "older_than is required")
+	} else if d, err := time.ParseDuration(req.OlderThan); err == nil {
+		if d < 0 {
+			addError("older_than", "older_than duration cannot be negative")
+		}
+		before = now.Add(-d)
+	} else if t, err := time.Parse(time.RFC3339, req.OlderThan); err == nil {
+		before = t
+	} else {
+		addError("older_than", "older_than must be a duration (e.g. 168h) or an RFC3339 timestamp")
+	}
+
+	return before, fieldErrors, summary
+}
+
 func (h *Handlers) CancelJob(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
@@ -232,6 +717,56 @@ func (h *Handlers) CancelJob(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, http.StatusOK, nil, "Job cancelled successfully")
 }
 
+// CancelJobByHash cancels the most recent job for a qBittorrent torrent hash.
+func (h *Handlers) CancelJobByHash(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)["hash"]
+
+	job, err := h.queue.GetJobByHash(hash)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "Job not found", err)
+		return
+	}
+
+	if err := h.queue.CancelJob(job.ID); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to cancel job", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, map[string]int64{"id": job.ID}, "Job cancelled successfully")
+}
+
+type CancelByPathRequest struct {
+	RemotePath string `json:"remote_path"`
+}
+
+// CancelJobByPath cancels the most recent unfinished job for a remote path, for
+// integrations that know what they asked for but not the job ID.
+func (h *Handlers) CancelJobByPath(w http.ResponseWriter, r *http.Request) {
+	var req CancelByPathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeDecodeError(w, "Invalid JSON payload", err)
+		return
+	}
+
+	if strings.TrimSpace(req.RemotePath) == "" {
+		h.writeValidationError(w, "remote_path is required", map[string]string{"remote_path": "remote_path is required"})
+		return
+	}
+
+	job, err := h.queue.GetActiveJobByRemotePath(req.RemotePath)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "No active job for remote path", err)
+		return
+	}
+
+	if err := h.queue.CancelJob(job.ID); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to cancel job", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, map[string]int64{"id": job.ID}, "Job cancelled successfully")
+}
+
 func (h *Handlers) RetryJob(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
@@ -248,6 +783,28 @@ func (h *Handlers) RetryJob(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, http.StatusOK, nil, "Job retried successfully")
 }
 
+// ForceStartJob starts a queued or pending job immediately, overriding the
+// gatekeeper. It still fails with 409 when max_concurrent jobs are running.
+func (h *Handlers) ForceStartJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	if err := h.queue.ForceSchedule(id); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, interfaces.ErrMaxConcurrent) {
+			status = http.StatusConflict
+		}
+		h.writeError(w, status, fmt.Sprintf("Failed to force-start job: %v", err), err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, nil, "Job force-started")
+}
+
 func (h *Handlers) GetJobSummary(w http.ResponseWriter, r *http.Request) {
 	summary, err := h.queue.GetSummary()
 	if err != nil {
@@ -255,7 +812,150 @@ func (h *Handlers) GetJobSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeSuccess(w, http.StatusOK, summary, "")
+	h.writeCacheableSuccess(w, r, summary, nil)
+}
+
+// GetJobsByCategory returns job counts by status and transferred bytes for each
+// category.
+func (h *Handlers) GetJobsByCategory(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.queue.GetJobStatsByCategory()
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get job stats by category", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, stats, "")
+}
+
+// validateCreateJobRequest checks every field of a create request and returns the
+// normalized remote path. Field errors are keyed by JSON field name; the summary is
+// the first error found, in field order.
+func validateCreateJobRequest(req *CreateJobRequest, allowedCategories []string, remoteRoot string, jobs config.JobsConfig) (string, map[string]string, string) {
+	fieldErrors := make(map[string]string)
+	var summary string
+	addError := func(field, message string) {
+		fieldErrors[field] = message
+		if summary == "" {
+			summary = message
+		}
+	}
+
+	// Without a name the job is named from jobs.name_template once the source is known
+	if req.Name == "" && jobs.NameTemplate == "" {
+		addError("name", "job name is required")
+	}
+
+	// A job copies either a seedbox path or, for copyurl jobs, an HTTP(S) URL
+	var remotePath, base string
+	req.SourceURL = strings.TrimSpace(req.SourceURL)
+	switch {
+	case req.RemotePath != "" && req.SourceURL != "":
+		addError("source_url", "source_url cannot be combined with remote_path")
+	case req.SourceURL != "":
+		if name, err := models.SourceURLFileName(req.SourceURL); err != nil {
+			addError("source_url", err.Error())
+		} else {
+			base = name
+		}
+	case req.RemotePath == "":
+		addError("remote_path", "remote_path or source_url is required")
+	default:
+		if normalized, err := models.ResolveRemotePath(remoteRoot, req.RemotePath); err != nil {
+			addError("remote_path", fmt.Sprintf("invalid remote_path: %v", err))
+		} else {
+			remotePath = normalized
+			base = path.Base(strings.TrimSuffix(remotePath, "/"))
+		}
+	}
+
+	if req.Name == "" && jobs.NameTemplate != "" && base != "" {
+		name, err := jobs.RenderJobName(config.JobNameData{
+			RemotePath: remotePath,
+			SourceURL:  req.SourceURL,
+			Base:       base,
+			Category:   req.Metadata.Category,
+			Metadata:   req.Metadata,
+		})
+		if err != nil {
+			addError("name", fmt.Sprintf("job name is required: name_template failed: %v", err))
+		} else {
+			req.Name = name
+		}
+	}
+
+	// Validate local_path doesn't try to escape base directory
+	if req.LocalPath == "" {
+		addError("local_path", "local_path is required")
+	} else if filepath.IsAbs(req.LocalPath) {
+		addError("local_path", "local_path must be a relative path")
+	} else if cleanPath := filepath.Clean(req.LocalPath); strings.HasPrefix(cleanPath, "..") || strings.Contains(cleanPath, "/../") {
+		addError("local_path", "local_path cannot escape base directory")
+	}
+
+	// Check category filtering
+	if len(allowedCategories) > 0 {
+		category := req.Metadata.Category
+		if category == "" || !contains(allowedCategories, category) {
+			addError("metadata.category", fmt.Sprintf("category '%s' not allowed. Allowed categories: %v",
+				category, allowedCategories))
+		}
+	}
+
+	// Priority 0 means unset and takes jobs.default_priority in the queue
+	if req.Priority != 0 && !jobs.PriorityInRange(req.Priority) {
+		if jobs.PriorityOutOfRange == config.PriorityOutOfRangeClamp {
+			req.Priority = jobs.ClampPriority(req.Priority)
+		} else {
+			addError("priority", priorityRangeMessage(jobs))
+		}
+	}
+
+	dcErrors := req.DownloadConfig.Validate()
+	dcFields := make([]string, 0, len(dcErrors))
+	for field := range dcErrors {
+		dcFields = append(dcFields, field)
+	}
+	sort.Strings(dcFields)
+	for _, field := range dcFields {
+		addError("download_config."+field, dcErrors[field])
+	}
+
+	return remotePath, fieldErrors, summary
+}
+
+// GetJobGroup returns all jobs sharing a group ID along with a rolled-up status.
+func (h *Handlers) GetJobGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := mux.Vars(r)["groupId"]
+
+	jobs, err := h.queue.GetJobs(models.JobFilter{
+		GroupID:   groupID,
+		SortBy:    "created_at",
+		SortOrder: "ASC",
+	})
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get job group", err)
+		return
+	}
+
+	if len(jobs) == 0 {
+		h.writeError(w, http.StatusNotFound, "Job group not found", nil)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, models.NewJobGroup(groupID, jobs), "")
+}
+
+// priorityRangeMessage describes the configured priority bounds for a
+// validation error.
+func priorityRangeMessage(jobs config.JobsConfig) string {
+	switch {
+	case jobs.MinPriority != nil && jobs.MaxPriority != nil:
+		return fmt.Sprintf("priority must be between %d and %d", *jobs.MinPriority, *jobs.MaxPriority)
+	case jobs.MinPriority != nil:
+		return fmt.Sprintf("priority must be at least %d", *jobs.MinPriority)
+	default:
+		return fmt.Sprintf("priority must be at most %d", *jobs.MaxPriority)
+	}
 }
 
 // Helper function to check if a slice contains a string
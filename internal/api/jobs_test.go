@@ -1,18 +1,28 @@
 package api
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"grabarr/internal/config"
 	"grabarr/internal/interfaces"
 	"grabarr/internal/mocks"
 	"grabarr/internal/models"
+	"grabarr/internal/testutil"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -65,6 +75,45 @@ func TestCreateJob_Success(t *testing.T) {
 	assert.Equal(t, "/downloads/test-file.mkv", jobData["local_path"])
 }
 
+func TestCreateJob_RemoteRoot(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.RemotePath == "/home/user/downloads/movies/x.mkv"
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{
+		Downloads: config.DownloadsConfig{LocalPath: "/downloads/"},
+		Remotes:   []config.RemoteConfig{{Name: "seedbox", RemoteRoot: "/home/user/downloads"}},
+	}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	reqBody := `{"name":"test-job","remote_path":"movies/x.mkv","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_RemoteRootRejectsTraversal(t *testing.T) {
+	cfg := &config.Config{
+		Remotes: []config.RemoteConfig{{Name: "seedbox", RemoteRoot: "/home/user/downloads"}},
+	}
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, cfg, nil, nil)
+
+	reqBody := `{"name":"test-job","remote_path":"../../.ssh/id_rsa","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestCreateJob_MissingName(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 	cfg := &config.Config{}
@@ -87,6 +136,122 @@ func TestCreateJob_MissingName(t *testing.T) {
 	assert.Equal(t, "job name is required", response.Error)
 }
 
+func TestCreateJob_SourceURL(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.SourceURL == "https://example.com/files/movie.mkv" &&
+				job.RemotePath == "" &&
+				job.LocalPath == "/downloads/movies"
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads/"}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	reqBody := `{"name":"movie","source_url":" https://example.com/files/movie.mkv ","local_path":"movies"}`
+	rec := httptest.NewRecorder()
+	handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_SourceURLValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		want  string
+	}{
+		{
+			name:  "both sources",
+			body:  `{"name":"x","remote_path":"/remote/x.mkv","source_url":"https://example.com/x.mkv","local_path":"x"}`,
+			field: "source_url",
+			want:  "source_url cannot be combined with remote_path",
+		},
+		{
+			name:  "unsupported scheme",
+			body:  `{"name":"x","source_url":"ftp://example.com/x.mkv","local_path":"x"}`,
+			field: "source_url",
+			want:  "source URL must use http or https: ftp://example.com/x.mkv",
+		},
+		{
+			name:  "no file name",
+			body:  `{"name":"x","source_url":"https://example.com/","local_path":"x"}`,
+			field: "source_url",
+			want:  "source URL does not name a file: https://example.com/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+			rec := httptest.NewRecorder()
+			handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(tt.body)))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var response APIResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Equal(t, tt.want, response.FieldErrors[tt.field])
+		})
+	}
+}
+
+func TestCreateJob_NameTemplate(t *testing.T) {
+	cfg := &config.Config{
+		Downloads: config.DownloadsConfig{LocalPath: "/downloads/"},
+		Jobs:      config.JobsConfig{NameTemplate: "{{.Category}}: {{.Base}}"},
+	}
+
+	t.Run("derived when name is empty", func(t *testing.T) {
+		mockQueue := mocks.NewMockJobQueue(t)
+		mockQueue.EXPECT().
+			Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+				return job.Name == "movies: Some.Movie.2024"
+			})).
+			Return(nil).
+			Once()
+		handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+		reqBody := `{"remote_path":"/remote/Some.Movie.2024/","local_path":"movies","metadata":{"category":"movies"}}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("provided name is kept", func(t *testing.T) {
+		mockQueue := mocks.NewMockJobQueue(t)
+		mockQueue.EXPECT().
+			Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+				return job.Name == "my-job"
+			})).
+			Return(nil).
+			Once()
+		handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+		reqBody := `{"name":"my-job","remote_path":"/remote/Some.Movie.2024/","local_path":"movies","metadata":{"category":"movies"}}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("empty result is rejected", func(t *testing.T) {
+		cfg := &config.Config{Jobs: config.JobsConfig{NameTemplate: "{{.Category}}"}}
+		handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, cfg, nil, nil)
+
+		reqBody := `{"remote_path":"/remote/x.mkv","local_path":"movies"}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "name_template produced an empty name")
+	})
+}
+
 func TestCreateJob_MissingRemotePath(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 	cfg := &config.Config{}
@@ -106,7 +271,7 @@ func TestCreateJob_MissingRemotePath(t *testing.T) {
 	err := json.NewDecoder(rec.Body).Decode(&response)
 	require.NoError(t, err)
 	assert.False(t, response.Success)
-	assert.Equal(t, "remote_path is required", response.Error)
+	assert.Equal(t, "remote_path or source_url is required", response.Error)
 }
 
 func TestCreateJob_MissingLocalPath(t *testing.T) {
@@ -175,40 +340,277 @@ func TestCreateJob_LocalPathAbsolute(t *testing.T) {
 	assert.Equal(t, "local_path must be a relative path", response.Error)
 }
 
-func TestCreateJob_InvalidJSON(t *testing.T) {
+func TestCreateJob_NormalizesRemotePath(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.RemotePath == "/downloads/Show.S01/"
+		})).
+		Return(nil).
+		Once()
 
-	reqBody := `{invalid json`
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads"}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"  //downloads//Show.S01// ","local_path":"tv"}`
 	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
 	rec := httptest.NewRecorder()
 
 	handlers.CreateJob(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_NormalizesWindowsRemotePath(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.RemotePath == "/downloads/movies/Movie.mkv"
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads"}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"\\\\downloads\\movies\\Movie.mkv","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+// fakeStat serves os.Stat-style lookups from an in-memory filesystem keyed by absolute path.
+func fakeStat(files fstest.MapFS) func(name string) (os.FileInfo, error) {
+	return func(name string) (os.FileInfo, error) {
+		return fs.Stat(files, strings.TrimPrefix(name, "/"))
+	}
+}
+
+func TestAlreadyPresent(t *testing.T) {
+	stat := fakeStat(fstest.MapFS{
+		"downloads/movies/Movie.mkv": {Data: make([]byte, 100)},
+		"downloads/movies/Show.S01":  {Mode: fs.ModeDir},
+	})
+
+	tests := []struct {
+		name       string
+		remotePath string
+		sourceURL  string
+		size       int64
+		want       bool
+	}{
+		{"present with matching size", "/remote/Movie.mkv", "", 100, true},
+		{"present with different size", "/remote/Movie.mkv", "", 200, false},
+		{"unknown size", "/remote/Movie.mkv", "", 0, false},
+		{"missing", "/remote/Other.mkv", "", 100, false},
+		{"directory job", "/remote/Show.S01/", "", 100, false},
+		{"copyurl present", "", "https://example.com/files/Movie.mkv?token=abc", 100, true},
+		{"copyurl missing", "", "https://example.com/files/Other.mkv", 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := alreadyPresent(stat, "/downloads/movies", tt.remotePath, tt.sourceURL, tt.size)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestCreateJob_SkipExisting_Present(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusCompleted &&
+				job.Metadata.ExtraFields["skip_reason"] == "already present locally"
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads", SkipExisting: true}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.statFile = fakeStat(fstest.MapFS{
+		"downloads/movies/Movie.mkv": {Data: make([]byte, 100)},
+	})
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"movies","file_size":100}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
 
 	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
-	require.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Invalid JSON payload", response.Error)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "Job skipped: file already present locally", response.Message)
 }
 
-func TestCreateJob_CategoryNotAllowed(t *testing.T) {
+func TestCreateJob_SkipExisting_CopyURL(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
-	cfg := &config.Config{
-		Downloads: config.DownloadsConfig{
-			AllowedCategories: []string{"movies", "tv"},
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusCompleted &&
+				job.Metadata.ExtraFields["skip_reason"] == "already present locally"
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads", SkipExisting: true}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.statFile = fakeStat(fstest.MapFS{
+		"downloads/movies/Movie.mkv": {Data: make([]byte, 100)},
+	})
+
+	reqBody := `{"name":"Movie","source_url":"https://example.com/dl/Movie.mkv?sig=1","local_path":"movies","file_size":100}`
+	rec := httptest.NewRecorder()
+	handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_SkipExisting_Missing(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusQueued
+		})).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads", SkipExisting: true}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.statFile = fakeStat(fstest.MapFS{})
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"movies","file_size":100}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "Job created successfully", response.Message)
+}
+
+func TestCreateJob_SkipExisting_DestTemplate(t *testing.T) {
+	year := time.Now().Format("2006")
+	downloads := config.DownloadsConfig{
+		LocalPath:     "/downloads",
+		SkipExisting:  true,
+		DestTemplates: map[string]string{"movies": "{category}/{year}"},
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		files      fstest.MapFS
+		wantStatus models.JobStatus
+		wantPath   string
+	}{
+		{
+			name:       "present in the template directory",
+			body:       `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"films","file_size":100,"metadata":{"category":"movies"}}`,
+			files:      fstest.MapFS{"downloads/films/movies/" + year + "/Movie.mkv": {Data: make([]byte, 100)}},
+			wantStatus: models.JobStatusCompleted,
+			wantPath:   "/downloads/films/movies/" + year,
+		},
+		{
+			name:       "same file in the base directory only",
+			body:       `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"films","file_size":100,"metadata":{"category":"movies"}}`,
+			files:      fstest.MapFS{"downloads/films/Movie.mkv": {Data: make([]byte, 100)}},
+			wantStatus: models.JobStatusQueued,
+			wantPath:   "/downloads/films/movies/" + year,
+		},
+		{
+			name:       "job template",
+			body:       `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"films","file_size":100,"download_config":{"dest_template":"{name}"}}`,
+			files:      fstest.MapFS{"downloads/films/Movie/Movie.mkv": {Data: make([]byte, 100)}},
+			wantStatus: models.JobStatusCompleted,
+			wantPath:   "/downloads/films/Movie",
 		},
 	}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
 
-	reqBody := `{"name":"test","remote_path":"/path","local_path":"test.mkv","metadata":{"category":"music"}}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enqueued *models.Job
+			mockQueue := mocks.NewMockJobQueue(t)
+			mockQueue.EXPECT().Enqueue(mock.AnythingOfType("*models.Job")).RunAndReturn(func(job *models.Job) error {
+				enqueued = job
+				return nil
+			}).Once()
+
+			handlers := NewHandlers(mockQueue, nil, &config.Config{Downloads: downloads}, nil, nil)
+			handlers.statFile = fakeStat(tt.files)
+
+			rec := httptest.NewRecorder()
+			handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(tt.body)))
+
+			require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+			require.NotNil(t, enqueued)
+			assert.Equal(t, tt.wantStatus, enqueued.Status)
+			// The transfer uses the same resolved directory
+			assert.Equal(t, tt.wantPath, enqueued.LocalPath)
+			assert.Contains(t, enqueued.Metadata.ExtraFields, models.DestSubpathField)
+		})
+	}
+}
+
+func TestCreateJob_DestTemplateUnresolvable(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{Downloads: config.DownloadsConfig{LocalPath: "/downloads"}}, nil, nil)
+
+	body := `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"films","download_config":{"dest_template":"{extra.show}"}}`
+	rec := httptest.NewRecorder()
+	handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Contains(t, response.FieldErrors, "download_config.dest_template")
+}
+
+type fakeRemotePathChecker func(ctx context.Context, remotePath string) (bool, error)
+
+func (f fakeRemotePathChecker) Stat(ctx context.Context, remotePath string) (bool, error) {
+	return f(ctx, remotePath)
+}
+
+func TestCreateJob_ValidateRemotePath_Present(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().Enqueue(mock.AnythingOfType("*models.Job")).Return(nil).Once()
+
+	cfg := &config.Config{Jobs: config.JobsConfig{ValidateRemotePath: true, ValidateRemotePathTimeout: time.Second}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.SetRemotePathChecker(fakeRemotePathChecker(func(ctx context.Context, remotePath string) (bool, error) {
+		assert.Equal(t, "/remote/Movie.mkv", remotePath)
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return true, nil
+	}))
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_ValidateRemotePath_Absent(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	cfg := &config.Config{Jobs: config.JobsConfig{ValidateRemotePath: true}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.SetRemotePathChecker(fakeRemotePathChecker(func(ctx context.Context, remotePath string) (bool, error) {
+		return false, nil
+	}))
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Missing.mkv","local_path":"movies"}`
 	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
 	rec := httptest.NewRecorder()
 
@@ -217,57 +619,1124 @@ func TestCreateJob_CategoryNotAllowed(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
-	require.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Contains(t, response.Error, "category 'music' not allowed")
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "remote path not found", response.Error)
+	assert.Equal(t, "remote path not found", response.FieldErrors["remote_path"])
 }
 
-func TestCreateJob_EnqueueError(t *testing.T) {
+func TestCreateJob_ValidateRemotePath_CheckFailsOpen(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().Enqueue(mock.AnythingOfType("*models.Job")).Return(nil).Once()
+
+	cfg := &config.Config{Jobs: config.JobsConfig{ValidateRemotePath: true, ValidateRemotePathTimeout: 10 * time.Millisecond}}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	handlers.SetRemotePathChecker(fakeRemotePathChecker(func(ctx context.Context, remotePath string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}))
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Movie.mkv","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_ValidateRemotePath_Disabled(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().Enqueue(mock.AnythingOfType("*models.Job")).Return(nil).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+	handlers.SetRemotePathChecker(fakeRemotePathChecker(func(ctx context.Context, remotePath string) (bool, error) {
+		t.Fatal("remote path checked while validate_remote_path is off")
+		return false, nil
+	}))
+
+	reqBody := `{"name":"Movie","remote_path":"/remote/Missing.mkv","local_path":"movies"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_QueueFull(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 	mockQueue.EXPECT().
 		Enqueue(mock.AnythingOfType("*models.Job")).
-		Return(errors.New("queue is full")).
+		Return(fmt.Errorf("%w: 100 jobs waiting (max 100)", interfaces.ErrQueueFull)).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	reqBody := `{"name":"test","remote_path":"/path","local_path":"test.mkv"}`
+	reqBody := `{"name":"test","remote_path":"/remote/file.mkv","local_path":"movies"}`
 	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
 	rec := httptest.NewRecorder()
 
 	handlers.CreateJob(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "Job queue is full, try again later", response.Error)
+}
+
+func TestCreateJob_RemotePathTraversal(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"/downloads/../etc/passwd","local_path":"test.mkv"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 	var response APIResponse
 	err := json.NewDecoder(rec.Body).Decode(&response)
 	require.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Failed to enqueue job", response.Error)
+	assert.Contains(t, response.Error, "invalid remote_path")
 }
 
-func TestGetJobs_Success(t *testing.T) {
+func TestCreateJob_FieldErrors(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
-
-	testJobs := []*models.Job{
-		{ID: 1, Name: "job1", Status: models.JobStatusQueued},
-		{ID: 2, Name: "job2", Status: models.JobStatusRunning},
+	cfg := &config.Config{
+		Downloads: config.DownloadsConfig{
+			AllowedCategories: []string{"movies", "tv"},
+		},
 	}
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	reqBody := `{"remote_path":"/downloads/../etc","local_path":"test.mkv","metadata":{"category":"music"}}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+
+	// Summary is the first failing field
+	assert.Equal(t, "job name is required", response.Error)
+
+	require.Len(t, response.FieldErrors, 3)
+	assert.Equal(t, "job name is required", response.FieldErrors["name"])
+	assert.Contains(t, response.FieldErrors["remote_path"], "invalid remote_path")
+	assert.Contains(t, response.FieldErrors["metadata.category"], "category 'music' not allowed")
+	assert.NotContains(t, response.FieldErrors, "local_path")
+}
+
+func TestCreateJob_DownloadConfig(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.MatchedBy(func(job *models.Job) bool {
+			dc := job.DownloadConfig
+			return dc != nil && *dc.Transfers == 8 && *dc.Checkers == 16 && *dc.BwLimit == "50M" && *dc.BufferSize == "64M"
+		})).
+		Return(nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"/downloads/big.mkv","local_path":"movies",
+		"download_config":{"transfers":8,"checkers":16,"bw_limit":"50M","buffer_size":"64M"}}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateJob_DownloadConfigOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		field  string
+	}{
+		{"transfers too high", `{"transfers":33}`, "download_config.transfers"},
+		{"transfers zero", `{"transfers":0}`, "download_config.transfers"},
+		{"checkers negative", `{"checkers":-1}`, "download_config.checkers"},
+		{"bw_limit timetable", `{"bw_limit":"08:00,512k 19:00,off"}`, "download_config.bw_limit"},
+		{"buffer_size garbage", `{"buffer_size":"lots"}`, "download_config.buffer_size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+			reqBody := `{"name":"test","remote_path":"/downloads/big.mkv","local_path":"movies","download_config":` + tt.config + `}`
+			req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			handlers.CreateJob(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response APIResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			require.Len(t, response.FieldErrors, 1)
+			assert.Contains(t, response.FieldErrors, tt.field)
+		})
+	}
+}
+
+func TestCreateJob_PriorityOutOfRange(t *testing.T) {
+	minPriority, maxPriority := 1, 10
+	jobsCfg := config.JobsConfig{MinPriority: &minPriority, MaxPriority: &maxPriority}
+
+	t.Run("rejected by default", func(t *testing.T) {
+		handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{Jobs: jobsCfg}, nil, nil)
+
+		reqBody := `{"name":"test","remote_path":"/downloads/a.mkv","local_path":"movies","priority":50}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var response APIResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, "priority must be between 1 and 10", response.FieldErrors["priority"])
+	})
+
+	t.Run("clamped when configured", func(t *testing.T) {
+		clampCfg := jobsCfg
+		clampCfg.PriorityOutOfRange = config.PriorityOutOfRangeClamp
+
+		mockQueue := mocks.NewMockJobQueue(t)
+		mockQueue.EXPECT().
+			Enqueue(mock.MatchedBy(func(job *models.Job) bool { return job.Priority == 10 })).
+			Return(nil).
+			Once()
+		handlers := NewHandlers(mockQueue, nil, &config.Config{Jobs: clampCfg}, nil, nil)
+
+		reqBody := `{"name":"test","remote_path":"/downloads/a.mkv","local_path":"movies","priority":50}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("unset priority is left for the queue default", func(t *testing.T) {
+		mockQueue := mocks.NewMockJobQueue(t)
+		mockQueue.EXPECT().
+			Enqueue(mock.MatchedBy(func(job *models.Job) bool { return job.Priority == 0 })).
+			Return(nil).
+			Once()
+		handlers := NewHandlers(mockQueue, nil, &config.Config{Jobs: jobsCfg}, nil, nil)
+
+		reqBody := `{"name":"test","remote_path":"/downloads/a.mkv","local_path":"movies"}`
+		rec := httptest.NewRecorder()
+		handlers.CreateJob(rec, httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+}
+
+func TestCreateJob_InvalidJSON(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	reqBody := `{invalid json`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid JSON payload", response.Error)
+}
+
+func TestCreateJob_CategoryNotAllowed(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{
+		Downloads: config.DownloadsConfig{
+			AllowedCategories: []string{"movies", "tv"},
+		},
+	}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"/path","local_path":"test.mkv","metadata":{"category":"music"}}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Error, "category 'music' not allowed")
+}
+
+func TestCreateJob_CategoryPathRouting(t *testing.T) {
+	tests := []struct {
+		name         string
+		category     string
+		expectedPath string
+	}{
+		{"matched category", "movies", "/media/movies/test.mkv"},
+		{"unmatched category", "anime", "/downloads/test.mkv"},
+		{"no category", "", "/downloads/test.mkv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueue := mocks.NewMockJobQueue(t)
+			mockQueue.EXPECT().
+				Enqueue(mock.AnythingOfType("*models.Job")).
+				Return(nil).
+				Once()
+
+			cfg := &config.Config{
+				Downloads: config.DownloadsConfig{
+					LocalPath: "/downloads",
+					CategoryPaths: map[string]string{
+						"movies": "/media/movies",
+						"tv":     "/media/tv",
+					},
+				},
+			}
+			handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+			reqBody := fmt.Sprintf(`{"name":"test","remote_path":"/path","local_path":"test.mkv","metadata":{"category":%q}}`, tt.category)
+			req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+			rec := httptest.NewRecorder()
+
+			handlers.CreateJob(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+
+			var response APIResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			require.NoError(t, err)
+
+			jobData, ok := response.Data.(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedPath, jobData["local_path"])
+		})
+	}
+}
+
+func TestCreateJob_EnqueueError(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Enqueue(mock.AnythingOfType("*models.Job")).
+		Return(errors.New("queue is full")).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	reqBody := `{"name":"test","remote_path":"/path","local_path":"test.mkv"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(reqBody))
+	rec := httptest.NewRecorder()
+
+	handlers.CreateJob(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Failed to enqueue job", response.Error)
+}
+
+func TestGetJobs_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	testJobs := []*models.Job{
+		{ID: 1, Name: "job1", Status: models.JobStatusQueued},
+		{ID: 2, Name: "job2", Status: models.JobStatusRunning},
+	}
+
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.Limit == 50 // Default limit
+		})).
+		Return(testJobs, nil).
+		Once()
+
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.Anything).
+		Return(len(testJobs), nil).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+}
+
+func TestGetJobs_ETagNotModified(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	testJobs := []*models.Job{{ID: 1, Name: "job1", Status: models.JobStatusQueued}}
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).Return(testJobs, nil).Times(2)
+	mockQueue.EXPECT().CountJobsContext(mock.Anything, mock.Anything).Return(1, nil).Times(2)
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestGetJobs_ETagChangesWithData(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).
+		Return([]*models.Job{{ID: 1, Name: "job1", Status: models.JobStatusQueued}}, nil).Once()
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).
+		Return([]*models.Job{{ID: 1, Name: "job1", Status: models.JobStatusRunning}}, nil).Once()
+	mockQueue.EXPECT().CountJobsContext(mock.Anything, mock.Anything).Return(1, nil).Times(2)
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs", nil))
+	etag := rec.Header().Get("ETag")
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestJobFilterFromQuery_RepeatedCategory(t *testing.T) {
+	query, err := url.ParseQuery("category=movies&category=tv&category=")
+	require.NoError(t, err)
+
+	filter, err := jobFilterFromQuery(query)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"movies", "tv"}, filter.Categories)
+}
+
+func TestGetJobs_WithFilters(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return len(filter.Status) == 1 &&
+				filter.Status[0] == models.JobStatusQueued &&
+				len(filter.Categories) == 1 && filter.Categories[0] == "movies" &&
+				filter.Limit == 10
+		})).
+		Return([]*models.Job{}, nil).
+		Once()
+
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.Anything).
+		Return(0, nil).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs?status=queued&category=movies&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGetJobs_WithPagination(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.Limit == 25 && filter.Offset == 50
+		})).
+		Return([]*models.Job{}, nil).
+		Once()
+
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.Anything).
+		Return(100, nil).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs?limit=25&offset=50", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGetJobs_Error(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.Anything).
+		Return(nil, errors.New("database error")).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestGetJobs_InvalidSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"unknown sort_by", "?sort_by=name%3B%20DROP%20TABLE%20jobs", "invalid sort_by"},
+		{"unknown sort_order", "?sort_by=priority&sort_order=sideways", "invalid sort_order"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueue := mocks.NewMockJobQueue(t)
+			handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("GET", "/api/v1/jobs"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handlers.GetJobs(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response APIResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Contains(t, response.Error, tt.want)
+		})
+	}
+}
+
+func TestGetJobs_CursorPagination(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.Limit == 2 && filter.Offset == 0 &&
+				assert.ObjectsAreEqual(&models.JobCursor{ID: 7, SortKey: int64(3)}, filter.After)
+		})).
+		Return([]*models.Job{{ID: 8, SortKey: int64(3)}, {ID: 9, SortKey: int64(5)}}, nil).
+		Once()
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.Anything).
+		Return(10, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	cursor := encodeJobCursor(&models.JobCursor{ID: 7, SortKey: int64(3)}, "priority", "asc")
+	req := httptest.NewRequest("GET", "/api/v1/jobs?sort_by=priority&sort_order=asc&limit=2&offset=40&after="+cursor, nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.NotNil(t, response.Pagination)
+	next, err := decodeJobCursor(response.Pagination.NextCursor, "priority", "asc")
+	require.NoError(t, err)
+	assert.Equal(t, &models.JobCursor{ID: 9, SortKey: int64(5)}, next)
+}
+
+func TestJobCursor_RoundTrip(t *testing.T) {
+	for _, key := range []interface{}{int64(-2), 33.333333333333336, "2024-01-02 03:04:05.123456789-04:00", "", nil} {
+		cursor := &models.JobCursor{ID: 12, SortKey: key}
+		decoded, err := decodeJobCursor(encodeJobCursor(cursor, "updated_at", "desc"), "updated_at", "desc")
+		require.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	}
+}
+
+func TestGetJobs_InvalidCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"not base64", "?after=!!!"},
+		{"not a cursor", "?after=" + base64.RawURLEncoding.EncodeToString([]byte("hello"))},
+		{"old id-only cursor", "?sort_by=priority&sort_order=asc&after=" + base64.RawURLEncoding.EncodeToString([]byte("priority:asc:7"))},
+		{"bad sort key", "?sort_by=priority&sort_order=asc&after=" + base64.RawURLEncoding.EncodeToString([]byte("priority:asc:7:i:high"))},
+		{"different sort", "?sort_by=name&after=" + encodeJobCursor(&models.JobCursor{ID: 3, SortKey: int64(1)}, "priority", "desc")},
+		{"default sort mismatch", "?after=" + encodeJobCursor(&models.JobCursor{ID: 3, SortKey: "2024-01-01 00:00:00"}, "created_at", "asc")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("GET", "/api/v1/jobs"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handlers.GetJobs(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestGetJob_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	testJob := &models.Job{
+		ID:     123,
+		Name:   "test-job",
+		Status: models.JobStatusRunning,
+		Progress: models.JobProgress{
+			Percentage:     50.0,
+			LastUpdateTime: time.Now(),
+		},
+	}
+
+	mockQueue.EXPECT().
+		GetJobContext(mock.Anything, int64(123)).
+		Return(testJob, nil).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/123", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJob(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+}
+
+type fakeProgressSource map[int64]models.JobProgress
+
+func (f fakeProgressSource) LatestProgress(jobID int64) (models.JobProgress, bool) {
+	progress, ok := f[jobID]
+	return progress, ok
+}
+
+func TestGetJobs_LiveProgress(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	running := &models.Job{ID: 1, Status: models.JobStatusRunning, Progress: models.JobProgress{Percentage: 10}}
+	completed := &models.Job{ID: 2, Status: models.JobStatusCompleted, Progress: models.JobProgress{Percentage: 100}}
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).Return([]*models.Job{running, completed}, nil).Once()
+	mockQueue.EXPECT().CountJobsContext(mock.Anything, mock.Anything).Return(2, nil).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+	handlers.SetProgressSource(fakeProgressSource{
+		1: {Percentage: 42, TransferredBytes: 4200, TransferSpeed: 100},
+		// A stale entry for a finished job is ignored
+		2: {Percentage: 99},
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data []models.Job `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, 42.0, response.Data[0].Progress.Percentage)
+	assert.Equal(t, int64(4200), response.Data[0].TransferredBytes)
+	assert.Equal(t, 100.0, response.Data[1].Progress.Percentage)
+}
+
+func TestGetJob_InvalidID(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/invalid", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "invalid"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJob(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid job ID", response.Error)
+}
+
+func TestGetJob_NotFound(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobContext(mock.Anything, int64(999)).
+		Return(nil, errors.New("job not found")).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/999", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "999"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJob(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestForceStartJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		queueErr   error
+		wantStatus int
+	}{
+		{"started", nil, http.StatusOK},
+		{"at max concurrent", fmt.Errorf("%w (max 2)", interfaces.ErrMaxConcurrent), http.StatusConflict},
+		{"not waiting", errors.New("job is not queued or pending (current status: completed)"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQueue := mocks.NewMockJobQueue(t)
+			mockQueue.EXPECT().ForceSchedule(int64(123)).Return(tt.queueErr).Once()
+
+			handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("POST", "/api/v1/jobs/123/force-start", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": "123"})
+			rec := httptest.NewRecorder()
+
+			handlers.ForceStartJob(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.queueErr != nil {
+				var response APIResponse
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+				assert.Contains(t, response.Error, tt.queueErr.Error())
+			}
+		})
+	}
+}
+
+func TestGetJobsStatus(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		GetJobsByIDs([]int64{3, 99, 1}).
+		Return([]*models.Job{
+			{ID: 1, Name: "one", Status: models.JobStatusCompleted},
+			{ID: 3, Name: "three", Status: models.JobStatusRunning},
+		}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/jobs/status", strings.NewReader(`{"ids":[3,99,1,3]}`))
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobsStatus(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data BatchJobStatusResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.Len(t, response.Data.Jobs, 2)
+	assert.Equal(t, int64(3), response.Data.Jobs[0].ID)
+	assert.Equal(t, int64(1), response.Data.Jobs[1].ID)
+	assert.Equal(t, []int64{99}, response.Data.Missing)
+}
+
+func TestGetJobsStatus_InvalidRequest(t *testing.T) {
+	tooMany := make([]string, maxBatchStatusIDs+1)
+	for i := range tooMany {
+		tooMany[i] = strconv.Itoa(i + 1)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty ids", `{"ids":[]}`},
+		{"missing ids", `{}`},
+		{"too many ids", `{"ids":[` + strings.Join(tooMany, ",") + `]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("POST", "/api/v1/jobs/status", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handlers.GetJobsStatus(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestGetJobAttempts_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().GetJob(int64(123)).Return(&models.Job{ID: 123}, nil).Once()
+	mockQueue.EXPECT().
+		GetJobAttempts(int64(123)).
+		Return([]*models.JobAttempt{
+			{ID: 2, JobID: 123, AttemptNum: 2, Status: models.JobStatusFailed, LogData: "result: failed (retryable)\n"},
+			{ID: 1, JobID: 123, AttemptNum: 1, Status: models.JobStatusFailed},
+		}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/123/attempts", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobAttempts(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	attempts, ok := response.Data.([]interface{})
+	require.True(t, ok)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, "result: failed (retryable)\n", attempts[0].(map[string]interface{})["log_data"])
+}
+
+func TestGetJobAttempts_NotFound(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().GetJob(int64(999)).Return(nil, errors.New("job not found")).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/999/attempts", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "999"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobAttempts(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetJobAudit_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().GetJob(int64(123)).Return(&models.Job{ID: 123}, nil).Once()
+	mockQueue.EXPECT().
+		GetJobAudit(int64(123)).
+		Return([]*models.AuditEntry{
+			{ID: 1, JobID: 123, Event: models.AuditEventCreated},
+			{ID: 2, JobID: 123, Event: models.AuditEventStarted, Message: "attempt 1"},
+		}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/123/audit", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobAudit(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	entries, ok := response.Data.([]interface{})
+	require.True(t, ok)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "created", entries[0].(map[string]interface{})["event"])
+	assert.Equal(t, "attempt 1", entries[1].(map[string]interface{})["message"])
+}
+
+func TestGetJobAudit_NotFound(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().GetJob(int64(999)).Return(nil, errors.New("job not found")).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/999/audit", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "999"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobAudit(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDeleteJob_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		DeleteJob(int64(123)).
+		Return(nil).
+		Once()
+
+	cfg := &config.Config{}
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
+	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/jobs/123", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.DeleteJob(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "Job deleted successfully", response.Message)
+}
+
+func TestRestoreJob_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().RestoreJob(int64(123)).Return(nil).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/jobs/123/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.RestoreJob(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "Job restored successfully", response.Message)
+}
+
+func TestRestoreJob_NotDeleted(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().RestoreJob(int64(123)).Return(errors.New("deleted job 123 not found")).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/jobs/123/restore", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	rec := httptest.NewRecorder()
+
+	handlers.RestoreJob(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetJobs_IncludeDeleted(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.IncludeDeleted
+		})).
+		Return([]*models.Job{}, nil).
+		Once()
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.IncludeDeleted
+		})).
+		Return(0, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs?include_deleted=true", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestPurgeJobs_Duration(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	start := time.Now()
+	mockQueue.EXPECT().
+		PurgeJobs([]models.JobStatus{models.JobStatusCompleted}, mock.MatchedBy(func(before time.Time) bool {
+			cutoff := start.Add(-168 * time.Hour)
+			return !before.Before(cutoff) && before.Sub(cutoff) < time.Minute
+		})).
+		Return(4, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	body := `{"status":["completed"],"older_than":"168h"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs/purge", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handlers.PurgeJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "Purged 4 jobs", response.Message)
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(4), data["deleted"])
+}
+
+func TestPurgeJobs_Timestamp(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockQueue.EXPECT().
+		PurgeJobs([]models.JobStatus{models.JobStatusFailed, models.JobStatusCancelled}, mock.MatchedBy(func(before time.Time) bool {
+			return before.Equal(cutoff)
+		})).
+		Return(0, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	body := `{"status":["failed","cancelled"],"older_than":"2024-01-01T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs/purge", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handlers.PurgeJobs(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestPurgeJobs_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{name: "active status", body: `{"status":["completed","running"],"older_than":"24h"}`, field: "status"},
+		{name: "queued status", body: `{"status":["queued"],"older_than":"24h"}`, field: "status"},
+		{name: "missing status", body: `{"older_than":"24h"}`, field: "status"},
+		{name: "missing older_than", body: `{"status":["completed"]}`, field: "older_than"},
+		{name: "invalid older_than", body: `{"status":["completed"],"older_than":"last week"}`, field: "older_than"},
+		{name: "negative older_than", body: `{"status":["completed"],"older_than":"-1h"}`, field: "older_than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("POST", "/api/v1/jobs/purge", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handlers.PurgeJobs(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response APIResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Contains(t, response.FieldErrors, tt.field)
+		})
+	}
+}
+
+func TestPurgeJobs_Error(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().PurgeJobs(mock.Anything, mock.Anything).Return(0, errors.New("database error")).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	body := `{"status":["completed"],"older_than":"24h"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs/purge", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handlers.PurgeJobs(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
 
-	mockQueue.EXPECT().
-		GetJobs(mock.MatchedBy(func(filter models.JobFilter) bool {
-			return filter.Limit == 50 // Default limit
-		})).
-		Return(testJobs, nil).
-		Once()
+func TestCancelJob_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
 
 	mockQueue.EXPECT().
-		CountJobs(mock.Anything).
-		Return(len(testJobs), nil).
+		CancelJob(int64(123)).
+		Return(nil).
 		Once()
 
 	cfg := &config.Config{}
@@ -275,10 +1744,11 @@ func TestGetJobs_Success(t *testing.T) {
 	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
 	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/123/cancel", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
 	rec := httptest.NewRecorder()
 
-	handlers.GetJobs(rec, req)
+	handlers.CancelJob(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 
@@ -286,24 +1756,15 @@ func TestGetJobs_Success(t *testing.T) {
 	err := json.NewDecoder(rec.Body).Decode(&response)
 	require.NoError(t, err)
 	assert.True(t, response.Success)
+	assert.Equal(t, "Job cancelled successfully", response.Message)
 }
 
-func TestGetJobs_WithFilters(t *testing.T) {
+func TestCancelJob_Error(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
 	mockQueue.EXPECT().
-		GetJobs(mock.MatchedBy(func(filter models.JobFilter) bool {
-			return len(filter.Status) == 1 &&
-				filter.Status[0] == models.JobStatusQueued &&
-				filter.Category == "movies" &&
-				filter.Limit == 10
-		})).
-		Return([]*models.Job{}, nil).
-		Once()
-
-	mockQueue.EXPECT().
-		CountJobs(mock.Anything).
-		Return(0, nil).
+		CancelJob(int64(123)).
+		Return(errors.New("cannot cancel completed job")).
 		Once()
 
 	cfg := &config.Config{}
@@ -311,220 +1772,290 @@ func TestGetJobs_WithFilters(t *testing.T) {
 	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
 	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs?status=queued&category=movies&limit=10", nil)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/123/cancel", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "123"})
 	rec := httptest.NewRecorder()
 
-	handlers.GetJobs(rec, req)
+	handlers.CancelJob(rec, req)
 
-	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
-func TestGetJobs_WithPagination(t *testing.T) {
+func TestCancelJobByHash_Success(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
 	mockQueue.EXPECT().
-		GetJobs(mock.MatchedBy(func(filter models.JobFilter) bool {
-			return filter.Limit == 25 && filter.Offset == 50
-		})).
-		Return([]*models.Job{}, nil).
+		GetJobByHash("abc123").
+		Return(&models.Job{ID: 42}, nil).
 		Once()
-
 	mockQueue.EXPECT().
-		CountJobs(mock.Anything).
-		Return(100, nil).
+		CancelJob(int64(42)).
+		Return(nil).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs?limit=25&offset=50", nil)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/by-hash/abc123/cancel", nil)
+	req = mux.SetURLVars(req, map[string]string{"hash": "abc123"})
 	rec := httptest.NewRecorder()
 
-	handlers.GetJobs(rec, req)
+	handlers.CancelJobByHash(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.True(t, response.Success)
+	assert.Equal(t, float64(42), response.Data.(map[string]interface{})["id"])
 }
 
-func TestGetJobs_Error(t *testing.T) {
+func TestCancelJobByHash_NotFound(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
 	mockQueue.EXPECT().
-		GetJobs(mock.Anything).
-		Return(nil, errors.New("database error")).
+		GetJobByHash("abc123").
+		Return(nil, errors.New("job with hash abc123 not found")).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs", nil)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/by-hash/abc123/cancel", nil)
+	req = mux.SetURLVars(req, map[string]string{"hash": "abc123"})
 	rec := httptest.NewRecorder()
 
-	handlers.GetJobs(rec, req)
+	handlers.CancelJobByHash(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
-func TestGetJob_Success(t *testing.T) {
+func TestCancelJobByPath_Success(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
-	testJob := &models.Job{
-		ID:     123,
-		Name:   "test-job",
-		Status: models.JobStatusRunning,
-		Progress: models.JobProgress{
-			Percentage:     50.0,
-			LastUpdateTime: time.Now(),
-		},
-	}
-
 	mockQueue.EXPECT().
-		GetJob(int64(123)).
-		Return(testJob, nil).
+		GetActiveJobByRemotePath("/downloads/Show.S01E01.mkv").
+		Return(&models.Job{ID: 42}, nil).
+		Once()
+	mockQueue.EXPECT().
+		CancelJob(int64(42)).
+		Return(nil).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs/123", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	body := strings.NewReader(`{"remote_path": "/downloads/Show.S01E01.mkv"}`)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/cancel-by-path", body)
 	rec := httptest.NewRecorder()
 
-	handlers.GetJob(rec, req)
+	handlers.CancelJobByPath(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 
 	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
-	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
 	assert.True(t, response.Success)
+	assert.Equal(t, float64(42), response.Data.(map[string]interface{})["id"])
 }
 
-func TestGetJob_InvalidID(t *testing.T) {
+func TestCancelJobByPath_NotFound(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
-
-	req := httptest.NewRequest("GET", "/api/v1/jobs/invalid", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "invalid"})
-	rec := httptest.NewRecorder()
 
-	handlers.GetJob(rec, req)
+	mockQueue.EXPECT().
+		GetActiveJobByRemotePath("/downloads/missing.mkv").
+		Return(nil, errors.New("no active job for remote path /downloads/missing.mkv")).
+		Once()
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
-	require.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Invalid job ID", response.Error)
-}
+	body := strings.NewReader(`{"remote_path": "/downloads/missing.mkv"}`)
+	req := httptest.NewRequest("POST", "/api/v1/jobs/cancel-by-path", body)
+	rec := httptest.NewRecorder()
 
-func TestGetJob_NotFound(t *testing.T) {
-	mockQueue := mocks.NewMockJobQueue(t)
+	handlers.CancelJobByPath(rec, req)
 
-	mockQueue.EXPECT().
-		GetJob(int64(999)).
-		Return(nil, errors.New("job not found")).
-		Once()
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+func TestCancelJobByPath_MissingRemotePath(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/jobs/999", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "999"})
+	req := httptest.NewRequest("POST", "/api/v1/jobs/cancel-by-path", strings.NewReader(`{"remote_path": " "}`))
 	rec := httptest.NewRecorder()
 
-	handlers.GetJob(rec, req)
+	handlers.CancelJobByPath(rec, req)
 
-	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
-func TestDeleteJob_Success(t *testing.T) {
+func TestExportJobs(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	completed := created.Add(90 * time.Minute)
 	mockQueue.EXPECT().
-		DeleteJob(int64(123)).
-		Return(nil).
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return len(filter.Status) == 1 && filter.Status[0] == models.JobStatusFailed &&
+				len(filter.Categories) == 1 && filter.Categories[0] == "tv" && filter.Limit == exportBatchSize
+		})).
+		Return([]*models.Job{
+			{ID: 7, Name: "Show, Part 1", RemotePath: "/remote/show1.mkv", Status: models.JobStatusFailed,
+				FileSize: 1024, TransferSpeed: 512, CreatedAt: created, CompletedAt: &completed, ErrorMessage: `rsync: "link" failed`},
+			{ID: 9, Name: "show2", RemotePath: "/remote/show2.mkv", Status: models.JobStatusFailed, CreatedAt: created},
+		}, nil).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("DELETE", "/api/v1/jobs/123", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	req := httptest.NewRequest("GET", "/api/v1/jobs/export?status=failed&category=tv", nil)
 	rec := httptest.NewRecorder()
 
-	handlers.DeleteJob(rec, req)
+	handlers.ExportJobs(rec, req)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), `attachment; filename="grabarr-jobs-`)
 
-	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
+	records, err := csv.NewReader(rec.Body).ReadAll()
 	require.NoError(t, err)
-	assert.True(t, response.Success)
-	assert.Equal(t, "Job deleted successfully", response.Message)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "name", "remote_path", "status", "size_bytes", "speed_bytes_per_sec", "created_at", "completed_at", "error"}, records[0])
+	assert.Equal(t, []string{"7", "Show, Part 1", "/remote/show1.mkv", "failed", "1024", "512", "2024-03-01T12:00:00Z", "2024-03-01T13:30:00Z", `rsync: "link" failed`}, records[1])
+	assert.Equal(t, []string{"9", "show2", "/remote/show2.mkv", "failed", "0", "0", "2024-03-01T12:00:00Z", "", ""}, records[2])
 }
 
-func TestCancelJob_Success(t *testing.T) {
+func TestExportJobs_Batches(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
+	firstBatch := make([]*models.Job, exportBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = &models.Job{ID: int64(i + 1), Status: models.JobStatusCompleted, SortKey: fmt.Sprintf("key-%d", i+1)}
+	}
 	mockQueue.EXPECT().
-		CancelJob(int64(123)).
-		Return(nil).
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool { return filter.After == nil })).
+		Return(firstBatch, nil).
+		Once()
+	mockQueue.EXPECT().
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return assert.ObjectsAreEqual(&models.JobCursor{ID: exportBatchSize, SortKey: fmt.Sprintf("key-%d", exportBatchSize)}, filter.After)
+		})).
+		Return([]*models.Job{{ID: exportBatchSize + 1, Status: models.JobStatusCompleted}}, nil).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("POST", "/api/v1/jobs/123/cancel", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "123"})
 	rec := httptest.NewRecorder()
+	handlers.ExportJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs/export", nil))
 
-	handlers.CancelJob(rec, req)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, exportBatchSize+2)
+}
 
-	assert.Equal(t, http.StatusOK, rec.Code)
+func TestExportJobs_BatchesWithNullSortKeys(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+
+	// Every third job is still queued and has no completed_at, so batch
+	// boundaries fall on NULL sort keys
+	total := exportBatchSize*2 + 10
+	completed := time.Now().Add(-time.Hour)
+	for i := 0; i < total; i++ {
+		job := testutil.CreateTestJob()
+		require.NoError(t, repo.CreateJob(job))
+		if i%3 != 0 {
+			at := completed.Add(time.Duration(i) * time.Second)
+			job.Status = models.JobStatusCompleted
+			job.CompletedAt = &at
+			require.NoError(t, repo.UpdateJob(job))
+		}
+	}
 
-	var response APIResponse
-	err := json.NewDecoder(rec.Body).Decode(&response)
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).RunAndReturn(repo.GetJobsContext)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	for _, order := range []string{"asc", "desc"} {
+		rec := httptest.NewRecorder()
+		handlers.ExportJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs/export?sort_by=completed_at&sort_order="+order, nil))
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, total+1, "order %s", order)
+
+		seen := make(map[string]bool)
+		for _, record := range records[1:] {
+			seen[record[0]] = true
+		}
+		assert.Len(t, seen, total, "order %s", order)
+	}
+}
+
+func TestExportJobs_CursorRowPurgedBetweenBatches(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+
+	total := exportBatchSize*2 + 10
+	for i := 0; i < total; i++ {
+		require.NoError(t, repo.CreateJob(testutil.CreateTestJob()))
+	}
+
+	// Cleanup purges the last job of each batch after it has been written
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+		jobs, err := repo.GetJobsContext(ctx, filter)
+		if err == nil && len(jobs) == exportBatchSize {
+			require.NoError(t, repo.DeleteJob(jobs[len(jobs)-1].ID))
+			purged, err := repo.PurgeDeletedJobs(time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			require.Equal(t, 1, purged)
+		}
+		return jobs, err
+	})
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.ExportJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs/export", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
 	require.NoError(t, err)
-	assert.True(t, response.Success)
-	assert.Equal(t, "Job cancelled successfully", response.Message)
+	require.Len(t, records, total+1)
+
+	seen := make(map[string]bool)
+	for _, record := range records[1:] {
+		seen[record[0]] = true
+	}
+	assert.Len(t, seen, total)
 }
 
-func TestCancelJob_Error(t *testing.T) {
+func TestExportJobs_InvalidSort(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.ExportJobs(rec, httptest.NewRequest("GET", "/api/v1/jobs/export?sort_by=bogus", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetJobs_HashFilter(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
 	mockQueue.EXPECT().
-		CancelJob(int64(123)).
-		Return(errors.New("cannot cancel completed job")).
+		GetJobsContext(mock.Anything, mock.MatchedBy(func(filter models.JobFilter) bool {
+			return filter.Hash == "abc123"
+		})).
+		Return([]*models.Job{}, nil).
+		Once()
+	mockQueue.EXPECT().
+		CountJobsContext(mock.Anything, mock.Anything).
+		Return(0, nil).
 		Once()
 
-	cfg := &config.Config{}
-	mockGatekeeper := mocks.NewMockGatekeeper(t)
-	mockGatekeeper.EXPECT().CanStartJob(mock.AnythingOfType("int64")).Return(interfaces.GateDecision{Allowed: true}).Maybe()
-	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
 
-	req := httptest.NewRequest("POST", "/api/v1/jobs/123/cancel", nil)
-	req = mux.SetURLVars(req, map[string]string{"id": "123"})
+	req := httptest.NewRequest("GET", "/api/v1/jobs?hash=abc123", nil)
 	rec := httptest.NewRecorder()
 
-	handlers.CancelJob(rec, req)
+	handlers.GetJobs(rec, req)
 
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
 func TestRetryJob_Success(t *testing.T) {
@@ -633,6 +2164,55 @@ func TestGetJobSummary_Success(t *testing.T) {
 	assert.Equal(t, float64(10), summaryData["queued_jobs"])
 }
 
+func TestGetJobsByCategory(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		GetJobStatsByCategory().
+		Return([]*models.CategoryStats{
+			{Category: "movies", TotalJobs: 3, CompletedJobs: 2, RunningJobs: 1, TransferredBytes: 6000},
+			{Category: "tv", TotalJobs: 1, QueuedJobs: 1},
+		}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/by-category", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobsByCategory(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data []models.CategoryStats `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "movies", response.Data[0].Category)
+	assert.Equal(t, int64(6000), response.Data[0].TransferredBytes)
+	assert.Equal(t, 1, response.Data[1].QueuedJobs)
+}
+
+func TestGetJobSummary_ETagNotModified(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().GetSummary().Return(&models.JobSummary{TotalJobs: 3, QueuedJobs: 3}, nil).Times(2)
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetJobSummary(rec, httptest.NewRequest("GET", "/api/v1/jobs/summary", nil))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest("GET", "/api/v1/jobs/summary", nil)
+	req.Header.Set("If-None-Match", `"unrelated", `+etag)
+	rec = httptest.NewRecorder()
+	handlers.GetJobSummary(rec, req)
+
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
 func TestGetJobSummary_Error(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 
@@ -654,6 +2234,60 @@ func TestGetJobSummary_Error(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
+func TestGetJobGroup_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	jobs := []*models.Job{
+		{ID: 1, Name: "episode-1", Status: models.JobStatusCompleted, GroupID: "season-1", FileSize: 100, TransferredBytes: 100},
+		{ID: 2, Name: "episode-2", Status: models.JobStatusRunning, GroupID: "season-1", FileSize: 100, TransferredBytes: 50},
+	}
+
+	mockQueue.EXPECT().
+		GetJobs(mock.MatchedBy(func(f models.JobFilter) bool { return f.GroupID == "season-1" })).
+		Return(jobs, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/groups/season-1", nil)
+	req = mux.SetURLVars(req, map[string]string{"groupId": "season-1"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobGroup(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "season-1", data["group_id"])
+	assert.Equal(t, "running", data["status"])
+	assert.Equal(t, float64(75), data["percentage"])
+	assert.Len(t, data["jobs"], 2)
+}
+
+func TestGetJobGroup_NotFound(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetJobs(mock.Anything).
+		Return([]*models.Job{}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/groups/missing", nil)
+	req = mux.SetURLVars(req, map[string]string{"groupId": "missing"})
+	rec := httptest.NewRecorder()
+
+	handlers.GetJobGroup(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		name  string
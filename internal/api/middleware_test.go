@@ -2,12 +2,21 @@ package api
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCorsMiddleware(t *testing.T) {
@@ -138,3 +147,142 @@ func TestResponseWriter_DefaultStatusCode(t *testing.T) {
 	// Status code should remain at default
 	assert.Equal(t, http.StatusOK, rw.statusCode)
 }
+
+func TestBodyLimit_OversizedCreateJob(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{
+		Server: config.ServerConfig{MaxRequestBodyBytes: 1024},
+	}
+
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	router := mux.NewRouter()
+	handlers.RegisterRoutes(router)
+
+	body := `{"name":"test","remote_path":"/` + strings.Repeat("a", 2048) + `"}`
+	req := httptest.NewRequest("POST", "/api/v1/jobs", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "Request body too large", response.Error)
+}
+
+func TestBodyLimit_DefaultAllowsNormalBody(t *testing.T) {
+	handler := bodyLimitMiddleware(defaultMaxRequestBodyBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name":"test"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGzipMiddleware_CompressesForGzipClients(t *testing.T) {
+	body := `{"data":"` + strings.Repeat("job ", 500) + `"}`
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=0.8")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.Less(t, rec.Body.Len(), len(body))
+
+	gz, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
+
+func TestGzipMiddleware_PlainForOtherClients(t *testing.T) {
+	handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+
+	for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"), acceptEncoding)
+		assert.Equal(t, `{"status":"ok"}`, rec.Body.String(), acceptEncoding)
+	}
+}
+
+func TestGzipMiddleware_SkipsStreamsAndEmptyBodies(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"websocket upgrade", "Upgrade", "websocket", http.StatusOK},
+		{"event stream", "Accept", "text/event-stream", http.StatusOK},
+		{"not modified", "", "", http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := gzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			assert.Zero(t, rec.Body.Len())
+		})
+	}
+}
+
+func TestGzip_OptIn(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{Server: config.ServerConfig{EnableGzip: enabled}}, nil, nil)
+		router := mux.NewRouter()
+		handlers.RegisterRoutes(router)
+
+		req := httptest.NewRequest("GET", "/api/v1/version", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		if !enabled {
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			continue
+		}
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		gz, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		var response APIResponse
+		require.NoError(t, json.NewDecoder(gz).Decode(&response))
+		assert.True(t, response.Success)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// TestNotification sends a test notification through the configured notifier and
+// reports the underlying error if delivery fails.
+func (h *Handlers) TestNotification(w http.ResponseWriter, r *http.Request) {
+	if h.notifier == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Notifications not available", nil)
+		return
+	}
+
+	if !h.notifier.IsEnabled() {
+		h.writeError(w, http.StatusBadRequest, "Notifications are disabled", nil)
+		return
+	}
+
+	if err := h.notifier.TestNotification(); err != nil {
+		h.writeError(w, http.StatusBadGateway, fmt.Sprintf("Test notification failed: %v", err), err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, nil, "Test notification sent")
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestNotification_Success(t *testing.T) {
+	mockNotifier := mocks.NewMockNotifier(t)
+	mockNotifier.EXPECT().IsEnabled().Return(true).Once()
+	mockNotifier.EXPECT().TestNotification().Return(nil).Once()
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+	handlers.SetNotifier(mockNotifier)
+
+	req := httptest.NewRequest("POST", "/api/v1/notifications/test", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.TestNotification(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "Test notification sent", response.Message)
+}
+
+func TestTestNotification_SendError(t *testing.T) {
+	mockNotifier := mocks.NewMockNotifier(t)
+	mockNotifier.EXPECT().IsEnabled().Return(true).Once()
+	mockNotifier.EXPECT().TestNotification().Return(errors.New("pushover API error: invalid token")).Once()
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+	handlers.SetNotifier(mockNotifier)
+
+	req := httptest.NewRequest("POST", "/api/v1/notifications/test", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.TestNotification(rec, req)
+
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Error, "invalid token")
+}
+
+func TestTestNotification_Disabled(t *testing.T) {
+	mockNotifier := mocks.NewMockNotifier(t)
+	mockNotifier.EXPECT().IsEnabled().Return(false).Once()
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+	handlers.SetNotifier(mockNotifier)
+
+	req := httptest.NewRequest("POST", "/api/v1/notifications/test", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.TestNotification(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTestNotification_NoNotifier(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/notifications/test", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.TestNotification(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// staleBucketAge is how long a client bucket can sit idle before it is pruned.
+const staleBucketAge = 10 * time.Minute
+
+// rateLimiter is a per-client token bucket limiter for mutating API requests.
+type rateLimiter struct {
+	rate              float64 // tokens added per second
+	burst             float64 // bucket capacity
+	trustForwardedFor bool
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int, trustForwardedFor bool) *rateLimiter {
+	return &rateLimiter{
+		rate:              requestsPerSecond,
+		burst:             float64(burst),
+		trustForwardedFor: trustForwardedFor,
+		buckets:           make(map[string]*tokenBucket),
+		now:               time.Now,
+	}
+}
+
+// allow consumes a token for key. When the bucket is empty it returns false and
+// how long the caller should wait before a token becomes available.
+func (rl *rateLimiter) allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.pruneLocked(now)
+
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: rl.burst, lastSeen: now}
+		rl.buckets[key] = b
+	} else {
+		elapsed := now.Sub(b.lastSeen).Seconds()
+		b.tokens = math.Min(rl.burst, b.tokens+elapsed*rl.rate)
+		b.lastSeen = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+	return false, wait
+}
+
+// pruneLocked drops buckets that have been idle long enough to have refilled.
+func (rl *rateLimiter) pruneLocked(now time.Time) {
+	if rl.lastPrune.IsZero() {
+		rl.lastPrune = now
+		return
+	}
+	if now.Sub(rl.lastPrune) < staleBucketAge {
+		return
+	}
+	for key, b := range rl.buckets {
+		if now.Sub(b.lastSeen) >= staleBucketAge {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.lastPrune = now
+}
+
+// clientKey identifies the caller, optionally trusting the first X-Forwarded-For hop.
+func (rl *rateLimiter) clientKey(r *http.Request) string {
+	if rl.trustForwardedFor {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			first := strings.TrimSpace(strings.Split(xff, ",")[0])
+			if first != "" {
+				return first
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// middleware rejects POST and DELETE requests that exceed the client's bucket with 429.
+func (rl *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, wait := rl.allow(rl.clientKey(r))
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success":false,"error":"rate limit exceeded"}`))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
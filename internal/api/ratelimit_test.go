@@ -0,0 +1,194 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateLimiter(rps float64, burst int, trustXFF bool) (*rateLimiter, *time.Time) {
+	rl := newRateLimiter(rps, burst, trustXFF)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rl.now = func() time.Time { return now }
+	return rl, &now
+}
+
+func TestRateLimiter_ExhaustAndRefill(t *testing.T) {
+	rl, now := newTestRateLimiter(2, 3, false)
+
+	// Burst of 3 is allowed
+	for i := 0; i < 3; i++ {
+		allowed, _ := rl.allow("10.0.0.1")
+		assert.True(t, allowed, "request %d should be allowed", i+1)
+	}
+
+	// Bucket is now empty
+	allowed, wait := rl.allow("10.0.0.1")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// Half a second at 2 req/s refills one token
+	*now = now.Add(500 * time.Millisecond)
+	allowed, _ = rl.allow("10.0.0.1")
+	assert.True(t, allowed)
+
+	allowed, _ = rl.allow("10.0.0.1")
+	assert.False(t, allowed)
+
+	// Long idle period refills to burst, not beyond
+	*now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		allowed, _ := rl.allow("10.0.0.1")
+		assert.True(t, allowed)
+	}
+	allowed, _ = rl.allow("10.0.0.1")
+	assert.False(t, allowed)
+}
+
+func TestRateLimiter_PerClientBuckets(t *testing.T) {
+	rl, _ := newTestRateLimiter(1, 1, false)
+
+	allowed, _ := rl.allow("10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _ = rl.allow("10.0.0.1")
+	assert.False(t, allowed)
+
+	// A different client has its own bucket
+	allowed, _ = rl.allow("10.0.0.2")
+	assert.True(t, allowed)
+}
+
+func TestRateLimiter_PrunesIdleBuckets(t *testing.T) {
+	rl, now := newTestRateLimiter(1, 1, false)
+
+	rl.allow("10.0.0.1")
+	require.Len(t, rl.buckets, 1)
+
+	*now = now.Add(staleBucketAge + time.Second)
+	rl.allow("10.0.0.2")
+
+	assert.Len(t, rl.buckets, 1)
+	assert.Contains(t, rl.buckets, "10.0.0.2")
+}
+
+func TestRateLimiter_ClientKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustXFF   bool
+		remoteAddr string
+		xff        string
+		expected   string
+	}{
+		{
+			name:       "remote addr host",
+			remoteAddr: "192.168.1.10:54321",
+			expected:   "192.168.1.10",
+		},
+		{
+			name:       "forwarded for ignored when untrusted",
+			remoteAddr: "192.168.1.10:54321",
+			xff:        "203.0.113.5",
+			expected:   "192.168.1.10",
+		},
+		{
+			name:       "forwarded for trusted uses first hop",
+			trustXFF:   true,
+			remoteAddr: "192.168.1.10:54321",
+			xff:        "203.0.113.5, 10.0.0.1",
+			expected:   "203.0.113.5",
+		},
+		{
+			name:       "trusted but header missing",
+			trustXFF:   true,
+			remoteAddr: "192.168.1.10:54321",
+			expected:   "192.168.1.10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := newRateLimiter(1, 1, tt.trustXFF)
+			req := httptest.NewRequest("POST", "/api/v1/jobs", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			assert.Equal(t, tt.expected, rl.clientKey(req))
+		})
+	}
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	rl, now := newTestRateLimiter(1, 2, false)
+
+	handler := rl.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/jobs", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do("POST").Code)
+	assert.Equal(t, http.StatusOK, do("DELETE").Code)
+
+	rec := do("POST")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "rate limit exceeded")
+
+	// Reads are never limited
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, do("GET").Code)
+	}
+
+	*now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, do("POST").Code)
+}
+
+func TestRegisterRoutes_RateLimitEnabled(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			RateLimit: config.RateLimitConfig{
+				Enabled:           true,
+				RequestsPerSecond: 1,
+				Burst:             1,
+			},
+		},
+	}
+	mockQueue.EXPECT().CancelJob(int64(1)).Return(nil).Once()
+
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+	require.NotNil(t, handlers.rateLimiter)
+
+	router := mux.NewRouter()
+	handlers.RegisterRoutes(router)
+
+	req := httptest.NewRequest("POST", "/api/v1/jobs/1/cancel", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest("POST", "/api/v1/jobs/1/cancel", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestNewHandlers_RateLimitDisabled(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+	assert.Nil(t, handlers.rateLimiter)
+}
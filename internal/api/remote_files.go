@@ -1,7 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"path/filepath"
@@ -28,6 +32,27 @@ type RemoteFileRepo interface {
 	GetRemoteFilesByPathPrefix(watchedRoot, pathPrefix string) ([]*models.RemoteFile, error)
 }
 
+// RemoteSizer reports the total size of a path on the seedbox.
+type RemoteSizer interface {
+	Size(ctx context.Context, remotePath string) (*models.RemoteSize, error)
+}
+
+// RemoteTester checks that a seedbox is reachable and remotePath exists on it.
+type RemoteTester interface {
+	Ping(ctx context.Context, remotePath string) error
+}
+
+// RemotePathChecker reports whether a path exists on the seedbox.
+type RemotePathChecker interface {
+	Stat(ctx context.Context, remotePath string) (bool, error)
+}
+
+// defaultRemoteSizeTimeout bounds how long a remote size lookup may take.
+const defaultRemoteSizeTimeout = 30 * time.Second
+
+// remoteTestTimeout bounds how long a remote connectivity test may take.
+const remoteTestTimeout = 20 * time.Second
+
 // ListRemoteFiles returns all remote files with optional status/extension filters.
 func (h *Handlers) ListRemoteFiles(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
@@ -124,7 +149,7 @@ func (h *Handlers) QueueRemoteFile(w http.ResponseWriter, r *http.Request) {
 	job.FileSize = rf.Size
 
 	if err := h.queue.Enqueue(job); err != nil {
-		h.writeError(w, http.StatusInternalServerError, "failed to enqueue job", err)
+		h.writeEnqueueError(w, "failed to enqueue job", err)
 		return
 	}
 
@@ -561,7 +586,7 @@ type queueFolderResponse struct {
 func (h *Handlers) QueueFolder(w http.ResponseWriter, r *http.Request) {
 	var req queueFolderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "invalid request body", err)
+		h.writeDecodeError(w, "invalid request body", err)
 		return
 	}
 
@@ -623,3 +648,99 @@ func (h *Handlers) QueueFolder(w http.ResponseWriter, r *http.Request) {
 
 	h.writeSuccess(w, http.StatusOK, resp, "")
 }
+
+// GetRemoteSize returns the total size and file count under a seedbox path.
+func (h *Handlers) GetRemoteSize(w http.ResponseWriter, r *http.Request) {
+	if h.remoteSizer == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "remote size lookup not available", nil)
+		return
+	}
+
+	remotePath := r.URL.Query().Get("path")
+	if strings.TrimSpace(remotePath) == "" {
+		h.writeError(w, http.StatusBadRequest, "path is required", nil)
+		return
+	}
+	remotePath, err := models.ResolveRemotePath(h.remoteRoot(), remotePath)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid path: %v", err), nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.remoteSizeTimeout)
+	defer cancel()
+
+	size, err := h.remoteSizer.Size(ctx, remotePath)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			h.writeError(w, http.StatusGatewayTimeout, "remote size lookup timed out", err)
+			return
+		}
+		h.writeError(w, http.StatusBadGateway, "remote size lookup failed", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, size, "")
+}
+
+// TestRemoteRequest selects the remote to test; empty means the first configured remote.
+type TestRemoteRequest struct {
+	Remote string `json:"remote"`
+}
+
+// RemoteTestResult reports the outcome of a remote connectivity test.
+type RemoteTestResult struct {
+	Remote    string `json:"remote"`
+	Path      string `json:"path"`
+	Reachable bool   `json:"reachable"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// TestRemote checks that grabarr can reach a remote over SSH and list its remote root.
+func (h *Handlers) TestRemote(w http.ResponseWriter, r *http.Request) {
+	var req TestRemoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeDecodeError(w, "Invalid JSON payload", err)
+		return
+	}
+
+	remotes := h.config.GetRemotes()
+	var remote *config.RemoteConfig
+	for i := range remotes {
+		if req.Remote == "" || remotes[i].Name == req.Remote {
+			remote = &remotes[i]
+			break
+		}
+	}
+	if remote == nil {
+		if req.Remote == "" {
+			h.writeError(w, http.StatusServiceUnavailable, "no remotes configured", nil)
+			return
+		}
+		h.writeError(w, http.StatusNotFound, fmt.Sprintf("remote '%s' not found", req.Remote), nil)
+		return
+	}
+
+	tester, ok := h.remoteTesters[remote.Name]
+	if !ok {
+		h.writeError(w, http.StatusServiceUnavailable, "remote test not available", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), remoteTestTimeout)
+	defer cancel()
+
+	result := RemoteTestResult{Remote: remote.Name, Path: remote.RemoteRoot}
+	start := time.Now()
+	err := tester.Ping(ctx, remote.RemoteRoot)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		slog.Warn("remote connectivity test failed", "remote", remote.Name, "error", err)
+		result.Error = err.Error()
+	} else {
+		result.Reachable = true
+	}
+
+	h.writeSuccess(w, http.StatusOK, result, "")
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -408,3 +409,152 @@ func TestQueueFolder_PartialFailure(t *testing.T) {
 	assert.Equal(t, float64(1), data["queued"])
 	assert.Equal(t, float64(1), data["failed"])
 }
+
+// ---- GetRemoteSize tests ----
+
+type fakeRemoteSizer func(ctx context.Context, remotePath string) (*models.RemoteSize, error)
+
+func (f fakeRemoteSizer) Size(ctx context.Context, remotePath string) (*models.RemoteSize, error) {
+	return f(ctx, remotePath)
+}
+
+func TestGetRemoteSize_Success(t *testing.T) {
+	h, _, _ := setupRemoteFileHandlers(t)
+	h.SetRemoteSizer(fakeRemoteSizer(func(ctx context.Context, remotePath string) (*models.RemoteSize, error) {
+		assert.Equal(t, "/downloads/Show.S01/", remotePath)
+		return &models.RemoteSize{Path: remotePath, TotalBytes: 8589934592, FileCount: 8}, nil
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/remote/size?path=/downloads//Show.S01/", nil)
+	rec := httptest.NewRecorder()
+	h.GetRemoteSize(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, float64(8589934592), data["total_bytes"])
+	assert.Equal(t, float64(8), data["file_count"])
+}
+
+func TestGetRemoteSize_Timeout(t *testing.T) {
+	h, _, _ := setupRemoteFileHandlers(t)
+	h.remoteSizeTimeout = 10 * time.Millisecond
+	h.SetRemoteSizer(fakeRemoteSizer(func(ctx context.Context, remotePath string) (*models.RemoteSize, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/remote/size?path=/downloads/x", nil)
+	rec := httptest.NewRecorder()
+	h.GetRemoteSize(rec, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
+
+func TestGetRemoteSize_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		sizer    RemoteSizer
+		wantCode int
+	}{
+		{"not configured", "?path=/downloads/x", nil, http.StatusServiceUnavailable},
+		{"missing path", "", fakeRemoteSizer(nil), http.StatusBadRequest},
+		{"path traversal", "?path=/downloads/../etc", fakeRemoteSizer(nil), http.StatusBadRequest},
+		{"lookup failure", "?path=/downloads/x", fakeRemoteSizer(func(ctx context.Context, remotePath string) (*models.RemoteSize, error) {
+			return nil, errors.New("ssh find failed: exit status 1")
+		}), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _ := setupRemoteFileHandlers(t)
+			if tt.sizer != nil {
+				h.SetRemoteSizer(tt.sizer)
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/remote/size"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h.GetRemoteSize(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+// ---- TestRemote tests ----
+
+type fakeRemoteTester func(ctx context.Context, remotePath string) error
+
+func (f fakeRemoteTester) Ping(ctx context.Context, remotePath string) error {
+	return f(ctx, remotePath)
+}
+
+func TestTestRemote_Success(t *testing.T) {
+	h, _, _ := setupRemoteFileHandlers(t)
+	h.config.Remotes[0].RemoteRoot = "/home/testuser"
+	h.SetRemoteTester("whatbox", fakeRemoteTester(func(ctx context.Context, remotePath string) error {
+		assert.Equal(t, "/home/testuser", remotePath)
+		return nil
+	}))
+
+	req := httptest.NewRequest("POST", "/api/v1/remote/test", nil)
+	rec := httptest.NewRecorder()
+	h.TestRemote(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, "whatbox", data["remote"])
+	assert.Equal(t, true, data["reachable"])
+	assert.Contains(t, data, "latency_ms")
+	assert.NotContains(t, data, "error")
+}
+
+func TestTestRemote_Failure(t *testing.T) {
+	h, _, _ := setupRemoteFileHandlers(t)
+	h.SetRemoteTester("whatbox", fakeRemoteTester(func(ctx context.Context, remotePath string) error {
+		return errors.New("ssh check failed: exit status 255 (stderr: Permission denied (publickey).)")
+	}))
+
+	req := httptest.NewRequest("POST", "/api/v1/remote/test", bytes.NewBufferString(`{"remote":"whatbox"}`))
+	rec := httptest.NewRecorder()
+	h.TestRemote(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, false, data["reachable"])
+	assert.Contains(t, data["error"], "Permission denied")
+}
+
+func TestTestRemote_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		tester   RemoteTester
+		wantCode int
+	}{
+		{"unknown remote", `{"remote":"other"}`, fakeRemoteTester(nil), http.StatusNotFound},
+		{"invalid json", `{`, fakeRemoteTester(nil), http.StatusBadRequest},
+		{"not configured", `{}`, nil, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, _ := setupRemoteFileHandlers(t)
+			if tt.tester != nil {
+				h.SetRemoteTester("whatbox", tt.tester)
+			}
+
+			req := httptest.NewRequest("POST", "/api/v1/remote/test", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			h.TestRemote(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
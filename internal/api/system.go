@@ -1,8 +1,15 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
+
+	"grabarr/internal/buildinfo"
+	"grabarr/internal/interfaces"
 )
 
 var startTime = time.Now()
@@ -12,7 +19,7 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		"status":    "healthy",
 		"timestamp": time.Now().UTC(),
 		"uptime":    time.Since(startTime).String(),
-		"version":   "1.0.0", // TODO: Get from build info
+		"version":   buildinfo.Version,
 	}
 
 	// Check resource status
@@ -24,6 +31,50 @@ func (h *Handlers) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	h.writeSuccess(w, http.StatusOK, health, "Service is healthy")
 }
 
+// readinessTimeout bounds the database check made by ReadinessCheck so a hung
+// database fails the probe instead of stalling it.
+const readinessTimeout = 2 * time.Second
+
+// ReadinessCheck answers /readyz and /api/v1/ready. It reports whether startup
+// has finished (the gatekeeper has made its first resource check and the job
+// queue is running) and the database answers a SELECT 1. Unlike HealthCheck it
+// returns 503 until then, while the database is unreachable, and again once
+// shutdown begins. rsync runs per transfer with no daemon, so there is no
+// transfer dependency to check.
+func (h *Handlers) ReadinessCheck(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		h.writeError(w, http.StatusServiceUnavailable, "Service is not ready", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+	if err := h.queue.Ping(ctx); err != nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Service is not ready: database unreachable", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, map[string]interface{}{
+		"status":    "ready",
+		"timestamp": time.Now().UTC(),
+		"checks":    map[string]string{"database": "ok"},
+	}, "Service is ready")
+}
+
+// Liveness answers /healthz: it only shows the process is up and serving
+// requests, so it always returns 200 and never checks dependencies.
+func (h *Handlers) Liveness(w http.ResponseWriter, r *http.Request) {
+	h.writeSuccess(w, http.StatusOK, map[string]interface{}{
+		"status":    "alive",
+		"timestamp": time.Now().UTC(),
+	}, "")
+}
+
+// GetVersion returns the build metadata injected at build time.
+func (h *Handlers) GetVersion(w http.ResponseWriter, r *http.Request) {
+	h.writeSuccess(w, http.StatusOK, buildinfo.Get(), "")
+}
+
 func (h *Handlers) GetMetrics(w http.ResponseWriter, r *http.Request) {
 	metrics := make(map[string]interface{})
 
@@ -38,13 +89,18 @@ func (h *Handlers) GetMetrics(w http.ResponseWriter, r *http.Request) {
 		metrics["jobs"] = summary
 	}
 
+	// Add lifetime totals
+	if lifetime, err := h.queue.GetLifetimeStats(); err == nil {
+		metrics["lifetime"] = lifetime
+	}
+
 	h.writeSuccess(w, http.StatusOK, metrics, "")
 }
 
 func (h *Handlers) GetStatus(w http.ResponseWriter, r *http.Request) {
 	status := map[string]interface{}{
 		"service":   "grabarr",
-		"version":   "1.0.0",
+		"version":   buildinfo.Version,
 		"timestamp": time.Now().UTC(),
 		"uptime":    time.Since(startTime).String(),
 	}
@@ -61,3 +117,122 @@ func (h *Handlers) GetStatus(w http.ResponseWriter, r *http.Request) {
 
 	h.writeSuccess(w, http.StatusOK, status, "")
 }
+
+// GetTransferStats returns aggregate transfer totals for completed jobs, optionally
+// limited to jobs completed since an RFC3339 timestamp.
+func (h *Handlers) GetTransferStats(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid since parameter, expected RFC3339 timestamp", err)
+			return
+		}
+		since = parsed
+	}
+
+	stats, err := h.queue.GetTransferStats(since)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to get transfer stats", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, stats, "")
+}
+
+// RefreshGatekeeper makes the gatekeeper re-check resource usage now, e.g.
+// right after freeing cache disk space, and returns the fresh status.
+func (h *Handlers) RefreshGatekeeper(w http.ResponseWriter, r *http.Request) {
+	if h.gatekeeper == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Gatekeeper not available", nil)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, h.gatekeeper.RefreshNow(), "Resource status refreshed")
+}
+
+// DiskUsageEntry is the space on one configured path's filesystem, or why it
+// couldn't be read.
+type DiskUsageEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	*interfaces.DiskUsage
+	Error string `json:"error,omitempty"`
+}
+
+// GetDiskUsage reports free, used and total space for the cache disk, the
+// downloads directory and each category path. A path that can't be read gets
+// an error entry instead of failing the whole response.
+func (h *Handlers) GetDiskUsage(w http.ResponseWriter, r *http.Request) {
+	if h.gatekeeper == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Gatekeeper not available", nil)
+		return
+	}
+
+	downloads := h.config.GetDownloads()
+	entries := []DiskUsageEntry{
+		{Name: "cache", Path: h.config.GetGatekeeper().CacheDisk.Path},
+		{Name: "downloads", Path: downloads.LocalPath},
+	}
+
+	categories := make([]string, 0, len(downloads.CategoryPaths))
+	for category := range downloads.CategoryPaths {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		entries = append(entries, DiskUsageEntry{Name: "category:" + category, Path: downloads.CategoryPaths[category]})
+	}
+
+	for i := range entries {
+		if entries[i].Path == "" {
+			entries[i].Error = "path not configured"
+			continue
+		}
+		usage, err := h.gatekeeper.DiskUsage(entries[i].Path)
+		if err != nil {
+			entries[i].Error = err.Error()
+			continue
+		}
+		entries[i].DiskUsage = &usage
+	}
+
+	h.writeSuccess(w, http.StatusOK, entries, "")
+}
+
+// VacuumDatabase checkpoints the WAL and vacuums the database now. VACUUM
+// blocks writes while it runs, so it is refused while any job is active.
+func (h *Handlers) VacuumDatabase(w http.ResponseWriter, r *http.Request) {
+	result, err := h.queue.Vacuum()
+	if err != nil {
+		if errors.Is(err, interfaces.ErrJobsActive) {
+			h.writeError(w, http.StatusConflict, "Cannot vacuum while jobs are active", err)
+			return
+		}
+		h.writeError(w, http.StatusInternalServerError, "Failed to vacuum database", err)
+		return
+	}
+
+	h.writeSuccess(w, http.StatusOK, result, "Database vacuumed")
+}
+
+// CheckGatekeeper previews whether the gatekeeper would currently allow a job of
+// the given size to start, returning the full decision including its details.
+func (h *Handlers) CheckGatekeeper(w http.ResponseWriter, r *http.Request) {
+	if h.gatekeeper == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "Gatekeeper not available", nil)
+		return
+	}
+
+	var size int64
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		parsed, err := strconv.ParseInt(sizeStr, 10, 64)
+		if err != nil || parsed < 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid size parameter, expected a non-negative byte count", err)
+			return
+		}
+		size = parsed
+	}
+
+	h.writeSuccess(w, http.StatusOK, h.gatekeeper.CanStartJob(size), "")
+}
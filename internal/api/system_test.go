@@ -5,13 +5,17 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"grabarr/internal/buildinfo"
 	"grabarr/internal/config"
 	"grabarr/internal/interfaces"
 	"grabarr/internal/mocks"
 	"grabarr/internal/models"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,7 +59,7 @@ func TestHealthCheck_WithGatekeeper(t *testing.T) {
 	assert.Equal(t, "healthy", data["status"])
 	assert.NotNil(t, data["timestamp"])
 	assert.NotNil(t, data["uptime"])
-	assert.Equal(t, "1.0.0", data["version"])
+	assert.Equal(t, buildinfo.Version, data["version"])
 	assert.NotNil(t, data["resources"])
 }
 
@@ -84,6 +88,114 @@ func TestHealthCheck_WithoutGatekeeper(t *testing.T) {
 	assert.Nil(t, data["resources"]) // No monitor, no resources
 }
 
+func TestReadinessCheck(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	check := func() (int, APIResponse) {
+		rec := httptest.NewRecorder()
+		handlers.ReadinessCheck(rec, httptest.NewRequest("GET", "/readyz", nil))
+		var response APIResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		return rec.Code, response
+	}
+
+	// Not ready until startup finishes; the database isn't pinged before then
+	code, response := check()
+	assert.Equal(t, 503, code)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Service is not ready", response.Error)
+
+	handlers.SetReady(true)
+	mockQueue.EXPECT().Ping(mock.Anything).Return(nil).Once()
+	code, response = check()
+	assert.Equal(t, 200, code)
+	assert.True(t, response.Success)
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "ready", data["status"])
+	assert.Equal(t, map[string]interface{}{"database": "ok"}, data["checks"])
+
+	mockQueue.EXPECT().Ping(mock.Anything).Return(errors.New("database is locked")).Once()
+	code, response = check()
+	assert.Equal(t, 503, code)
+	assert.Equal(t, "Service is not ready: database unreachable", response.Error)
+
+	// Shutdown drops readiness again
+	handlers.SetReady(false)
+	code, _ = check()
+	assert.Equal(t, 503, code)
+}
+
+func TestReadinessCheck_BothRoutes(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().Ping(mock.Anything).Return(errors.New("database is locked")).Times(2)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+	handlers.SetReady(true)
+	router := mux.NewRouter()
+	handlers.RegisterRoutes(router)
+
+	// Both probes give the same answer
+	for _, path := range []string{"/readyz", "/api/v1/ready"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, 503, rec.Code, path)
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	// Liveness doesn't depend on startup or the database
+	rec := httptest.NewRecorder()
+	handlers.Liveness(rec, httptest.NewRequest("GET", "/healthz", nil))
+
+	assert.Equal(t, 200, rec.Code)
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "alive", response.Data.(map[string]interface{})["status"])
+}
+
+func TestGetVersion_Defaults(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetVersion(rec, httptest.NewRequest("GET", "/api/v1/version", nil))
+
+	assert.Equal(t, 200, rec.Code)
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	data := response.Data.(map[string]interface{})
+	assert.Equal(t, "dev", data["version"])
+	assert.Equal(t, "dev", data["commit"])
+	assert.Equal(t, "dev", data["build_date"])
+}
+
+func TestGetVersion_InjectedValues(t *testing.T) {
+	origVersion, origCommit, origDate := buildinfo.Version, buildinfo.Commit, buildinfo.BuildDate
+	t.Cleanup(func() {
+		buildinfo.Version, buildinfo.Commit, buildinfo.BuildDate = origVersion, origCommit, origDate
+	})
+	buildinfo.Version, buildinfo.Commit, buildinfo.BuildDate = "v1.4.0", "abc1234", "2024-06-01_12:00:00"
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetVersion(rec, httptest.NewRequest("GET", "/api/v1/version", nil))
+
+	var response APIResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	data := response.Data.(map[string]interface{})
+	assert.Equal(t, "v1.4.0", data["version"])
+	assert.Equal(t, "abc1234", data["commit"])
+	assert.Equal(t, "2024-06-01_12:00:00", data["build_date"])
+
+	rec = httptest.NewRecorder()
+	handlers.HealthCheck(rec, httptest.NewRequest("GET", "/api/v1/health", nil))
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, "v1.4.0", response.Data.(map[string]interface{})["version"])
+}
+
 func TestGetMetrics_Success(t *testing.T) {
 	mockQueue := mocks.NewMockJobQueue(t)
 	mockGatekeeper := mocks.NewMockGatekeeper(t)
@@ -115,6 +227,11 @@ func TestGetMetrics_Success(t *testing.T) {
 		Return(summary, nil).
 		Once()
 
+	mockQueue.EXPECT().
+		GetLifetimeStats().
+		Return(&models.LifetimeStats{BytesTransferred: 5000, CompletedJobs: 12}, nil).
+		Once()
+
 	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/v1/metrics", nil)
@@ -134,6 +251,11 @@ func TestGetMetrics_Success(t *testing.T) {
 	require.True(t, ok)
 	assert.NotNil(t, data["resources"])
 	assert.NotNil(t, data["jobs"])
+
+	lifetime, ok := data["lifetime"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(5000), lifetime["bytes_transferred"])
+	assert.Equal(t, float64(12), lifetime["completed_jobs"])
 }
 
 func TestGetMetrics_JobSummaryError(t *testing.T) {
@@ -156,6 +278,11 @@ func TestGetMetrics_JobSummaryError(t *testing.T) {
 		Return(nil, errors.New("database error")).
 		Once()
 
+	mockQueue.EXPECT().
+		GetLifetimeStats().
+		Return(nil, errors.New("database error")).
+		Once()
+
 	handlers := NewHandlers(mockQueue, mockGatekeeper, cfg, nil, nil)
 
 	req := httptest.NewRequest("GET", "/api/v1/metrics", nil)
@@ -225,7 +352,7 @@ func TestGetStatus_Full(t *testing.T) {
 	data, ok := response.Data.(map[string]interface{})
 	require.True(t, ok)
 	assert.Equal(t, "grabarr", data["service"])
-	assert.Equal(t, "1.0.0", data["version"])
+	assert.Equal(t, buildinfo.Version, data["version"])
 	assert.NotNil(t, data["timestamp"])
 	assert.NotNil(t, data["uptime"])
 	assert.NotNil(t, data["jobs"])
@@ -309,3 +436,323 @@ func TestGetStatus_JobSummaryError(t *testing.T) {
 	assert.Nil(t, data["jobs"]) // Job summary not included
 	assert.NotNil(t, data["resources"])
 }
+
+func TestGetTransferStats_Success(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{}
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := &models.TransferStats{
+		CompletedJobs: 2,
+		TotalBytes:    4000,
+		AverageSpeed:  100,
+	}
+
+	mockQueue.EXPECT().
+		GetTransferStats(mock.MatchedBy(func(t time.Time) bool { return t.Equal(since) })).
+		Return(stats, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/stats?since=2024-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetTransferStats(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(2), data["completed_jobs"])
+	assert.Equal(t, float64(4000), data["total_bytes"])
+	assert.Equal(t, float64(100), data["average_speed"])
+}
+
+func TestGetTransferStats_NoSince(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	cfg := &config.Config{}
+
+	mockQueue.EXPECT().
+		GetTransferStats(time.Time{}).
+		Return(&models.TransferStats{}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, cfg, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/stats", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetTransferStats(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+
+	var response APIResponse
+	err := json.NewDecoder(rec.Body).Decode(&response)
+	require.NoError(t, err)
+
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(0), data["total_bytes"])
+}
+
+func TestGetTransferStats_InvalidSince(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/stats?since=yesterday", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetTransferStats(rec, req)
+
+	assert.Equal(t, 400, rec.Code)
+}
+
+func TestGetTransferStats_Error(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+
+	mockQueue.EXPECT().
+		GetTransferStats(time.Time{}).
+		Return(nil, errors.New("database error")).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/stats", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.GetTransferStats(rec, req)
+
+	assert.Equal(t, 500, rec.Code)
+}
+
+func TestCheckGatekeeper_Decisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision interfaces.GateDecision
+	}{
+		{
+			name:     "allowed",
+			decision: interfaces.GateDecision{Allowed: true, Reason: "All checks passed"},
+		},
+		{
+			name: "bandwidth limit reached",
+			decision: interfaces.GateDecision{
+				Reason:  "Bandwidth limit reached",
+				Details: map[string]interface{}{"current_mbps": 510.0, "limit_mbps": 500.0},
+			},
+		},
+		{
+			name: "cache disk usage too high",
+			decision: interfaces.GateDecision{
+				Reason:  "Cache disk usage too high",
+				Details: map[string]interface{}{"current_percent": 85.0, "max_percent": 80.0},
+			},
+		},
+		{
+			name: "unable to verify disk space",
+			decision: interfaces.GateDecision{
+				Reason:  "Unable to verify disk space",
+				Details: map[string]interface{}{"error": "no such file or directory"},
+			},
+		},
+		{
+			name: "file size would exceed cache limit",
+			decision: interfaces.GateDecision{
+				Reason: "File size would exceed cache limit",
+				Details: map[string]interface{}{
+					"file_size_bytes":         5000.0,
+					"available_bytes":         1000.0,
+					"projected_usage_percent": 92.5,
+					"max_percent":             80.0,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGatekeeper := mocks.NewMockGatekeeper(t)
+			mockGatekeeper.EXPECT().CanStartJob(int64(5000)).Return(tt.decision).Once()
+
+			handlers := NewHandlers(mocks.NewMockJobQueue(t), mockGatekeeper, &config.Config{}, nil, nil)
+
+			req := httptest.NewRequest("GET", "/api/v1/gatekeeper/check?size=5000", nil)
+			rec := httptest.NewRecorder()
+
+			handlers.CheckGatekeeper(rec, req)
+
+			assert.Equal(t, 200, rec.Code)
+
+			var response struct {
+				Data interfaces.GateDecision `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			assert.Equal(t, tt.decision, response.Data)
+		})
+	}
+}
+
+func TestCheckGatekeeper_NoSize(t *testing.T) {
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().CanStartJob(int64(0)).Return(interfaces.GateDecision{Allowed: true}).Once()
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), mockGatekeeper, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/gatekeeper/check", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.CheckGatekeeper(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+}
+
+func TestCheckGatekeeper_InvalidSize(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), mocks.NewMockGatekeeper(t), &config.Config{}, nil, nil)
+
+	for _, size := range []string{"abc", "-1"} {
+		req := httptest.NewRequest("GET", "/api/v1/gatekeeper/check?size="+size, nil)
+		rec := httptest.NewRecorder()
+
+		handlers.CheckGatekeeper(rec, req)
+
+		assert.Equal(t, 400, rec.Code, size)
+	}
+}
+
+func TestCheckGatekeeper_NoGatekeeper(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/gatekeeper/check?size=100", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.CheckGatekeeper(rec, req)
+
+	assert.Equal(t, 503, rec.Code)
+}
+
+func TestRefreshGatekeeper(t *testing.T) {
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().
+		RefreshNow().
+		Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 12.5, CacheMaxPercent: 80}).
+		Once()
+
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), mockGatekeeper, &config.Config{}, nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/gatekeeper/refresh", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.RefreshGatekeeper(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+
+	var response struct {
+		Data interfaces.GatekeeperResourceStatus `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Equal(t, 12.5, response.Data.CacheUsagePercent)
+}
+
+func TestRefreshGatekeeper_NoGatekeeper(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.RefreshGatekeeper(rec, httptest.NewRequest("POST", "/api/v1/gatekeeper/refresh", nil))
+
+	assert.Equal(t, 503, rec.Code)
+}
+
+func TestVacuumDatabase(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().
+		Vacuum().
+		Return(&models.MaintenanceResult{Vacuumed: true, SizeBefore: 4096, SizeAfter: 1024, ReclaimedBytes: 3072}, nil).
+		Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.VacuumDatabase(rec, httptest.NewRequest("POST", "/api/v1/maintenance/vacuum", nil))
+
+	assert.Equal(t, 200, rec.Code)
+
+	var response struct {
+		Data models.MaintenanceResult `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.True(t, response.Data.Vacuumed)
+	assert.Equal(t, int64(3072), response.Data.ReclaimedBytes)
+}
+
+func TestVacuumDatabase_JobsActive(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	mockQueue.EXPECT().Vacuum().Return(nil, interfaces.ErrJobsActive).Once()
+
+	handlers := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.VacuumDatabase(rec, httptest.NewRequest("POST", "/api/v1/maintenance/vacuum", nil))
+
+	assert.Equal(t, 409, rec.Code)
+}
+
+func TestGetDiskUsage(t *testing.T) {
+	mockGatekeeper := mocks.NewMockGatekeeper(t)
+	mockGatekeeper.EXPECT().
+		DiskUsage("/cache").
+		Return(interfaces.DiskUsage{TotalBytes: 1000, UsedBytes: 250, FreeBytes: 750, UsedPercent: 25}, nil).
+		Once()
+	mockGatekeeper.EXPECT().
+		DiskUsage("/downloads").
+		Return(interfaces.DiskUsage{TotalBytes: 2000, UsedBytes: 1000, FreeBytes: 1000, UsedPercent: 50}, nil).
+		Once()
+	mockGatekeeper.EXPECT().
+		DiskUsage("/missing/tv").
+		Return(interfaces.DiskUsage{}, errors.New("no such file or directory")).
+		Once()
+
+	cfg := &config.Config{
+		Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{Path: "/cache"}},
+		Downloads: config.DownloadsConfig{
+			LocalPath:     "/downloads",
+			CategoryPaths: map[string]string{"tv": "/missing/tv"},
+		},
+	}
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), mockGatekeeper, cfg, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetDiskUsage(rec, httptest.NewRequest("GET", "/api/v1/system/disk", nil))
+
+	assert.Equal(t, 200, rec.Code)
+
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	require.Len(t, response.Data, 3)
+
+	assert.Equal(t, "cache", response.Data[0]["name"])
+	assert.Equal(t, float64(750), response.Data[0]["free_bytes"])
+	assert.NotContains(t, response.Data[0], "error")
+
+	assert.Equal(t, "downloads", response.Data[1]["name"])
+	assert.Equal(t, float64(50), response.Data[1]["used_percent"])
+
+	assert.Equal(t, "category:tv", response.Data[2]["name"])
+	assert.Equal(t, "no such file or directory", response.Data[2]["error"])
+	assert.NotContains(t, response.Data[2], "total_bytes")
+}
+
+func TestGetDiskUsage_NoGatekeeper(t *testing.T) {
+	handlers := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	handlers.GetDiskUsage(rec, httptest.NewRequest("GET", "/api/v1/system/disk", nil))
+
+	assert.Equal(t, 503, rec.Code)
+}
@@ -0,0 +1,330 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"grabarr/internal/models"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket streams a client can subscribe to.
+const (
+	wsStreamJobs    = "jobs"    // progress of running jobs
+	wsStreamSummary = "summary" // job counts by status
+	wsStreamSync    = "sync"    // seedbox scanner status
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 4096
+	wsSendBuffer     = 64
+
+	// wsPublishInterval is how often stream snapshots are checked for changes.
+	wsPublishInterval = 2 * time.Second
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsMessage is the envelope for every message sent to WebSocket clients.
+type wsMessage struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// wsClientMessage is a message sent by a client. The only type is "subscribe",
+// which replaces the client's stream subscriptions.
+type wsClientMessage struct {
+	Type    string   `json:"type"`
+	Streams []string `json:"streams"`
+}
+
+// wsClient is a single WebSocket connection. Outgoing messages are buffered in
+// send; a client that falls too far behind is disconnected.
+type wsClient struct {
+	hub     *wsHub
+	conn    *websocket.Conn
+	send    chan []byte
+	mu      sync.Mutex
+	streams map[string]bool
+}
+
+func (c *wsClient) subscribed(stream string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.streams[stream]
+}
+
+// wsHub tracks connected clients and fans broadcasts out to subscribers.
+type wsHub struct {
+	mu      sync.Mutex
+	clients map[*wsClient]struct{}
+	// last holds the most recent payload per stream so unchanged snapshots
+	// aren't re-sent.
+	last map[string][]byte
+}
+
+func newWSHub() *wsHub {
+	return &wsHub{
+		clients: make(map[*wsClient]struct{}),
+		last:    make(map[string][]byte),
+	}
+}
+
+func (hub *wsHub) register(c *wsClient) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	hub.clients[c] = struct{}{}
+}
+
+func (hub *wsHub) unregister(c *wsClient) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := hub.clients[c]; ok {
+		delete(hub.clients, c)
+		close(c.send)
+	}
+}
+
+func (hub *wsHub) clientCount() int {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	return len(hub.clients)
+}
+
+// broadcast sends data as a stream event to every client subscribed to stream.
+func (hub *wsHub) broadcast(stream string, data interface{}) {
+	msg, err := json.Marshal(wsMessage{Type: stream, Data: data})
+	if err != nil {
+		slog.Error("failed to encode websocket event", "stream", stream, "error", err)
+		return
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	hub.last[stream] = msg
+	for c := range hub.clients {
+		if !c.subscribed(stream) {
+			continue
+		}
+		select {
+		case c.send <- msg:
+		default:
+			slog.Warn("websocket client too slow, disconnecting")
+			delete(hub.clients, c)
+			close(c.send)
+		}
+	}
+}
+
+// broadcastIfChanged broadcasts data unless it encodes the same as the last
+// event on stream.
+func (hub *wsHub) broadcastIfChanged(stream string, data interface{}) {
+	msg, err := json.Marshal(wsMessage{Type: stream, Data: data})
+	if err != nil {
+		slog.Error("failed to encode websocket event", "stream", stream, "error", err)
+		return
+	}
+
+	hub.mu.Lock()
+	unchanged := string(hub.last[stream]) == string(msg)
+	hub.mu.Unlock()
+	if !unchanged {
+		hub.broadcast(stream, data)
+	}
+}
+
+// ServeWebSocket upgrades the request to a WebSocket carrying live job,
+// summary and sync events.
+func (h *Handlers) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written an error response
+		slog.Warn("websocket upgrade failed", "error", err)
+		return
+	}
+
+	c := &wsClient{
+		hub:     h.wsHub,
+		conn:    conn,
+		send:    make(chan []byte, wsSendBuffer),
+		streams: make(map[string]bool),
+	}
+	h.wsHub.register(c)
+
+	go c.writePump()
+	c.readPump()
+}
+
+// readPump handles subscription messages and pongs until the connection closes.
+func (c *wsClient) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var msg wsClientMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.Warn("websocket read failed", "error", err)
+			}
+			return
+		}
+		if msg.Type != "subscribe" {
+			continue
+		}
+		c.subscribe(msg.Streams)
+	}
+}
+
+// subscribe replaces the client's subscriptions, acknowledges them, and sends
+// the latest event on each newly subscribed stream so the client starts with
+// current state.
+func (c *wsClient) subscribe(streams []string) {
+	valid := make(map[string]bool)
+	accepted := []string{}
+	for _, s := range streams {
+		switch s {
+		case wsStreamJobs, wsStreamSummary, wsStreamSync:
+			if !valid[s] {
+				valid[s] = true
+				accepted = append(accepted, s)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.streams = valid
+	c.mu.Unlock()
+
+	ack, _ := json.Marshal(wsMessage{Type: "subscribed", Data: map[string][]string{"streams": accepted}})
+
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	if _, ok := c.hub.clients[c]; !ok {
+		return
+	}
+	msgs := [][]byte{ack}
+	for _, s := range accepted {
+		if last := c.hub.last[s]; last != nil {
+			msgs = append(msgs, last)
+		}
+	}
+	for _, m := range msgs {
+		select {
+		case c.send <- m:
+		default:
+		}
+	}
+}
+
+// writePump writes buffered messages and keepalive pings to the connection.
+func (c *wsClient) writePump() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// StartEventStream publishes job, summary and sync snapshots to WebSocket
+// subscribers whenever they change, until ctx is cancelled.
+func (h *Handlers) StartEventStream(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(wsPublishInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if h.wsHub.clientCount() > 0 {
+					h.publishSnapshots(ctx)
+				}
+			}
+		}
+	}()
+}
+
+// wsJobProgress is the per-job payload of the jobs stream.
+type wsJobProgress struct {
+	ID       int64              `json:"id"`
+	Name     string             `json:"name"`
+	Status   models.JobStatus   `json:"status"`
+	Progress models.JobProgress `json:"progress"`
+}
+
+// wsSyncStatus is the payload of the sync stream.
+type wsSyncStatus struct {
+	LastScanAt   *time.Time `json:"last_scan_at"`
+	FilesFound   int        `json:"files_found"`
+	ScanInFlight bool       `json:"scan_in_flight"`
+	Error        string     `json:"error,omitempty"`
+}
+
+func (h *Handlers) publishSnapshots(ctx context.Context) {
+	running, err := h.queue.GetJobsContext(ctx, models.JobFilter{Status: []models.JobStatus{models.JobStatusRunning}})
+	if err != nil {
+		slog.Error("failed to load running jobs for websocket", "error", err)
+	} else {
+		h.applyLiveProgress(running...)
+		jobs := make([]wsJobProgress, 0, len(running))
+		for _, job := range running {
+			jobs = append(jobs, wsJobProgress{ID: job.ID, Name: job.Name, Status: job.Status, Progress: job.Progress})
+		}
+		h.wsHub.broadcastIfChanged(wsStreamJobs, jobs)
+	}
+
+	if summary, err := h.queue.GetSummary(); err != nil {
+		slog.Error("failed to load job summary for websocket", "error", err)
+	} else {
+		h.wsHub.broadcastIfChanged(wsStreamSummary, summary)
+	}
+
+	if h.scanner != nil {
+		st := h.scanner.GetStatus()
+		h.wsHub.broadcastIfChanged(wsStreamSync, wsSyncStatus{
+			LastScanAt:   st.LastScanAt,
+			FilesFound:   st.FilesFound,
+			ScanInFlight: st.ScanInFlight,
+			Error:        st.Error,
+		})
+	}
+}
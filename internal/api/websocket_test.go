@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+	"grabarr/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dialTestWebSocket serves h's routes and connects a WebSocket client
+// subscribed to streams.
+func dialTestWebSocket(t *testing.T, h *Handlers, streams ...string) *websocket.Conn {
+	t.Helper()
+	router := mux.NewRouter()
+	h.RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.WriteJSON(wsClientMessage{Type: "subscribe", Streams: streams}))
+
+	var ack wsMessage
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	require.NoError(t, conn.ReadJSON(&ack))
+	require.Equal(t, "subscribed", ack.Type)
+	return conn
+}
+
+func TestWebSocket_ReceivesSubscribedBroadcast(t *testing.T) {
+	h := NewHandlers(mocks.NewMockJobQueue(t), nil, &config.Config{}, nil, nil)
+	conn := dialTestWebSocket(t, h, wsStreamSummary, "bogus")
+
+	// Events on streams the client didn't subscribe to are not delivered
+	h.wsHub.broadcast(wsStreamJobs, []wsJobProgress{{ID: 1}})
+	h.wsHub.broadcast(wsStreamSummary, &models.JobSummary{TotalJobs: 3, RunningJobs: 1})
+
+	var msg struct {
+		Type string            `json:"type"`
+		Data models.JobSummary `json:"data"`
+	}
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, wsStreamSummary, msg.Type)
+	assert.Equal(t, 3, msg.Data.TotalJobs)
+	assert.Equal(t, 1, msg.Data.RunningJobs)
+}
+
+func TestWebSocket_PublishSnapshotsSendsOnlyChanges(t *testing.T) {
+	mockQueue := mocks.NewMockJobQueue(t)
+	running := []*models.Job{{ID: 7, Name: "Movie.mkv", Status: models.JobStatusRunning, Progress: models.JobProgress{Percentage: 42}}}
+	mockQueue.EXPECT().GetJobsContext(mock.Anything, mock.Anything).Return(running, nil).Times(2)
+	mockQueue.EXPECT().GetSummary().Return(&models.JobSummary{TotalJobs: 1, RunningJobs: 1}, nil).Times(2)
+
+	h := NewHandlers(mockQueue, nil, &config.Config{}, nil, nil)
+	conn := dialTestWebSocket(t, h, wsStreamJobs)
+
+	h.publishSnapshots(context.Background())
+	h.publishSnapshots(context.Background())
+	h.wsHub.broadcast(wsStreamJobs, []wsJobProgress{})
+
+	var msg struct {
+		Type string          `json:"type"`
+		Data []wsJobProgress `json:"data"`
+	}
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, wsStreamJobs, msg.Type)
+	require.Len(t, msg.Data, 1)
+	assert.Equal(t, int64(7), msg.Data[0].ID)
+	assert.Equal(t, 42.0, msg.Data[0].Progress.Percentage)
+
+	// The unchanged second snapshot was skipped, so the next event is the empty list
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Empty(t, msg.Data)
+}
@@ -0,0 +1,28 @@
+// Package buildinfo holds version metadata injected at build time, e.g.
+//
+//	go build -ldflags "-X grabarr/internal/buildinfo.Version=v1.2.0 -X grabarr/internal/buildinfo.Commit=abc1234"
+package buildinfo
+
+// Build metadata; each is "dev" unless set with -ldflags -X.
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildDate = "dev"
+)
+
+// Info is the build metadata reported by the API.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// Get returns the current build metadata.
+func Get() Info {
+	return Info{Version: Version, Commit: Commit, BuildDate: BuildDate}
+}
+
+// UserAgent returns the default User-Agent for outbound requests.
+func UserAgent() string {
+	return "grabarr/" + Version
+}
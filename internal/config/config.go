@@ -5,10 +5,14 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"grabarr/internal/models"
+
 	"github.com/fsnotify/fsnotify"
 	"github.com/goccy/go-yaml"
 )
@@ -24,9 +28,17 @@ type Config struct {
 	Logging       LoggingConfig       `yaml:"logging"`
 	Sync          SyncConfig          `yaml:"sync"`
 	Extraction    ExtractionConfig    `yaml:"extraction"`
+	Hooks         HooksConfig         `yaml:"hooks"`
 
 	mu       sync.RWMutex
 	watchers []chan<- struct{}
+
+	// Sections replaced at runtime through the admin API, and the values from
+	// the config file they hide. Overrides survive reloads until cleared.
+	jobsOverride       *JobsConfig
+	gatekeeperOverride *GatekeeperConfig
+	fileJobs           JobsConfig
+	fileGatekeeper     GatekeeperConfig
 }
 
 type SyncConfig struct {
@@ -39,11 +51,18 @@ type ExtractionConfig struct {
 	CleanupArchives bool `yaml:"cleanup_archives"`
 }
 
+// HooksConfig lists shell commands run on job events.
+type HooksConfig struct {
+	OnJobCompleted []string      `yaml:"on_job_completed"` // run with sh -c after a job completes
+	Timeout        time.Duration `yaml:"timeout"`          // per-command limit (default 5m)
+}
+
 type RemoteConfig struct {
 	Name         string        `yaml:"name"`
 	SSHHost      string        `yaml:"ssh_host"`
 	SSHUser      string        `yaml:"ssh_user"`
 	SSHKeyFile   string        `yaml:"ssh_key_file"`
+	RemoteRoot   string        `yaml:"remote_root"` // API remote paths are resolved relative to this directory
 	WatchedPaths []WatchedPath `yaml:"watched_paths"`
 }
 
@@ -58,14 +77,52 @@ type WatchedPath struct {
 }
 
 type ServerConfig struct {
-	Port            int           `yaml:"port"`
-	Host            string        `yaml:"host"`
-	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	Port                int             `yaml:"port"`
+	Host                string          `yaml:"host"`
+	ShutdownTimeout     time.Duration   `yaml:"shutdown_timeout"`
+	ShutdownMode        string          `yaml:"shutdown_mode"` // requeue (default) or drain
+	RateLimit           RateLimitConfig `yaml:"rate_limit"`
+	MaxRequestBodyBytes int64           `yaml:"max_request_body_bytes"` // default 1MB
+	EnableGzip          bool            `yaml:"enable_gzip"`            // gzip API responses for clients that accept it
+	AdminToken          string          `yaml:"admin_token"`            // bearer token for the runtime config endpoints (empty = disabled)
+}
+
+// RateLimitConfig controls the per-client token bucket applied to mutating API requests.
+type RateLimitConfig struct {
+	Enabled           bool    `yaml:"enabled"`
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+	TrustForwardedFor bool    `yaml:"trust_forwarded_for"` // use X-Forwarded-For instead of RemoteAddr
 }
 
 type DownloadsConfig struct {
-	LocalPath         string   `yaml:"local_path"`
-	AllowedCategories []string `yaml:"allowed_categories"`
+	LocalPath         string            `yaml:"local_path"`
+	AllowedCategories []string          `yaml:"allowed_categories"`
+	CategoryPaths     map[string]string `yaml:"category_paths"` // category -> base download directory
+	DestTemplates     map[string]string `yaml:"dest_templates"` // category -> subdirectory template, e.g. "{category}/{year}"
+	SkipExisting      bool              `yaml:"skip_existing"`  // complete new jobs immediately when the file is already present locally
+}
+
+// BasePathForCategory returns the download directory for a job category,
+// falling back to LocalPath when the category has no mapping.
+func (d DownloadsConfig) BasePathForCategory(category string) string {
+	if path, ok := d.CategoryPaths[category]; ok && category != "" {
+		return path
+	}
+	return d.LocalPath
+}
+
+// DestTemplateForJob returns the dest_template a job downloads into: its own
+// download_config.dest_template, else the one configured for its category, else
+// "".
+func (d DownloadsConfig) DestTemplateForJob(job *models.Job) string {
+	if job.DownloadConfig != nil && job.DownloadConfig.DestTemplate != nil {
+		return *job.DownloadConfig.DestTemplate
+	}
+	if job.Metadata.Category == "" {
+		return ""
+	}
+	return d.DestTemplates[job.Metadata.Category]
 }
 
 type GatekeeperConfig struct {
@@ -75,35 +132,291 @@ type GatekeeperConfig struct {
 }
 
 type SeedboxConfig struct {
-	BandwidthLimitMbps int           `yaml:"bandwidth_limit_mbps"`
-	CheckInterval      time.Duration `yaml:"check_interval"`
+	BandwidthLimitMbps int               `yaml:"bandwidth_limit_mbps"`
+	CheckInterval      time.Duration     `yaml:"check_interval"`
+	BandwidthSchedule  []BandwidthWindow `yaml:"bandwidth_schedule"` // time-of-day overrides of bandwidth_limit_mbps
+}
+
+// BandwidthWindow overrides the seedbox bandwidth limit during a daily time window.
+type BandwidthWindow struct {
+	Name               string `yaml:"name"`
+	Start              string `yaml:"start"` // HH:MM local time, inclusive
+	End                string `yaml:"end"`   // HH:MM local time, exclusive; before Start wraps past midnight
+	BandwidthLimitMbps int    `yaml:"bandwidth_limit_mbps"`
+}
+
+// ActiveBandwidthWindow returns the first schedule window containing t, if any.
+func (s SeedboxConfig) ActiveBandwidthWindow(t time.Time) (BandwidthWindow, bool) {
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range s.BandwidthSchedule {
+		start, err := parseClock(w.Start)
+		if err != nil {
+			continue
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			continue
+		}
+		if start < end {
+			if minute >= start && minute < end {
+				return w, true
+			}
+		} else if minute >= start || minute < end {
+			return w, true
+		}
+	}
+	return BandwidthWindow{}, false
+}
+
+// BandwidthLimitAt returns the bandwidth limit in effect at t: the active
+// schedule window's limit, or BandwidthLimitMbps outside every window.
+func (s SeedboxConfig) BandwidthLimitAt(t time.Time) int {
+	if w, ok := s.ActiveBandwidthWindow(t); ok {
+		return w.BandwidthLimitMbps
+	}
+	return s.BandwidthLimitMbps
+}
+
+// parseClock parses an HH:MM time of day into minutes past midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ByteSize is a size in bytes. In YAML it may be a plain number of bytes or a
+// string with a unit, e.g. "20GB" or "512MiB"; see ParseByteSize.
+type ByteSize int64
+
+// UnmarshalYAML accepts either a number or a human-readable size string.
+func (b *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n int64
+	if err := unmarshal(&n); err == nil {
+		*b = ByteSize(n)
+		return nil
+	}
+
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	size, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// byteSizeUnits maps size suffixes to multipliers. Units are powers of 1024
+// whether or not the "i" is written, matching how sizes are displayed.
+var byteSizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// ParseByteSize parses sizes like "1024", "512K", "20GB", "1.5 TiB". Units are
+// case-insensitive.
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	i := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(trimmed)
+	}
+
+	value, err := strconv.ParseFloat(trimmed[:i], 64)
+	multiplier, ok := byteSizeUnits[strings.ToLower(strings.TrimSpace(trimmed[i:]))]
+	if err != nil || !ok {
+		return 0, fmt.Errorf("invalid size %q, expected a number with an optional unit like 20GB", s)
+	}
+	return ByteSize(value * multiplier), nil
 }
 
 type CacheDiskConfig struct {
-	Path            string        `yaml:"path"`
-	MaxUsagePercent int           `yaml:"max_usage_percent"`
-	CheckInterval   time.Duration `yaml:"check_interval"`
+	Path            string              `yaml:"path"`
+	MaxUsagePercent int                 `yaml:"max_usage_percent"`
+	MinFreeBytes    ByteSize            `yaml:"min_free_bytes"` // deny jobs that would leave less free space than this, e.g. "20GB"
+	CheckInterval   time.Duration       `yaml:"check_interval"`
+	Eviction        CacheEvictionConfig `yaml:"eviction"`
+}
+
+// CacheEvictionConfig frees cache space by deleting the local files of the
+// oldest completed downloads when the cache disk fills up.
+type CacheEvictionConfig struct {
+	Enabled          bool          `yaml:"enabled"`
+	ThresholdPercent int           `yaml:"threshold_percent"` // evict while usage is at or above this (default max_usage_percent)
+	MinAge           time.Duration `yaml:"min_age"`           // never evict downloads completed more recently than this (default 24h)
 }
 
 type GatekeeperRules struct {
 	RequireFilesizeCheck bool `yaml:"require_filesize_check"`
+	FailOpenOnStatError  bool `yaml:"fail_open_on_stat_error"` // allow jobs when the cache disk can't be stat'd
 }
 
 type JobsConfig struct {
-	MaxConcurrent         int           `yaml:"max_concurrent"`
-	MaxRetries            int           `yaml:"max_retries"`
-	CleanupCompletedAfter time.Duration `yaml:"cleanup_completed_after"`
-	CleanupFailedAfter    time.Duration `yaml:"cleanup_failed_after"`
+	MaxConcurrent             int                      `yaml:"max_concurrent"`
+	MaxRetries                int                      `yaml:"max_retries"`
+	CleanupCompletedAfter     time.Duration            `yaml:"cleanup_completed_after"`
+	CleanupFailedAfter        time.Duration            `yaml:"cleanup_failed_after"`
+	CleanupExcludeCategories  []string                 `yaml:"cleanup_exclude_categories"`    // job categories never removed by cleanup
+	PendingWatchdogInterval   time.Duration            `yaml:"pending_watchdog_interval"`     // how often to re-queue dropped pending jobs (default 1m)
+	MaxJobDuration            time.Duration            `yaml:"max_job_duration"`              // cancel jobs running longer than this (0 = no limit)
+	StallTimeout              time.Duration            `yaml:"stall_timeout"`                 // fail transfers whose progress hasn't advanced for this long (0 = disabled)
+	MaxQueueDepth             int                      `yaml:"max_queue_depth"`               // reject new jobs when this many are queued or pending (0 = no limit)
+	SummaryCacheTTL           time.Duration            `yaml:"summary_cache_ttl"`             // how long job summaries are served from memory (default 2s)
+	RetryableFailures         []models.FailureCategory `yaml:"retryable_failures"`            // failure categories that are retried (default: disk, network, unknown)
+	RecoveryMode              string                   `yaml:"recovery_mode"`                 // what happens to jobs left running at startup: requeue (default), fail or leave
+	DeletedJobRetention       time.Duration            `yaml:"deleted_job_retention"`         // how long deleted jobs can be restored before cleanup removes them (default 168h)
+	NotifyIfRunningLongerThan time.Duration            `yaml:"notify_if_running_longer_than"` // send one system alert per job running longer than this (0 = disabled)
+	DefaultPriority           int                      `yaml:"default_priority"`              // priority given to jobs submitted without one
+	MinPriority               *int                     `yaml:"min_priority"`                  // lowest priority accepted from the API (default: unbounded)
+	MaxPriority               *int                     `yaml:"max_priority"`                  // highest priority accepted from the API (default: unbounded)
+	PriorityOutOfRange        string                   `yaml:"priority_out_of_range"`         // reject (default) or clamp priorities outside min/max_priority
+	NameTemplate              string                   `yaml:"name_template"`                 // Go template naming jobs submitted without a name
+	ValidateRemotePath        bool                     `yaml:"validate_remote_path"`          // reject jobs whose remote_path doesn't exist on the seedbox
+	ValidateRemotePathTimeout time.Duration            `yaml:"validate_remote_path_timeout"`  // how long the existence check may take before the job is accepted anyway (default 10s)
+	ProgressPersistInterval   time.Duration            `yaml:"progress_persist_interval"`     // write transfer progress to the database at most this often (default 5s)
+	ProgressPersistMinDelta   float64                  `yaml:"progress_persist_min_delta"`    // also write sooner once the percentage moves by more than this many points (default 1)
+}
+
+// JobNameData is what jobs.name_template is executed against.
+type JobNameData struct {
+	RemotePath string // resolved seedbox path; empty for copyurl jobs
+	SourceURL  string // HTTP(S) source of copyurl jobs
+	Base       string // last element of RemotePath, or the file name from SourceURL
+	Category   string
+	Metadata   models.JobMetadata
+}
+
+// RenderJobName executes jobs.name_template for a job submitted without a name.
+// Surrounding whitespace is trimmed and an empty result is an error.
+func (j JobsConfig) RenderJobName(data JobNameData) (string, error) {
+	tmpl, err := template.New("name_template").Parse(j.NameTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(b.String())
+	if name == "" {
+		return "", fmt.Errorf("name_template produced an empty name")
+	}
+	return name, nil
+}
+
+// Handling of API priorities outside jobs.min_priority/max_priority, accepted
+// in jobs.priority_out_of_range.
+const (
+	PriorityOutOfRangeReject = "reject" // fail the request with a validation error
+	PriorityOutOfRangeClamp  = "clamp"  // move the priority to the nearest bound
+)
+
+// PriorityInRange reports whether priority is within min_priority and max_priority.
+func (j JobsConfig) PriorityInRange(priority int) bool {
+	return j.ClampPriority(priority) == priority
+}
+
+// ClampPriority moves priority into the min_priority/max_priority range.
+func (j JobsConfig) ClampPriority(priority int) int {
+	if j.MinPriority != nil && priority < *j.MinPriority {
+		return *j.MinPriority
+	}
+	if j.MaxPriority != nil && priority > *j.MaxPriority {
+		return *j.MaxPriority
+	}
+	return priority
+}
+
+// Startup recovery modes accepted in jobs.recovery_mode.
+const (
+	RecoveryModeRequeue = "requeue" // reset interrupted jobs to queued and run them again
+	RecoveryModeFail    = "fail"    // mark interrupted jobs failed for manual review
+	RecoveryModeLeave   = "leave"   // leave interrupted jobs' status untouched and don't run them
+)
+
+// defaultRetryableFailures is used when jobs.retryable_failures is not set.
+var defaultRetryableFailures = []models.FailureCategory{models.FailureCategoryDisk, models.FailureCategoryNetwork, models.FailureCategoryUnknown}
+
+// IsRetryableFailure reports whether a job that failed with category should be
+// retried. Failures without a category are always retryable.
+func (j JobsConfig) IsRetryableFailure(category models.FailureCategory) bool {
+	if category == "" {
+		return true
+	}
+	retryable := j.RetryableFailures
+	if len(retryable) == 0 {
+		retryable = defaultRetryableFailures
+	}
+	for _, c := range retryable {
+		if c == category {
+			return true
+		}
+	}
+	return false
 }
 
 type DatabaseConfig struct {
-	Path string `yaml:"path"`
+	Path                string        `yaml:"path"`
+	BusyTimeoutMs       int           `yaml:"busy_timeout_ms"`      // how long SQLite waits on a locked database (default 5000)
+	MaxOpenConns        int           `yaml:"max_open_conns"`       // default 10
+	MaxIdleConns        int           `yaml:"max_idle_conns"`       // default 5
+	ConnMaxLifetime     time.Duration `yaml:"conn_max_lifetime"`    // default 1h
+	MaintenanceInterval time.Duration `yaml:"maintenance_interval"` // how often the WAL is checkpointed (default 1h)
+	VacuumInterval      time.Duration `yaml:"vacuum_interval"`      // how often maintenance also runs VACUUM, when no jobs are active (default 168h)
 }
 
 type NotificationsConfig struct {
-	Pushover PushoverConfig `yaml:"pushover"`
+	Pushover     PushoverConfig     `yaml:"pushover"`
+	MinPriority  *int               `yaml:"min_priority"`   // completions below this priority are not notified (default 5)
+	MinSizeBytes int64              `yaml:"min_size_bytes"` // completions smaller than this are not notified
+	NotifyOn     []string           `yaml:"notify_on"`      // job events to notify on (default: all)
+	DedupWindow  time.Duration      `yaml:"dedup_window"`   // suppress repeats of the same job event within this window (0 = off)
+	HTTP         OutboundHTTPConfig `yaml:"http"`
+}
+
+// OutboundHTTPConfig controls the HTTP clients used for outbound notification
+// requests and copyurl downloads; downloads ignore Timeout.
+type OutboundHTTPConfig struct {
+	UserAgent string        `yaml:"user_agent"` // default "grabarr/<version>"
+	Timeout   time.Duration `yaml:"timeout"`    // default 30s
 }
 
+// Shutdown modes accepted in server.shutdown_mode.
+const (
+	ShutdownModeRequeue = "requeue" // interrupt active jobs and mark them queued
+	ShutdownModeDrain   = "drain"   // let active jobs finish, re-queuing only on timeout
+)
+
+// Job notification events accepted in notifications.notify_on.
+const (
+	NotifyEventJobCompleted = "job_completed"
+	NotifyEventJobFailed    = "job_failed"
+)
+
+// DefaultNotifyMinPriority is the minimum job priority for completion notifications
+// when notifications.min_priority is not set.
+const DefaultNotifyMinPriority = 5
+
 type PushoverConfig struct {
 	Token         string        `yaml:"token"`
 	User          string        `yaml:"user"`
@@ -157,6 +470,8 @@ func loadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	config.ApplyDefaults()
+
 	// Validate configuration
 	if err := config.validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
@@ -170,6 +485,91 @@ func loadConfig(configPath string) (*Config, error) {
 	return &config, nil
 }
 
+// Defaults for settings that must be positive, filled in by ApplyDefaults when
+// the YAML leaves them out.
+const (
+	defaultPort               = 8080
+	defaultShutdownTimeout    = 30 * time.Second
+	defaultMaxConcurrent      = 5
+	defaultGatekeeperInterval = 30 * time.Second
+	defaultMaxUsagePercent    = 80
+	defaultPushoverRetry      = 60 * time.Second
+	defaultPushoverExpire     = time.Hour
+	defaultSyncScanInterval   = 5 * time.Minute
+
+	defaultSummaryCacheTTL         = 2 * time.Second
+	defaultDeletedJobRetention     = 7 * 24 * time.Hour
+	defaultRemotePathCheckTimeout  = 10 * time.Second
+	defaultEvictionMinAge          = 24 * time.Hour
+	defaultHookTimeout             = 5 * time.Minute
+	defaultDBMaintenanceInterval   = time.Hour
+	defaultDBVacuumInterval        = 7 * 24 * time.Hour
+	defaultProgressPersistInterval = 5 * time.Second
+	defaultProgressPersistMinDelta = 1.0
+)
+
+// ApplyDefaults fills zero values that would otherwise break the service: a
+// zero check_interval panics the gatekeeper's ticker, a zero shutdown_timeout
+// cuts off in-flight requests and a zero max_usage_percent blocks every job.
+// Negative values are left for validate to reject. Load applies it; a Config
+// built by hand, as in tests, must call it before use.
+func (c *Config) ApplyDefaults() {
+	if c.Server.Port == 0 {
+		c.Server.Port = defaultPort
+	}
+	if c.Server.ShutdownTimeout == 0 {
+		c.Server.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if c.Jobs.MaxConcurrent == 0 {
+		c.Jobs.MaxConcurrent = defaultMaxConcurrent
+	}
+	if c.Gatekeeper.Seedbox.CheckInterval == 0 {
+		c.Gatekeeper.Seedbox.CheckInterval = defaultGatekeeperInterval
+	}
+	if c.Gatekeeper.CacheDisk.CheckInterval == 0 {
+		c.Gatekeeper.CacheDisk.CheckInterval = defaultGatekeeperInterval
+	}
+	if c.Gatekeeper.CacheDisk.MaxUsagePercent == 0 {
+		c.Gatekeeper.CacheDisk.MaxUsagePercent = defaultMaxUsagePercent
+	}
+	if c.Notifications.Pushover.RetryInterval == 0 {
+		c.Notifications.Pushover.RetryInterval = defaultPushoverRetry
+	}
+	if c.Notifications.Pushover.ExpireTime == 0 {
+		c.Notifications.Pushover.ExpireTime = defaultPushoverExpire
+	}
+	if c.Sync.ScanInterval == 0 {
+		c.Sync.ScanInterval = defaultSyncScanInterval
+	}
+	if c.Jobs.SummaryCacheTTL == 0 {
+		c.Jobs.SummaryCacheTTL = defaultSummaryCacheTTL
+	}
+	if c.Jobs.DeletedJobRetention == 0 {
+		c.Jobs.DeletedJobRetention = defaultDeletedJobRetention
+	}
+	if c.Jobs.ValidateRemotePathTimeout == 0 {
+		c.Jobs.ValidateRemotePathTimeout = defaultRemotePathCheckTimeout
+	}
+	if c.Gatekeeper.CacheDisk.Eviction.MinAge == 0 {
+		c.Gatekeeper.CacheDisk.Eviction.MinAge = defaultEvictionMinAge
+	}
+	if c.Hooks.Timeout == 0 {
+		c.Hooks.Timeout = defaultHookTimeout
+	}
+	if c.Database.MaintenanceInterval == 0 {
+		c.Database.MaintenanceInterval = defaultDBMaintenanceInterval
+	}
+	if c.Database.VacuumInterval == 0 {
+		c.Database.VacuumInterval = defaultDBVacuumInterval
+	}
+	if c.Jobs.ProgressPersistInterval == 0 {
+		c.Jobs.ProgressPersistInterval = defaultProgressPersistInterval
+	}
+	if c.Jobs.ProgressPersistMinDelta == 0 {
+		c.Jobs.ProgressPersistMinDelta = defaultProgressPersistMinDelta
+	}
+}
+
 func (c *Config) validate() error {
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
@@ -183,6 +583,209 @@ func (c *Config) validate() error {
 		return fmt.Errorf("max_retries cannot be negative")
 	}
 
+	if c.Jobs.MaxJobDuration < 0 {
+		return fmt.Errorf("max_job_duration cannot be negative")
+	}
+
+	if c.Jobs.StallTimeout < 0 {
+		return fmt.Errorf("stall_timeout cannot be negative")
+	}
+
+	if c.Jobs.MaxQueueDepth < 0 {
+		return fmt.Errorf("max_queue_depth cannot be negative")
+	}
+
+	if c.Jobs.DeletedJobRetention < 0 {
+		return fmt.Errorf("deleted_job_retention cannot be negative")
+	}
+
+	if c.Jobs.NotifyIfRunningLongerThan < 0 {
+		return fmt.Errorf("notify_if_running_longer_than cannot be negative")
+	}
+
+	if c.Jobs.SummaryCacheTTL < 0 {
+		return fmt.Errorf("summary_cache_ttl cannot be negative")
+	}
+
+	if c.Jobs.ValidateRemotePathTimeout < 0 {
+		return fmt.Errorf("validate_remote_path_timeout cannot be negative")
+	}
+
+	if c.Jobs.ProgressPersistInterval < 0 {
+		return fmt.Errorf("progress_persist_interval cannot be negative")
+	}
+
+	if c.Jobs.ProgressPersistMinDelta < 0 || c.Jobs.ProgressPersistMinDelta > 100 {
+		return fmt.Errorf("progress_persist_min_delta must be between 0 and 100")
+	}
+
+	if c.Hooks.Timeout < 0 {
+		return fmt.Errorf("hooks timeout cannot be negative")
+	}
+
+	for i, command := range c.Hooks.OnJobCompleted {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("hooks.on_job_completed[%d] cannot be empty", i)
+		}
+	}
+
+	if c.Database.BusyTimeoutMs < 0 {
+		return fmt.Errorf("busy_timeout_ms cannot be negative")
+	}
+
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		return fmt.Errorf("database connection pool sizes cannot be negative")
+	}
+
+	if c.Database.ConnMaxLifetime < 0 {
+		return fmt.Errorf("conn_max_lifetime cannot be negative")
+	}
+
+	if c.Database.MaintenanceInterval < 0 {
+		return fmt.Errorf("maintenance_interval cannot be negative")
+	}
+
+	if c.Database.VacuumInterval < 0 {
+		return fmt.Errorf("vacuum_interval cannot be negative")
+	}
+
+	for _, category := range c.Jobs.RetryableFailures {
+		switch category {
+		case models.FailureCategoryAuth, models.FailureCategoryDisk, models.FailureCategoryNetwork, models.FailureCategoryNotFound, models.FailureCategoryUnknown:
+		default:
+			return fmt.Errorf("invalid retryable_failures category: %s", category)
+		}
+	}
+
+	if c.Jobs.MinPriority != nil && c.Jobs.MaxPriority != nil && *c.Jobs.MinPriority > *c.Jobs.MaxPriority {
+		return fmt.Errorf("min_priority cannot be greater than max_priority")
+	}
+
+	if !c.Jobs.PriorityInRange(c.Jobs.DefaultPriority) {
+		return fmt.Errorf("default_priority must be between min_priority and max_priority")
+	}
+
+	switch c.Jobs.PriorityOutOfRange {
+	case "", PriorityOutOfRangeReject, PriorityOutOfRangeClamp:
+	default:
+		return fmt.Errorf("invalid priority_out_of_range: %s", c.Jobs.PriorityOutOfRange)
+	}
+
+	if c.Jobs.NameTemplate != "" {
+		// Rendering against sample data also catches references to fields that don't exist
+		sample := JobNameData{RemotePath: "/sample", Base: "sample", Category: "sample"}
+		if _, err := c.Jobs.RenderJobName(sample); err != nil {
+			return fmt.Errorf("invalid name_template: %w", err)
+		}
+	}
+
+	switch c.Jobs.RecoveryMode {
+	case "", RecoveryModeRequeue, RecoveryModeFail, RecoveryModeLeave:
+	default:
+		return fmt.Errorf("invalid recovery_mode: %s", c.Jobs.RecoveryMode)
+	}
+
+	switch c.Server.ShutdownMode {
+	case "", ShutdownModeRequeue, ShutdownModeDrain:
+	default:
+		return fmt.Errorf("invalid shutdown_mode: %s", c.Server.ShutdownMode)
+	}
+
+	if c.Server.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown_timeout cannot be negative")
+	}
+
+	if c.Server.MaxRequestBodyBytes < 0 {
+		return fmt.Errorf("max_request_body_bytes cannot be negative")
+	}
+
+	if c.Server.RateLimit.Enabled {
+		if c.Server.RateLimit.RequestsPerSecond <= 0 {
+			return fmt.Errorf("rate_limit requests_per_second must be greater than 0")
+		}
+		if c.Server.RateLimit.Burst <= 0 {
+			return fmt.Errorf("rate_limit burst must be greater than 0")
+		}
+	}
+
+	if c.Gatekeeper.Seedbox.CheckInterval < 0 || c.Gatekeeper.CacheDisk.CheckInterval < 0 {
+		return fmt.Errorf("gatekeeper check_interval cannot be negative")
+	}
+
+	if c.Gatekeeper.CacheDisk.MinFreeBytes < 0 {
+		return fmt.Errorf("min_free_bytes cannot be negative")
+	}
+
+	if eviction := c.Gatekeeper.CacheDisk.Eviction; eviction.Enabled {
+		if eviction.ThresholdPercent < 0 || eviction.ThresholdPercent > 100 {
+			return fmt.Errorf("eviction threshold_percent must be between 0 and 100")
+		}
+		if eviction.MinAge < 0 {
+			return fmt.Errorf("eviction min_age cannot be negative")
+		}
+	}
+
+	for i, w := range c.Gatekeeper.Seedbox.BandwidthSchedule {
+		start, err := parseClock(w.Start)
+		if err != nil {
+			return fmt.Errorf("bandwidth_schedule[%d] start: %w", i, err)
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return fmt.Errorf("bandwidth_schedule[%d] end: %w", i, err)
+		}
+		if start == end {
+			return fmt.Errorf("bandwidth_schedule[%d] start and end must differ", i)
+		}
+		if w.BandwidthLimitMbps <= 0 {
+			return fmt.Errorf("bandwidth_schedule[%d] bandwidth_limit_mbps must be greater than 0", i)
+		}
+	}
+
+	for _, remote := range c.Remotes {
+		if remote.RemoteRoot == "" {
+			continue
+		}
+		if !strings.HasPrefix(remote.RemoteRoot, "/") {
+			return fmt.Errorf("remotes.%s remote_root must be an absolute path", remote.Name)
+		}
+		for _, segment := range strings.Split(remote.RemoteRoot, "/") {
+			if segment == ".." {
+				return fmt.Errorf("remotes.%s remote_root must not contain '..'", remote.Name)
+			}
+		}
+	}
+
+	for category, path := range c.Downloads.CategoryPaths {
+		if path == "" || !filepath.IsAbs(path) {
+			return fmt.Errorf("category_paths.%s must be an absolute path", category)
+		}
+	}
+
+	for category, tmpl := range c.Downloads.DestTemplates {
+		if err := models.ValidateDestTemplate(tmpl); err != nil {
+			return fmt.Errorf("dest_templates.%s: %w", category, err)
+		}
+	}
+
+	for _, event := range c.Notifications.NotifyOn {
+		if event != NotifyEventJobCompleted && event != NotifyEventJobFailed {
+			return fmt.Errorf("invalid notify_on event: %s", event)
+		}
+	}
+
+	if c.Notifications.MinSizeBytes < 0 {
+		return fmt.Errorf("min_size_bytes cannot be negative")
+	}
+
+	if c.Notifications.DedupWindow < 0 {
+		return fmt.Errorf("dedup_window cannot be negative")
+	}
+
+	if c.Notifications.HTTP.Timeout < 0 {
+		return fmt.Errorf("notifications http timeout cannot be negative")
+	}
+
 	if c.Notifications.Pushover.Enabled {
 		if c.Notifications.Pushover.Token == "" || strings.HasPrefix(c.Notifications.Pushover.Token, "${") {
 			return fmt.Errorf("pushover token is required when notifications are enabled")
@@ -199,6 +802,9 @@ func (c *Config) ensureDirectories() error {
 	dirs := []string{
 		filepath.Dir(c.Database.Path),
 	}
+	for _, path := range c.Downloads.CategoryPaths {
+		dirs = append(dirs, path)
+	}
 
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -283,11 +889,113 @@ func (c *Config) reload(configPath string) error {
 	c.Logging = newConfig.Logging
 	c.Sync = newConfig.Sync
 	c.Extraction = newConfig.Extraction
+	c.Hooks = newConfig.Hooks
+
+	c.fileJobs = newConfig.Jobs
+	if c.jobsOverride != nil {
+		c.Jobs = *c.jobsOverride
+	}
+	c.fileGatekeeper = newConfig.Gatekeeper
+	if c.gatekeeperOverride != nil {
+		c.Gatekeeper = *c.gatekeeperOverride
+	}
 
 	slog.Info("configuration reloaded successfully")
 	return nil
 }
 
+// OverrideJobs replaces the jobs section at runtime. Zero settings get the
+// same defaults as the config file, and the result is validated with the rest
+// of the config before it is applied. Watchers are notified on success.
+func (c *Config) OverrideJobs(jobs JobsConfig) (JobsConfig, error) {
+	candidate := c.snapshot()
+	candidate.Jobs = jobs
+	candidate.ApplyDefaults()
+	if err := candidate.validate(); err != nil {
+		return JobsConfig{}, err
+	}
+
+	c.mu.Lock()
+	if c.jobsOverride == nil {
+		c.fileJobs = c.Jobs
+	}
+	c.jobsOverride = &candidate.Jobs
+	c.Jobs = candidate.Jobs
+	c.mu.Unlock()
+
+	c.notifyWatchers()
+	return candidate.Jobs, nil
+}
+
+// ClearJobsOverride restores the jobs section from the config file.
+func (c *Config) ClearJobsOverride() {
+	c.mu.Lock()
+	if c.jobsOverride == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.jobsOverride = nil
+	c.Jobs = c.fileJobs
+	c.mu.Unlock()
+
+	c.notifyWatchers()
+}
+
+// OverrideGatekeeper replaces the gatekeeper section at runtime, like OverrideJobs.
+func (c *Config) OverrideGatekeeper(gatekeeper GatekeeperConfig) (GatekeeperConfig, error) {
+	candidate := c.snapshot()
+	candidate.Gatekeeper = gatekeeper
+	candidate.ApplyDefaults()
+	if err := candidate.validate(); err != nil {
+		return GatekeeperConfig{}, err
+	}
+
+	c.mu.Lock()
+	if c.gatekeeperOverride == nil {
+		c.fileGatekeeper = c.Gatekeeper
+	}
+	c.gatekeeperOverride = &candidate.Gatekeeper
+	c.Gatekeeper = candidate.Gatekeeper
+	c.mu.Unlock()
+
+	c.notifyWatchers()
+	return candidate.Gatekeeper, nil
+}
+
+// ClearGatekeeperOverride restores the gatekeeper section from the config file.
+func (c *Config) ClearGatekeeperOverride() {
+	c.mu.Lock()
+	if c.gatekeeperOverride == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.gatekeeperOverride = nil
+	c.Gatekeeper = c.fileGatekeeper
+	c.mu.Unlock()
+
+	c.notifyWatchers()
+}
+
+// snapshot copies the config sections, without the lock or watchers, so a
+// changed section can be validated against the rest.
+func (c *Config) snapshot() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &Config{
+		Server:        c.Server,
+		Downloads:     c.Downloads,
+		Remotes:       c.Remotes,
+		Gatekeeper:    c.Gatekeeper,
+		Jobs:          c.Jobs,
+		Database:      c.Database,
+		Notifications: c.Notifications,
+		Logging:       c.Logging,
+		Sync:          c.Sync,
+		Extraction:    c.Extraction,
+		Hooks:         c.Hooks,
+	}
+}
+
 func (c *Config) notifyWatchers() {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -372,3 +1080,10 @@ func (c *Config) GetExtraction() ExtractionConfig {
 	defer c.mu.RUnlock()
 	return c.Extraction
 }
+
+// GetHooks returns a copy of the hooks configuration
+func (c *Config) GetHooks() HooksConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Hooks
+}
@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"grabarr/internal/models"
+
+	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +40,12 @@ remotes:
         auto_download: true
         recursive: true
 
+gatekeeper:
+  cache_disk:
+    path: "/tmp"
+    max_usage_percent: 90
+    min_free_bytes: "20GB"
+
 jobs:
   max_concurrent: 3
   max_retries: 3
@@ -95,12 +104,60 @@ sync:
 	require.Len(t, cfg.Remotes[0].WatchedPaths, 1)
 	assert.Equal(t, "/home/testuser/downloads/", cfg.Remotes[0].WatchedPaths[0].RemotePath)
 
+	// Verify gatekeeper config
+	assert.Equal(t, ByteSize(20<<30), cfg.Gatekeeper.CacheDisk.MinFreeBytes)
+
 	// Verify jobs config
 	assert.Equal(t, 3, cfg.Jobs.MaxConcurrent)
 	assert.Equal(t, 3, cfg.Jobs.MaxRetries)
 }
 
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ByteSize
+		wantErr  bool
+	}{
+		{input: "1024", expected: 1024},
+		{input: "512B", expected: 512},
+		{input: "512K", expected: 512 << 10},
+		{input: "20GB", expected: 20 << 30},
+		{input: "20gb", expected: 20 << 30},
+		{input: "1.5 TiB", expected: 3 << 39},
+		{input: " 100MiB ", expected: 100 << 20},
+		{input: "", wantErr: true},
+		{input: "GB", wantErr: true},
+		{input: "20XB", wantErr: true},
+		{input: "-5GB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			size, err := ParseByteSize(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
+func TestByteSize_UnmarshalYAML(t *testing.T) {
+	var cfg CacheDiskConfig
+	require.NoError(t, yaml.Unmarshal([]byte("min_free_bytes: 1048576"), &cfg))
+	assert.Equal(t, ByteSize(1<<20), cfg.MinFreeBytes)
+
+	require.NoError(t, yaml.Unmarshal([]byte("min_free_bytes: 2GiB"), &cfg))
+	assert.Equal(t, ByteSize(2<<30), cfg.MinFreeBytes)
+
+	assert.Error(t, yaml.Unmarshal([]byte("min_free_bytes: lots"), &cfg))
+}
+
 func TestConfigValidation(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
 	tests := []struct {
 		name        string
 		config      *Config
@@ -143,6 +200,224 @@ func TestConfigValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "max_retries cannot be negative",
 		},
+		{
+			name: "negative shutdown timeout",
+			config: &Config{
+				Server: ServerConfig{Port: 8080, ShutdownTimeout: -time.Second},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+			},
+			expectError: true,
+			errorMsg:    "shutdown_timeout cannot be negative",
+		},
+		{
+			name: "negative gatekeeper check interval",
+			config: &Config{
+				Server:     ServerConfig{Port: 8080},
+				Jobs:       JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{CacheDisk: CacheDiskConfig{CheckInterval: -time.Second}},
+			},
+			expectError: true,
+			errorMsg:    "gatekeeper check_interval cannot be negative",
+		},
+		{
+			name: "negative progress persist interval",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, ProgressPersistInterval: -time.Second},
+			},
+			expectError: true,
+			errorMsg:    "progress_persist_interval cannot be negative",
+		},
+		{
+			name: "progress persist delta out of range",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, ProgressPersistMinDelta: 150},
+			},
+			expectError: true,
+			errorMsg:    "progress_persist_min_delta must be between 0 and 100",
+		},
+		{
+			name: "negative remote path validation timeout",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, ValidateRemotePath: true, ValidateRemotePathTimeout: -time.Second},
+			},
+			expectError: true,
+			errorMsg:    "validate_remote_path_timeout cannot be negative",
+		},
+		{
+			name: "invalid shutdown mode",
+			config: &Config{
+				Server: ServerConfig{Port: 8080, ShutdownMode: "abandon"},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+			},
+			expectError: true,
+			errorMsg:    "invalid shutdown_mode: abandon",
+		},
+		{
+			name: "invalid bandwidth schedule time",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{Seedbox: SeedboxConfig{
+					BandwidthSchedule: []BandwidthWindow{{Start: "25:00", End: "08:00", BandwidthLimitMbps: 500}},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "bandwidth_schedule[0] start",
+		},
+		{
+			name: "empty bandwidth schedule window",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{Seedbox: SeedboxConfig{
+					BandwidthSchedule: []BandwidthWindow{{Start: "08:00", End: "08:00", BandwidthLimitMbps: 500}},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "bandwidth_schedule[0] start and end must differ",
+		},
+		{
+			name: "bandwidth schedule without limit",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{Seedbox: SeedboxConfig{
+					BandwidthSchedule: []BandwidthWindow{{Start: "00:00", End: "08:00"}},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "bandwidth_schedule[0] bandwidth_limit_mbps must be greater than 0",
+		},
+		{
+			name: "negative busy timeout",
+			config: &Config{
+				Server:   ServerConfig{Port: 8080},
+				Jobs:     JobsConfig{MaxConcurrent: 1},
+				Database: DatabaseConfig{BusyTimeoutMs: -1},
+			},
+			expectError: true,
+			errorMsg:    "busy_timeout_ms cannot be negative",
+		},
+		{
+			name: "eviction threshold out of range",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{CacheDisk: CacheDiskConfig{
+					Eviction: CacheEvictionConfig{Enabled: true, ThresholdPercent: 120},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "eviction threshold_percent must be between 0 and 100",
+		},
+		{
+			name: "negative eviction min age",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{CacheDisk: CacheDiskConfig{
+					Eviction: CacheEvictionConfig{Enabled: true, MinAge: -time.Hour},
+				}},
+			},
+			expectError: true,
+			errorMsg:    "eviction min_age cannot be negative",
+		},
+		{
+			name: "negative min free bytes",
+			config: &Config{
+				Server:     ServerConfig{Port: 8080},
+				Jobs:       JobsConfig{MaxConcurrent: 1},
+				Gatekeeper: GatekeeperConfig{CacheDisk: CacheDiskConfig{MinFreeBytes: -1}},
+			},
+			expectError: true,
+			errorMsg:    "min_free_bytes cannot be negative",
+		},
+		{
+			name: "negative maintenance interval",
+			config: &Config{
+				Server:   ServerConfig{Port: 8080},
+				Jobs:     JobsConfig{MaxConcurrent: 1},
+				Database: DatabaseConfig{MaintenanceInterval: -time.Minute},
+			},
+			expectError: true,
+			errorMsg:    "maintenance_interval cannot be negative",
+		},
+		{
+			name: "invalid retryable failure category",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, RetryableFailures: []models.FailureCategory{"network", "cosmic_rays"}},
+			},
+			expectError: true,
+			errorMsg:    "invalid retryable_failures category: cosmic_rays",
+		},
+		{
+			name: "invalid recovery mode",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, RecoveryMode: "resume"},
+			},
+			expectError: true,
+			errorMsg:    "invalid recovery_mode: resume",
+		},
+		{
+			name: "min_priority above max_priority",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, MinPriority: intPtr(10), MaxPriority: intPtr(1)},
+			},
+			expectError: true,
+			errorMsg:    "min_priority cannot be greater than max_priority",
+		},
+		{
+			name: "default_priority outside range",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, DefaultPriority: 0, MinPriority: intPtr(1)},
+			},
+			expectError: true,
+			errorMsg:    "default_priority must be between min_priority and max_priority",
+		},
+		{
+			name: "invalid priority_out_of_range",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, PriorityOutOfRange: "ignore"},
+			},
+			expectError: true,
+			errorMsg:    "invalid priority_out_of_range: ignore",
+		},
+		{
+			name: "unparseable name_template",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, NameTemplate: "{{.Category"},
+			},
+			expectError: true,
+			errorMsg:    "invalid name_template",
+		},
+		{
+			name: "name_template with unknown field",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1, NameTemplate: "{{.Season}}"},
+			},
+			expectError: true,
+			errorMsg:    "can't evaluate field Season",
+		},
+		{
+			name: "relative remote_root",
+			config: &Config{
+				Server:  ServerConfig{Port: 8080},
+				Jobs:    JobsConfig{MaxConcurrent: 1},
+				Remotes: []RemoteConfig{{Name: "seedbox", RemoteRoot: "downloads"}},
+			},
+			expectError: true,
+			errorMsg:    "remotes.seedbox remote_root must be an absolute path",
+		},
 		{
 			name: "pushover enabled without token",
 			config: &Config{
@@ -159,6 +434,66 @@ func TestConfigValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "pushover token is required",
 		},
+		{
+			name: "invalid notify_on event",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Notifications: NotificationsConfig{
+					NotifyOn: []string{"job_completed", "job_exploded"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "invalid notify_on event: job_exploded",
+		},
+		{
+			name: "negative min_size_bytes",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Notifications: NotificationsConfig{
+					MinSizeBytes: -1,
+				},
+			},
+			expectError: true,
+			errorMsg:    "min_size_bytes cannot be negative",
+		},
+		{
+			name: "negative dedup_window",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Notifications: NotificationsConfig{
+					DedupWindow: -time.Second,
+				},
+			},
+			expectError: true,
+			errorMsg:    "dedup_window cannot be negative",
+		},
+		{
+			name: "relative category path",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Downloads: DownloadsConfig{
+					CategoryPaths: map[string]string{"movies": "media/movies"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "category_paths.movies must be an absolute path",
+		},
+		{
+			name: "invalid dest template",
+			config: &Config{
+				Server: ServerConfig{Port: 8080},
+				Jobs:   JobsConfig{MaxConcurrent: 1},
+				Downloads: DownloadsConfig{
+					DestTemplates: map[string]string{"movies": "../{year}"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "dest_templates.movies: dest_template must not contain '..': ../{year}",
+		},
 		{
 			name: "valid config",
 			config: &Config{
@@ -186,6 +521,49 @@ func TestConfigValidation(t *testing.T) {
 	}
 }
 
+func TestBasePathForCategory(t *testing.T) {
+	downloads := DownloadsConfig{
+		LocalPath: "/downloads",
+		CategoryPaths: map[string]string{
+			"movies": "/media/movies",
+			"tv":     "/media/tv",
+		},
+	}
+
+	assert.Equal(t, "/media/movies", downloads.BasePathForCategory("movies"))
+	assert.Equal(t, "/media/tv", downloads.BasePathForCategory("tv"))
+	assert.Equal(t, "/downloads", downloads.BasePathForCategory("anime"))
+	assert.Equal(t, "/downloads", downloads.BasePathForCategory(""))
+}
+
+func TestIsRetryableFailure(t *testing.T) {
+	defaults := JobsConfig{}
+	assert.True(t, defaults.IsRetryableFailure(""))
+	assert.True(t, defaults.IsRetryableFailure(models.FailureCategoryNetwork))
+	assert.True(t, defaults.IsRetryableFailure(models.FailureCategoryUnknown))
+	assert.False(t, defaults.IsRetryableFailure(models.FailureCategoryAuth))
+	assert.False(t, defaults.IsRetryableFailure(models.FailureCategoryNotFound))
+
+	custom := JobsConfig{RetryableFailures: []models.FailureCategory{models.FailureCategoryNotFound}}
+	assert.True(t, custom.IsRetryableFailure(models.FailureCategoryNotFound))
+	assert.False(t, custom.IsRetryableFailure(models.FailureCategoryNetwork))
+}
+
+func TestEnsureDirectories_CreatesCategoryPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	moviesPath := filepath.Join(tmpDir, "media", "movies")
+
+	cfg := &Config{
+		Database: DatabaseConfig{Path: filepath.Join(tmpDir, "data", "grabarr.db")},
+		Downloads: DownloadsConfig{
+			CategoryPaths: map[string]string{"movies": moviesPath},
+		},
+	}
+
+	require.NoError(t, cfg.ensureDirectories())
+	assert.DirExists(t, moviesPath)
+}
+
 func TestConfigGetters(t *testing.T) {
 	cfg := &Config{
 		Server: ServerConfig{Port: 8080, Host: "localhost"},
@@ -230,6 +608,134 @@ func TestConfigGetters(t *testing.T) {
 	assert.Equal(t, "/data/db.sqlite", dbCfg.Path)
 }
 
+func TestLoadConfigDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	configContent := `
+downloads:
+  local_path: "` + tmpDir + `/downloads"
+
+database:
+  path: "` + filepath.Join(tmpDir, "data", "grabarr.db") + `"
+`
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	// Reset global config for testing
+	globalConfig = nil
+	configOnce = sync.Once{}
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, 5, cfg.Jobs.MaxConcurrent)
+	assert.Equal(t, 30*time.Second, cfg.Gatekeeper.Seedbox.CheckInterval)
+	assert.Equal(t, 30*time.Second, cfg.Gatekeeper.CacheDisk.CheckInterval)
+	assert.Equal(t, 80, cfg.Gatekeeper.CacheDisk.MaxUsagePercent)
+	assert.Equal(t, 60*time.Second, cfg.Notifications.Pushover.RetryInterval)
+	assert.Equal(t, time.Hour, cfg.Notifications.Pushover.ExpireTime)
+	assert.Equal(t, 5*time.Minute, cfg.Sync.ScanInterval)
+	assert.Equal(t, 2*time.Second, cfg.Jobs.SummaryCacheTTL)
+	assert.Equal(t, 7*24*time.Hour, cfg.Jobs.DeletedJobRetention)
+	assert.Equal(t, 10*time.Second, cfg.Jobs.ValidateRemotePathTimeout)
+	assert.Equal(t, 24*time.Hour, cfg.Gatekeeper.CacheDisk.Eviction.MinAge)
+	assert.Equal(t, 5*time.Minute, cfg.Hooks.Timeout)
+	assert.Equal(t, time.Hour, cfg.Database.MaintenanceInterval)
+	assert.Equal(t, 7*24*time.Hour, cfg.Database.VacuumInterval)
+	assert.Equal(t, 5*time.Second, cfg.Jobs.ProgressPersistInterval)
+	assert.Equal(t, 1.0, cfg.Jobs.ProgressPersistMinDelta)
+
+	// Settings where zero is meaningful are left alone
+	assert.Zero(t, cfg.Jobs.MaxRetries)
+	assert.Zero(t, cfg.Jobs.MaxJobDuration)
+	assert.Zero(t, cfg.Jobs.StallTimeout)
+}
+
+func TestApplyDefaults_KeepsExplicitValues(t *testing.T) {
+	cfg := &Config{
+		Server: ServerConfig{Port: 9090, ShutdownTimeout: time.Minute},
+		Jobs:   JobsConfig{MaxConcurrent: 2},
+		Gatekeeper: GatekeeperConfig{
+			Seedbox:   SeedboxConfig{CheckInterval: 10 * time.Second},
+			CacheDisk: CacheDiskConfig{CheckInterval: -time.Second, MaxUsagePercent: 95},
+		},
+	}
+
+	cfg.ApplyDefaults()
+
+	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.Equal(t, time.Minute, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, 2, cfg.Jobs.MaxConcurrent)
+	assert.Equal(t, 10*time.Second, cfg.Gatekeeper.Seedbox.CheckInterval)
+	assert.Equal(t, -time.Second, cfg.Gatekeeper.CacheDisk.CheckInterval)
+	assert.Equal(t, 95, cfg.Gatekeeper.CacheDisk.MaxUsagePercent)
+}
+
+func TestOverrideJobs_SurvivesReload(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	writeConfig := func(maxConcurrent string) {
+		content := `
+downloads:
+  local_path: "` + tmpDir + `/downloads"
+database:
+  path: "` + filepath.Join(tmpDir, "grabarr.db") + `"
+jobs:
+  max_concurrent: ` + maxConcurrent + `
+`
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	}
+	writeConfig("3")
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+	changes := cfg.WatchForChanges()
+
+	applied, err := cfg.OverrideJobs(JobsConfig{MaxRetries: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 5, applied.MaxConcurrent, "zero settings get their defaults")
+	<-changes
+
+	_, err = cfg.OverrideJobs(JobsConfig{MaxConcurrent: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.GetJobs().MaxConcurrent)
+	<-changes
+
+	_, err = cfg.OverrideJobs(JobsConfig{MaxRetries: -1})
+	assert.EqualError(t, err, "max_retries cannot be negative")
+	assert.Equal(t, 7, cfg.GetJobs().MaxConcurrent)
+
+	// A reload keeps the override but remembers the file's new values
+	writeConfig("4")
+	require.NoError(t, cfg.reload(configPath))
+	assert.Equal(t, 7, cfg.GetJobs().MaxConcurrent)
+
+	cfg.ClearJobsOverride()
+	assert.Equal(t, 4, cfg.GetJobs().MaxConcurrent)
+	<-changes
+}
+
+func TestOverrideGatekeeper(t *testing.T) {
+	cfg := &Config{
+		Server:     ServerConfig{Port: 8080},
+		Jobs:       JobsConfig{MaxConcurrent: 1},
+		Gatekeeper: GatekeeperConfig{Seedbox: SeedboxConfig{BandwidthLimitMbps: 100}},
+	}
+
+	_, err := cfg.OverrideGatekeeper(GatekeeperConfig{CacheDisk: CacheDiskConfig{MinFreeBytes: -1}})
+	assert.EqualError(t, err, "min_free_bytes cannot be negative")
+
+	_, err = cfg.OverrideGatekeeper(GatekeeperConfig{Seedbox: SeedboxConfig{BandwidthLimitMbps: 300}})
+	require.NoError(t, err)
+	assert.Equal(t, 300, cfg.GetGatekeeper().Seedbox.BandwidthLimitMbps)
+
+	cfg.ClearGatekeeperOverride()
+	assert.Equal(t, 100, cfg.GetGatekeeper().Seedbox.BandwidthLimitMbps)
+}
+
 func TestLoadConfigWithEnvVars(t *testing.T) {
 	// Create temp directories
 	tmpDir := t.TempDir()
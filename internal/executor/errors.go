@@ -3,9 +3,11 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os/exec"
 	"strings"
 
+	"grabarr/internal/models"
 	"grabarr/internal/rsync"
 )
 
@@ -31,6 +33,10 @@ func classifyRsyncError(err error) error {
 	if !errors.As(err, &exitErr) {
 		return err // not an exit error — treat as retryable
 	}
+	// A rejected SSH key won't start working on its own
+	if ClassifyFailure(err) == models.FailureCategoryAuth {
+		return &PermanentError{Cause: err, Msg: fmt.Sprintf("rsync authentication failure (exit %d)", exitErr.ExitCode())}
+	}
 	switch exitErr.ExitCode() {
 	case 1, // syntax/usage error
 		2,  // protocol incompatibility
@@ -53,6 +59,95 @@ func classifyRsyncError(err error) error {
 	}
 }
 
+// classifyURLError wraps a failed copyurl download as PermanentError when the
+// server rejected the request outright. Timeouts, rate limiting and server
+// errors are left retryable.
+func classifyURLError(err error) error {
+	var statusErr *rsync.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	switch code := statusErr.StatusCode; {
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests:
+		return err
+	case code >= 400 && code < 500:
+		return &PermanentError{Cause: err, Msg: fmt.Sprintf("download rejected (HTTP %d)", code)}
+	default:
+		return err
+	}
+}
+
+// failurePatterns maps lowercase rsync/ssh stderr fragments to failure categories,
+// checked in order.
+var failurePatterns = []struct {
+	pattern  string
+	category models.FailureCategory
+}{
+	{"permission denied (publickey", models.FailureCategoryAuth},
+	{"authentication failed", models.FailureCategoryAuth},
+	{"host key verification failed", models.FailureCategoryAuth},
+	{"no space left on device", models.FailureCategoryDisk},
+	{"disk quota exceeded", models.FailureCategoryDisk},
+	{"read-only file system", models.FailureCategoryDisk},
+	{"no such file or directory", models.FailureCategoryNotFound},
+	{"connection timed out", models.FailureCategoryNetwork},
+	{"connection refused", models.FailureCategoryNetwork},
+	{"connection reset", models.FailureCategoryNetwork},
+	{"connection closed", models.FailureCategoryNetwork},
+	{"broken pipe", models.FailureCategoryNetwork},
+	{"could not resolve hostname", models.FailureCategoryNetwork},
+	{"network is unreachable", models.FailureCategoryNetwork},
+	{"transfer stalled", models.FailureCategoryNetwork},
+}
+
+// ClassifyFailure buckets a failed transfer into a FailureCategory using the
+// rsync stderr when available, falling back to the rsync exit code.
+func ClassifyFailure(err error) models.FailureCategory {
+	if err == nil {
+		return ""
+	}
+
+	text := err.Error()
+	var te *rsync.TransferError
+	if errors.As(err, &te) {
+		text = te.Stderr + "\n" + text
+	}
+	lower := strings.ToLower(text)
+	for _, p := range failurePatterns {
+		if strings.Contains(lower, p.pattern) {
+			return p.category
+		}
+	}
+
+	var statusErr *rsync.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch code := statusErr.StatusCode; {
+		case code == http.StatusUnauthorized, code == http.StatusForbidden:
+			return models.FailureCategoryAuth
+		case code == http.StatusNotFound, code == http.StatusGone:
+			return models.FailureCategoryNotFound
+		case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+			return models.FailureCategoryNetwork
+		}
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		switch exitErr.ExitCode() {
+		case 3: // file selection error (source not found)
+			return models.FailureCategoryNotFound
+		case 10, // socket I/O
+			12,  // protocol data stream
+			30,  // timeout in data send/receive
+			35,  // timeout waiting for daemon connection
+			255: // ssh connection failure
+			return models.FailureCategoryNetwork
+		}
+	}
+
+	return models.FailureCategoryUnknown
+}
+
 // classifyRcloneError inspects the rclone daemon error message string and returns a
 // PermanentError for conditions that cannot be fixed by retrying.
 func classifyRcloneError(errMsg string) error {
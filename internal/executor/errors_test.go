@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"grabarr/internal/models"
 	"grabarr/internal/rsync"
 )
 
@@ -133,6 +134,78 @@ func TestClassifyRsyncError_Exit23WithStderr(t *testing.T) {
 	})
 }
 
+func TestClassifyRsyncError_AuthFailureIsPermanent(t *testing.T) {
+	wrapped := &rsync.TransferError{
+		Err:    fmt.Errorf("rsync transfer failed: %w", makeExitError(t, 255)),
+		Stderr: "user@seedbox: Permission denied (publickey).",
+	}
+	assert.True(t, IsPermanent(classifyRsyncError(wrapped)))
+}
+
+func TestClassifyFailure(t *testing.T) {
+	withStderr := func(code int, stderr string) error {
+		return &rsync.TransferError{
+			Err:    fmt.Errorf("rsync transfer failed: %w", makeExitError(t, code)),
+			Stderr: stderr,
+		}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want models.FailureCategory
+	}{
+		{"nil", nil, ""},
+		{"publickey rejected", withStderr(255, "user@host: Permission denied (publickey,password)."), models.FailureCategoryAuth},
+		{"host key", withStderr(255, "Host key verification failed."), models.FailureCategoryAuth},
+		{"disk full", withStderr(11, "write failed on \"/downloads/a.mkv\": No space left on device (28)"), models.FailureCategoryDisk},
+		{"quota", withStderr(11, "Disk quota exceeded (122)"), models.FailureCategoryDisk},
+		{"missing source", withStderr(23, `change_dir "/remote/x" failed: No such file or directory (2)`), models.FailureCategoryNotFound},
+		{"exit 3 without stderr", withStderr(3, ""), models.FailureCategoryNotFound},
+		{"connection refused", withStderr(255, "ssh: connect to host seedbox port 22: Connection refused"), models.FailureCategoryNetwork},
+		{"dns", withStderr(255, "ssh: Could not resolve hostname seedbox: Name or service not known"), models.FailureCategoryNetwork},
+		{"exit 30 timeout", withStderr(30, ""), models.FailureCategoryNetwork},
+		{"exit 255 without stderr", withStderr(255, ""), models.FailureCategoryNetwork},
+		{"stalled", errors.New("transfer stalled: no progress for 10m0s"), models.FailureCategoryNetwork},
+		{"wrapped permanent error", &PermanentError{Cause: withStderr(255, "Permission denied (publickey)."), Msg: "auth"}, models.FailureCategoryAuth},
+		{"partial transfer", withStderr(23, "some files could not be transferred"), models.FailureCategoryUnknown},
+		{"plain error", errors.New("something odd"), models.FailureCategoryUnknown},
+		{"url forbidden", httpStatus(403), models.FailureCategoryAuth},
+		{"url not found", httpStatus(404), models.FailureCategoryNotFound},
+		{"url server error", httpStatus(502), models.FailureCategoryNetwork},
+		{"url rate limited", httpStatus(429), models.FailureCategoryNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyFailure(tt.err))
+		})
+	}
+}
+
+func httpStatus(code int) error {
+	return fmt.Errorf("download failed: %w", &rsync.HTTPStatusError{StatusCode: code, Status: fmt.Sprintf("%d", code)})
+}
+
+func TestClassifyURLError(t *testing.T) {
+	tests := []struct {
+		err       error
+		permanent bool
+	}{
+		{httpStatus(404), true},
+		{httpStatus(403), true},
+		{httpStatus(400), true},
+		{httpStatus(408), false},
+		{httpStatus(429), false},
+		{httpStatus(503), false},
+		{errors.New("connection reset by peer"), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.permanent, IsPermanent(classifyURLError(tt.err)), tt.err.Error())
+	}
+}
+
 func TestClassifyRsyncError_NonExitError(t *testing.T) {
 	err := errors.New("connection reset by peer")
 	result := classifyRsyncError(err)
@@ -0,0 +1,114 @@
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"grabarr/internal/models"
+)
+
+// progressSubscriberBuffer is how many updates a subscriber can fall behind
+// before further updates to it are dropped.
+const progressSubscriberBuffer = 16
+
+// ProgressUpdate is a progress report for one running job.
+type ProgressUpdate struct {
+	JobID    int64
+	Progress models.JobProgress
+}
+
+// progressPublisher fans progress updates out to any number of subscribers.
+// Publishing never blocks: a subscriber whose buffer is full misses updates
+// until it catches up, so a slow reader can't stall a transfer's monitor.
+// It also keeps each running job's latest update, which may be newer than
+// what has been persisted.
+type progressPublisher struct {
+	mu     sync.RWMutex
+	subs   map[<-chan ProgressUpdate]chan ProgressUpdate
+	latest map[int64]models.JobProgress
+}
+
+func newProgressPublisher() *progressPublisher {
+	return &progressPublisher{
+		subs:   make(map[<-chan ProgressUpdate]chan ProgressUpdate),
+		latest: make(map[int64]models.JobProgress),
+	}
+}
+
+func (p *progressPublisher) subscribe() <-chan ProgressUpdate {
+	ch := make(chan ProgressUpdate, progressSubscriberBuffer)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subs[ch] = ch
+	return ch
+}
+
+// unsubscribe removes and closes a subscription. Unknown or already removed
+// channels are ignored.
+func (p *progressPublisher) unsubscribe(ch <-chan ProgressUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if sub, ok := p.subs[ch]; ok {
+		delete(p.subs, ch)
+		close(sub)
+	}
+}
+
+func (p *progressPublisher) publish(update ProgressUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.latest[update.JobID] = update.Progress
+
+	for _, sub := range p.subs {
+		select {
+		case sub <- update:
+		default:
+			// Subscriber is behind; drop rather than block
+		}
+	}
+}
+
+// latestFor returns the last progress published for a job still being tracked.
+func (p *progressPublisher) latestFor(jobID int64) (models.JobProgress, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	progress, ok := p.latest[jobID]
+	return progress, ok
+}
+
+// forget stops tracking a job's latest progress once its transfer has ended.
+func (p *progressPublisher) forget(jobID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.latest, jobID)
+}
+
+// progressThrottle decides which progress updates are written to the database.
+// An update is persisted once interval has passed since the last write, or
+// sooner when the percentage has moved by more than minDelta points. Skipped
+// updates still reach the job in memory and progress subscribers.
+type progressThrottle struct {
+	interval    time.Duration
+	minDelta    float64
+	lastWrite   time.Time
+	lastPercent float64
+}
+
+// newProgressThrottle starts a throttle for a transfer beginning at start.
+func newProgressThrottle(interval time.Duration, minDelta float64, start time.Time) *progressThrottle {
+	return &progressThrottle{interval: interval, minDelta: minDelta, lastWrite: start}
+}
+
+// shouldPersist reports whether an update at percent should be written, and
+// if so records it as the last write.
+func (t *progressThrottle) shouldPersist(percent float64, now time.Time) bool {
+	if now.Sub(t.lastWrite) < t.interval && percent-t.lastPercent <= t.minDelta {
+		return false
+	}
+	t.lastWrite = now
+	t.lastPercent = percent
+	return true
+}
@@ -0,0 +1,127 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"grabarr/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressPublisher_FansOutToAllSubscribers(t *testing.T) {
+	p := newProgressPublisher()
+	a := p.subscribe()
+	b := p.subscribe()
+
+	p.publish(ProgressUpdate{JobID: 7, Progress: models.JobProgress{Percentage: 42}})
+
+	for _, ch := range []<-chan ProgressUpdate{a, b} {
+		select {
+		case update := <-ch:
+			assert.Equal(t, int64(7), update.JobID)
+			assert.Equal(t, 42.0, update.Progress.Percentage)
+		case <-time.After(time.Second):
+			t.Fatal("subscriber did not receive update")
+		}
+	}
+}
+
+func TestProgressPublisher_SlowSubscriberDoesNotBlock(t *testing.T) {
+	p := newProgressPublisher()
+	slow := p.subscribe()
+	fast := p.subscribe()
+
+	received := make(chan int, progressSubscriberBuffer*4)
+	go func() {
+		for update := range fast {
+			received <- int(update.Progress.Percentage)
+		}
+	}()
+
+	// slow is never read; publishing past its buffer must still return
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < progressSubscriberBuffer*2; i++ {
+			p.publish(ProgressUpdate{JobID: 1, Progress: models.JobProgress{Percentage: float64(i)}})
+			time.Sleep(time.Millisecond)
+		}
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+
+	assert.Len(t, slow, progressSubscriberBuffer)
+	assert.Eventually(t, func() bool { return len(received) == progressSubscriberBuffer*2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestProgressPublisher_Unsubscribe(t *testing.T) {
+	p := newProgressPublisher()
+	ch := p.subscribe()
+
+	p.unsubscribe(ch)
+	p.unsubscribe(ch) // already removed; ignored
+
+	_, ok := <-ch
+	require.False(t, ok, "channel should be closed")
+
+	// Publishing with no subscribers is a no-op
+	p.publish(ProgressUpdate{JobID: 1})
+}
+
+func TestProgressPublisher_LatestProgress(t *testing.T) {
+	p := newProgressPublisher()
+
+	_, ok := p.latestFor(7)
+	assert.False(t, ok)
+
+	p.publish(ProgressUpdate{JobID: 7, Progress: models.JobProgress{Percentage: 10}})
+	p.publish(ProgressUpdate{JobID: 7, Progress: models.JobProgress{Percentage: 20}})
+
+	progress, ok := p.latestFor(7)
+	require.True(t, ok)
+	assert.Equal(t, 20.0, progress.Percentage)
+
+	p.forget(7)
+	_, ok = p.latestFor(7)
+	assert.False(t, ok)
+}
+
+func TestProgressThrottle_SkipsUnchangedProgress(t *testing.T) {
+	start := time.Now()
+	throttle := newProgressThrottle(10*time.Second, 1, start)
+
+	// Slow transfer: the percentage barely moves between updates
+	for i, percent := range []float64{0.1, 0.2, 0.4, 0.6, 0.9} {
+		assert.False(t, throttle.shouldPersist(percent, start.Add(time.Duration(i+1)*time.Second)), "update %d", i)
+	}
+
+	// Once the interval has passed, the next update is written
+	assert.True(t, throttle.shouldPersist(1.0, start.Add(10*time.Second)))
+	assert.False(t, throttle.shouldPersist(1.1, start.Add(12*time.Second)))
+}
+
+func TestProgressThrottle_PersistsLargeChanges(t *testing.T) {
+	start := time.Now()
+	throttle := newProgressThrottle(time.Minute, 5, start)
+
+	assert.False(t, throttle.shouldPersist(5, start.Add(time.Second)))
+	assert.True(t, throttle.shouldPersist(5.5, start.Add(2*time.Second)))
+	// The delta is measured from the last write, not the last update
+	assert.False(t, throttle.shouldPersist(8, start.Add(3*time.Second)))
+	assert.False(t, throttle.shouldPersist(10.5, start.Add(4*time.Second)))
+	assert.True(t, throttle.shouldPersist(11, start.Add(5*time.Second)))
+}
+
+func TestProgressThrottle_ZeroSettingsPersistEveryChange(t *testing.T) {
+	start := time.Now()
+	throttle := newProgressThrottle(0, 0, start)
+
+	assert.True(t, throttle.shouldPersist(0.5, start))
+	assert.True(t, throttle.shouldPersist(0.5, start.Add(time.Millisecond)))
+}
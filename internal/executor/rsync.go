@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"grabarr/internal/config"
+	"grabarr/internal/httpclient"
 	"grabarr/internal/interfaces"
 	"grabarr/internal/models"
 	"grabarr/internal/rsync"
@@ -17,6 +21,7 @@ type RsyncExecutor struct {
 	gatekeeper interfaces.Gatekeeper
 	client     *rsync.Client
 	repo       interfaces.JobRepository
+	progress   *progressPublisher
 }
 
 func NewRsyncExecutor(cfg *config.Config, gatekeeper interfaces.Gatekeeper, repo interfaces.JobRepository) *RsyncExecutor {
@@ -26,12 +31,14 @@ func NewRsyncExecutor(cfg *config.Config, gatekeeper interfaces.Gatekeeper, repo
 	}
 	r := remotes[0]
 	client := rsync.NewClient(r.SSHHost, r.SSHUser, r.SSHKeyFile)
+	client.SetHTTPClient(httpclient.New(cfg.GetNotifications().HTTP, httpclient.WithoutTimeout()))
 
 	return &RsyncExecutor{
 		config:     cfg,
 		gatekeeper: gatekeeper,
 		client:     client,
 		repo:       repo,
+		progress:   newProgressPublisher(),
 	}
 }
 
@@ -53,78 +60,237 @@ func (r *RsyncExecutor) Execute(ctx context.Context, job *models.Job) error {
 
 	slog.Info("starting rsync execution", "job_id", job.ID, "name", job.Name)
 
-	// Prepare rsync paths
-	remotePath := job.RemotePath
-	localPath := job.LocalPath
-
 	// Ensure local path exists and is a directory
 	// rsync will create the target file/directory inside localPath
-	if !filepath.IsAbs(localPath) {
-		return &PermanentError{Msg: fmt.Sprintf("local path must be absolute: %s", localPath)}
+	if !filepath.IsAbs(job.LocalPath) {
+		return &PermanentError{Msg: fmt.Sprintf("local path must be absolute: %s", job.LocalPath)}
 	}
 
-	slog.Info("prepared rsync request",
-		"job_id", job.ID,
-		"remote_path", remotePath,
-		"local_path", localPath)
+	if err := resolveDestination(job, r.config.GetDownloads()); err != nil {
+		return err
+	}
+
+	// Prepare rsync paths
+	remotePath := job.RemotePath
+	localPath := job.LocalPath
 
-	// Start the transfer
-	transfer, err := r.client.Copy(ctx, remotePath, localPath)
+	transfer, err := r.startTransfer(ctx, job, remotePath, localPath)
 	if err != nil {
-		return fmt.Errorf("failed to start rsync: %w", err)
+		return err
 	}
 
-	slog.Info("rsync transfer started", "job_id", job.ID)
+	slog.Info("transfer started", "job_id", job.ID)
+	defer r.progress.forget(job.ID)
+
+	// Progress only moves forward within an attempt; a new attempt starts over
+	job.Progress.Percentage = 0
+
+	jobsConfig := r.config.GetJobs()
+	stallTimeout := jobsConfig.StallTimeout
+	stall := newStallDetector(stallTimeout, time.Now())
+	throttle := newProgressThrottle(jobsConfig.ProgressPersistInterval, jobsConfig.ProgressPersistMinDelta, time.Now())
 
 	// Monitor progress in a goroutine
 	progressDone := make(chan struct{})
 	go func() {
 		defer close(progressDone)
 		for progress := range transfer.ProgressChan() {
-			// Update job progress
-			job.Progress.Percentage = progress.Percentage
-			job.Progress.TransferredBytes = progress.TransferredBytes
-			job.Progress.TransferSpeed = progress.TransferSpeed
-			job.Progress.LastUpdateTime = progress.LastUpdateTime
-			if progress.ETA != nil {
-				job.Progress.ETA = progress.ETA
-			}
+			recordProgress(job, progress)
+			stall.observe(progress.TransferredBytes, time.Now())
+			r.progress.publish(ProgressUpdate{JobID: job.ID, Progress: job.Progress})
 
-			// Persist to database
+			// Persist to database; the final state is always written below
+			if !throttle.shouldPersist(job.Progress.Percentage, time.Now()) {
+				continue
+			}
 			if err := r.repo.UpdateJob(job); err != nil {
 				slog.Error("failed to update job progress", "job_id", job.ID, "error", err)
 			}
 		}
 	}()
 
-	// Wait for transfer to complete or context cancellation
-	select {
-	case <-ctx.Done():
-		// Context cancelled, stop the transfer
-		transfer.Stop()
-		<-progressDone // Wait for progress goroutine to finish
-		return ctx.Err()
-
-	case err := <-transfer.Done():
-		// Transfer completed or failed
-		<-progressDone // Wait for progress goroutine to finish
-
-		// Final persist
-		if err := r.repo.UpdateJob(job); err != nil {
-			slog.Error("failed to persist final job state", "job_id", job.ID, "error", err)
+	var stallCheck <-chan time.Time
+	if stallTimeout > 0 {
+		ticker := time.NewTicker(min(stallTimeout, maxStallCheckInterval))
+		defer ticker.Stop()
+		stallCheck = ticker.C
+	}
+
+	// Wait for transfer to complete, context cancellation, or a stall
+	for {
+		select {
+		case <-ctx.Done():
+			// Context cancelled, stop the transfer
+			transfer.Stop()
+			<-progressDone // Wait for progress goroutine to finish
+			return ctx.Err()
+
+		case now := <-stallCheck:
+			if !stall.stalled(now) {
+				continue
+			}
+			slog.Warn("rsync transfer stalled, stopping", "job_id", job.ID, "stall_timeout", stallTimeout)
+			transfer.Stop()
+			<-progressDone
+			if err := r.repo.UpdateJob(job); err != nil {
+				slog.Error("failed to persist final job state", "job_id", job.ID, "error", err)
+			}
+			return fmt.Errorf("transfer stalled: no progress for %s", stallTimeout)
+
+		case err := <-transfer.Done():
+			// Transfer completed or failed
+			<-progressDone // Wait for progress goroutine to finish
+
+			// Final persist
+			if err := r.repo.UpdateJob(job); err != nil {
+				slog.Error("failed to persist final job state", "job_id", job.ID, "error", err)
+			}
+
+			if err != nil {
+				if job.IsCopyURLJob() {
+					return classifyURLError(fmt.Errorf("download failed: %w", err))
+				}
+				return classifyRsyncError(fmt.Errorf("rsync transfer failed: %w", err))
+			}
+
+			slog.Info("rsync transfer completed successfully", "job_id", job.ID)
+			return nil
 		}
+	}
+}
 
+// startTransfer starts the job's transfer: an HTTP(S) download for copyurl
+// jobs, otherwise rsync from the seedbox.
+func (r *RsyncExecutor) startTransfer(ctx context.Context, job *models.Job, remotePath, localPath string) (*rsync.Transfer, error) {
+	if job.IsCopyURLJob() {
+		slog.Info("prepared copyurl request",
+			"job_id", job.ID,
+			"source_url", models.RedactURL(job.SourceURL),
+			"local_path", localPath)
+
+		transfer, err := r.client.CopyURL(ctx, job.SourceURL, localPath)
 		if err != nil {
-			return classifyRsyncError(fmt.Errorf("rsync transfer failed: %w", err))
+			return nil, &PermanentError{Msg: "invalid source URL", Cause: err}
 		}
+		return transfer, nil
+	}
 
-		slog.Info("rsync transfer completed successfully", "job_id", job.ID)
-		return nil
+	opts, err := copyOptions(job)
+	if err != nil {
+		return nil, err
 	}
+
+	slog.Info("prepared rsync request",
+		"job_id", job.ID,
+		"remote_path", remotePath,
+		"local_path", localPath,
+		"bw_limit", opts.BwLimit)
+
+	transfer, err := r.client.Copy(ctx, remotePath, localPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start rsync: %w", err)
+	}
+	return transfer, nil
 }
 
-func (r *RsyncExecutor) GetProgressChannel() <-chan models.JobProgress {
-	// rsync executor doesn't use a shared progress channel
-	// Progress is handled directly in Execute()
+// copyOptions applies the job's download_config overrides to the transfer.
+// Only bw_limit has an rsync equivalent; rclone's "off" means no limit. Job
+// settings override defaults, and the default for rsync is unlimited.
+func copyOptions(job *models.Job) (rsync.CopyOptions, error) {
+	var opts rsync.CopyOptions
+	if job.DownloadConfig == nil || job.DownloadConfig.BwLimit == nil {
+		return opts, nil
+	}
+
+	limit := strings.TrimSpace(*job.DownloadConfig.BwLimit)
+	if limit == "" || strings.EqualFold(limit, "off") {
+		return opts, nil
+	}
+	if !models.IsValidBwLimit(limit) {
+		return opts, &PermanentError{Msg: fmt.Sprintf("unsupported bw_limit %q: use a rate like 10M or 512K", limit)}
+	}
+	opts.BwLimit = limit
+	return opts, nil
+}
+
+// resolveDestination applies the job's dest_template to its LocalPath. Jobs
+// created through the API are resolved when they are created; this covers jobs
+// queued any other way, and is a no-op for jobs already resolved. Rewriting
+// LocalPath keeps extraction and eviction pointed at the real files.
+func resolveDestination(job *models.Job, downloads config.DownloadsConfig) error {
+	if err := job.ApplyDestTemplate(downloads.DestTemplateForJob(job)); err != nil {
+		return &PermanentError{Msg: "invalid destination", Cause: err}
+	}
 	return nil
 }
+
+// maxStallCheckInterval caps how often a running transfer is checked for stalls.
+const maxStallCheckInterval = 10 * time.Second
+
+// stallDetector reports when a transfer's byte count has not increased for
+// longer than its timeout. A zero timeout never stalls.
+type stallDetector struct {
+	mu           sync.Mutex
+	timeout      time.Duration
+	lastBytes    int64
+	lastProgress time.Time
+}
+
+func newStallDetector(timeout time.Duration, start time.Time) *stallDetector {
+	return &stallDetector{timeout: timeout, lastProgress: start}
+}
+
+// observe records a progress sample, resetting the stall clock when bytes increased.
+func (d *stallDetector) observe(bytes int64, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if bytes > d.lastBytes {
+		d.lastBytes = bytes
+		d.lastProgress = now
+	}
+}
+
+// stalled reports whether the timeout has passed since bytes last increased.
+func (d *stallDetector) stalled(now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.timeout > 0 && now.Sub(d.lastProgress) >= d.timeout
+}
+
+// recordProgress applies an rsync progress update to the job. The percentage
+// is smoothed so it never goes backwards within an attempt. Each rsync process
+// transfers exactly one job, so its reported speed is that job's live speed and is
+// also stored on the job's top-level transfer fields.
+func recordProgress(job *models.Job, progress *models.JobProgress) {
+	job.Progress.ObservePercentage(progress.Percentage)
+	job.Progress.TransferredBytes = progress.TransferredBytes
+	job.Progress.TransferSpeed = progress.TransferSpeed
+	job.Progress.LastUpdateTime = progress.LastUpdateTime
+	if progress.ETA != nil {
+		job.Progress.ETA = progress.ETA
+	}
+	if progress.CurrentFile != "" {
+		job.Progress.CurrentFile = progress.CurrentFile
+	}
+
+	job.TransferredBytes = progress.TransferredBytes
+	job.TransferSpeed = progress.TransferSpeed
+}
+
+// SubscribeProgress returns a channel receiving progress updates for every
+// running job. Updates are dropped for a subscriber that falls behind; call
+// UnsubscribeProgress when done to release it.
+func (r *RsyncExecutor) SubscribeProgress() <-chan ProgressUpdate {
+	return r.progress.subscribe()
+}
+
+// LatestProgress returns the most recent progress of a running transfer. It
+// can be ahead of the database, which jobs.progress_persist_interval throttles.
+func (r *RsyncExecutor) LatestProgress(jobID int64) (models.JobProgress, bool) {
+	return r.progress.latestFor(jobID)
+}
+
+// UnsubscribeProgress stops and closes a channel from SubscribeProgress.
+func (r *RsyncExecutor) UnsubscribeProgress(ch <-chan ProgressUpdate) {
+	r.progress.unsubscribe(ch)
+}
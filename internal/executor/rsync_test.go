@@ -0,0 +1,279 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"grabarr/internal/config"
+	"grabarr/internal/mocks"
+	"grabarr/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordProgress(t *testing.T) {
+	job := &models.Job{ID: 1, FileSize: 1000}
+	eta := time.Now().Add(time.Minute)
+	now := time.Now()
+
+	recordProgress(job, &models.JobProgress{
+		Percentage:       25,
+		TransferredBytes: 250,
+		TransferSpeed:    1024,
+		ETA:              &eta,
+		LastUpdateTime:   now,
+	})
+
+	assert.Equal(t, 25.0, job.Progress.Percentage)
+	assert.Equal(t, int64(250), job.Progress.TransferredBytes)
+	assert.Equal(t, int64(1024), job.Progress.TransferSpeed)
+	assert.Equal(t, &eta, job.Progress.ETA)
+	assert.Equal(t, now, job.Progress.LastUpdateTime)
+
+	// Per-job speed is recorded on the job itself
+	assert.Equal(t, int64(250), job.TransferredBytes)
+	assert.Equal(t, int64(1024), job.TransferSpeed)
+}
+
+func TestRecordProgress_KeepsPreviousETA(t *testing.T) {
+	eta := time.Now().Add(time.Hour)
+	job := &models.Job{Progress: models.JobProgress{ETA: &eta}}
+
+	recordProgress(job, &models.JobProgress{TransferredBytes: 10, TransferSpeed: 5})
+
+	assert.Equal(t, &eta, job.Progress.ETA)
+	assert.Equal(t, int64(5), job.TransferSpeed)
+}
+
+func TestRecordProgress_CurrentFile(t *testing.T) {
+	job := &models.Job{}
+
+	recordProgress(job, &models.JobProgress{TransferredBytes: 10, CurrentFile: "Show.S01/E01.mkv"})
+	assert.Equal(t, "Show.S01/E01.mkv", job.Progress.CurrentFile)
+
+	// Updates without a file name keep the last known one
+	recordProgress(job, &models.JobProgress{TransferredBytes: 20})
+	assert.Equal(t, "Show.S01/E01.mkv", job.Progress.CurrentFile)
+}
+
+func TestRecordProgress_PercentageNeverRegresses(t *testing.T) {
+	job := &models.Job{}
+
+	// rsync's total grows as it discovers files, so raw percentages can drop
+	raw := []float64{5, 40, 12, 38, 60, 59, 100}
+	highest := 0.0
+	for _, r := range raw {
+		recordProgress(job, &models.JobProgress{Percentage: r})
+		assert.GreaterOrEqual(t, job.Progress.Percentage, highest, "raw %v", r)
+		highest = job.Progress.Percentage
+	}
+	assert.Equal(t, 100.0, job.Progress.Percentage)
+}
+
+func TestStallDetector(t *testing.T) {
+	start := time.Now()
+	d := newStallDetector(time.Minute, start)
+
+	// Byte counts that don't increase never reset the clock
+	for i, bytes := range []int64{0, 0, 0} {
+		d.observe(bytes, start.Add(time.Duration(i)*20*time.Second))
+	}
+	assert.False(t, d.stalled(start.Add(59*time.Second)))
+	assert.True(t, d.stalled(start.Add(time.Minute)))
+
+	// Progress resets it
+	d.observe(100, start.Add(70*time.Second))
+	assert.False(t, d.stalled(start.Add(90*time.Second)))
+
+	d.observe(100, start.Add(100*time.Second))
+	d.observe(50, start.Add(110*time.Second))
+	assert.False(t, d.stalled(start.Add(129*time.Second)))
+	assert.True(t, d.stalled(start.Add(130*time.Second)))
+}
+
+func TestStallDetector_Disabled(t *testing.T) {
+	start := time.Now()
+	d := newStallDetector(0, start)
+
+	assert.False(t, d.stalled(start.Add(24*time.Hour)))
+}
+
+func TestCopyOptions_BwLimit(t *testing.T) {
+	limit := func(s string) *models.Job {
+		return &models.Job{DownloadConfig: &models.DownloadConfig{BwLimit: &s}}
+	}
+
+	tests := []struct {
+		name    string
+		job     *models.Job
+		want    string
+		wantErr bool
+	}{
+		{"no download config", &models.Job{}, "", false},
+		{"no bw_limit", &models.Job{DownloadConfig: &models.DownloadConfig{}}, "", false},
+		{"megabytes", limit("10M"), "10M", false},
+		{"fractional", limit("1.5m"), "1.5m", false},
+		{"bare KiB", limit("512"), "512", false},
+		{"off", limit("off"), "", false},
+		{"timetable", limit("08:00,512k 19:00,off"), "", true},
+		{"upload:download", limit("10M:1M"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := copyOptions(tt.job)
+			if tt.wantErr {
+				assert.True(t, IsPermanent(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, opts.BwLimit)
+		})
+	}
+}
+
+func TestResolveDestination(t *testing.T) {
+	created := time.Date(2024, 3, 7, 12, 0, 0, 0, time.UTC)
+	tmpl := func(s string) *models.DownloadConfig { return &models.DownloadConfig{DestTemplate: &s} }
+	downloads := config.DownloadsConfig{
+		DestTemplates: map[string]string{"movies": "{category}/{year}"},
+	}
+
+	tests := []struct {
+		name        string
+		job         *models.Job
+		wantPath    string
+		wantSubpath string
+	}{
+		{
+			name:     "no template",
+			job:      &models.Job{LocalPath: "/downloads", Metadata: models.JobMetadata{Category: "tv"}},
+			wantPath: "/downloads",
+		},
+		{
+			name:        "category template",
+			job:         &models.Job{LocalPath: "/downloads", CreatedAt: created, Metadata: models.JobMetadata{Category: "movies"}},
+			wantPath:    "/downloads/movies/2024",
+			wantSubpath: "movies/2024",
+		},
+		{
+			name: "job template overrides category",
+			job: &models.Job{
+				LocalPath:      "/downloads",
+				CreatedAt:      created,
+				Metadata:       models.JobMetadata{Category: "movies"},
+				DownloadConfig: tmpl("{year}-{month}-{day}"),
+			},
+			wantPath:    "/downloads/2024-03-07",
+			wantSubpath: "2024-03-07",
+		},
+		{
+			name: "extra fields",
+			job: &models.Job{
+				LocalPath: "/downloads",
+				Metadata: models.JobMetadata{ExtraFields: map[string]interface{}{
+					"show": "Some Show", "season": float64(2),
+				}},
+				DownloadConfig: tmpl("{extra.show}/Season {extra.season}"),
+			},
+			wantPath:    "/downloads/Some Show/Season 2",
+			wantSubpath: "Some Show/Season 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, resolveDestination(tt.job, downloads))
+			assert.Equal(t, tt.wantPath, tt.job.LocalPath)
+			if tt.wantSubpath == "" {
+				assert.NotContains(t, tt.job.Metadata.ExtraFields, models.DestSubpathField)
+			} else {
+				assert.Equal(t, tt.wantSubpath, tt.job.Metadata.ExtraFields[models.DestSubpathField])
+			}
+		})
+	}
+}
+
+func TestResolveDestination_AppliedOnce(t *testing.T) {
+	downloads := config.DownloadsConfig{DestTemplates: map[string]string{"movies": "{category}"}}
+	job := &models.Job{LocalPath: "/downloads", Metadata: models.JobMetadata{Category: "movies"}}
+
+	require.NoError(t, resolveDestination(job, downloads))
+	// A retry must not nest the subdirectory again
+	require.NoError(t, resolveDestination(job, downloads))
+	assert.Equal(t, "/downloads/movies", job.LocalPath)
+}
+
+func TestResolveDestination_Rejected(t *testing.T) {
+	tmpl := func(s string) *models.DownloadConfig { return &models.DownloadConfig{DestTemplate: &s} }
+
+	tests := []struct {
+		name string
+		job  *models.Job
+	}{
+		{"unknown key", &models.Job{DownloadConfig: tmpl("{season}")}},
+		{"traversal in template", &models.Job{DownloadConfig: tmpl("../{category}"), Metadata: models.JobMetadata{Category: "tv"}}},
+		{"traversal in value", &models.Job{DownloadConfig: tmpl("{category}"), Metadata: models.JobMetadata{Category: ".."}}},
+		{"separator in value", &models.Job{DownloadConfig: tmpl("{torrent_name}"), Metadata: models.JobMetadata{TorrentName: "a/../../etc"}}},
+		{"missing value", &models.Job{DownloadConfig: tmpl("{category}/{year}"), Metadata: models.JobMetadata{Category: "tv"}}},
+		{"missing extra field", &models.Job{DownloadConfig: tmpl("{extra.show}")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.job.LocalPath = "/downloads"
+			err := resolveDestination(tt.job, config.DownloadsConfig{})
+			assert.True(t, IsPermanent(err), "got %v", err)
+			assert.Equal(t, "/downloads", tt.job.LocalPath)
+		})
+	}
+}
+
+func TestExecute_CopyURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("episode contents"))
+	}))
+	defer server.Close()
+
+	repo := mocks.NewMockJobRepository(t)
+	repo.EXPECT().UpdateJob(mock.Anything).Return(nil).Maybe()
+
+	cfg := &config.Config{Remotes: []config.RemoteConfig{{Name: "seedbox"}}}
+	r := NewRsyncExecutor(cfg, nil, repo)
+
+	dir := t.TempDir()
+	job := &models.Job{ID: 1, Name: "episode", SourceURL: server.URL + "/dl/episode.mkv", LocalPath: dir}
+
+	require.NoError(t, r.Execute(context.Background(), job))
+
+	data, err := os.ReadFile(filepath.Join(dir, "episode.mkv"))
+	require.NoError(t, err)
+	assert.Equal(t, "episode contents", string(data))
+	assert.Equal(t, int64(len("episode contents")), job.TransferredBytes)
+}
+
+func TestExecute_CopyURLNotFoundIsPermanent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	repo := mocks.NewMockJobRepository(t)
+	repo.EXPECT().UpdateJob(mock.Anything).Return(nil).Maybe()
+
+	cfg := &config.Config{Remotes: []config.RemoteConfig{{Name: "seedbox"}}}
+	r := NewRsyncExecutor(cfg, nil, repo)
+
+	job := &models.Job{ID: 1, Name: "gone", SourceURL: server.URL + "/gone.mkv", LocalPath: t.TempDir()}
+
+	err := r.Execute(context.Background(), job)
+	assert.True(t, IsPermanent(err), "got %v", err)
+	assert.Equal(t, models.FailureCategoryNotFound, ClassifyFailure(err))
+}
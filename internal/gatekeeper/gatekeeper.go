@@ -20,8 +20,14 @@ type Gatekeeper struct {
 	mu             sync.RWMutex
 	bandwidthUsage float64 // Current bandwidth usage in Mbps
 	cacheUsage     float64 // Current cache usage percentage
+	cacheFree      int64   // Current free cache bytes; -1 until the first successful check
 	lastCheck      time.Time
 
+	// statfs is unix.Statfs; overridable for tests
+	statfs func(path string, buf *unix.Statfs_t) error
+	// now is time.Now; overridable for tests of the bandwidth schedule
+	now func() time.Time
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -38,6 +44,9 @@ func New(cfg *config.Config) *Gatekeeper {
 
 	return &Gatekeeper{
 		config:    cfg,
+		cacheFree: -1,
+		statfs:    unix.Statfs,
+		now:       time.Now,
 		ctx:       ctx,
 		cancel:    cancel,
 		lastCheck: time.Now(),
@@ -49,7 +58,7 @@ func (g *Gatekeeper) Start() error {
 	g.updateResourceStatus()
 
 	// Start monitoring loop
-	go g.monitorLoop()
+	go g.monitorLoop(g.config.WatchForChanges())
 
 	slog.Info("gatekeeper started")
 	return nil
@@ -68,15 +77,24 @@ func (g *Gatekeeper) CanStartJob(fileSize int64) interfaces.GateDecision {
 
 	gatekeeperCfg := g.config.GetGatekeeper()
 
-	// Rule 1: Check bandwidth availability
-	if g.bandwidthUsage >= float64(gatekeeperCfg.Seedbox.BandwidthLimitMbps) {
+	// Rule 1: Check bandwidth availability against the currently scheduled limit
+	bandwidthLimit := gatekeeperCfg.Seedbox.BandwidthLimitMbps
+	window, inWindow := gatekeeperCfg.Seedbox.ActiveBandwidthWindow(g.now())
+	if inWindow {
+		bandwidthLimit = window.BandwidthLimitMbps
+	}
+	if g.bandwidthUsage >= float64(bandwidthLimit) {
+		details := map[string]interface{}{
+			"current_mbps": g.bandwidthUsage,
+			"limit_mbps":   bandwidthLimit,
+		}
+		if inWindow {
+			details["bandwidth_window"] = window.Name
+		}
 		return interfaces.GateDecision{
 			Allowed: false,
 			Reason:  "Bandwidth limit reached",
-			Details: map[string]interface{}{
-				"current_mbps": g.bandwidthUsage,
-				"limit_mbps":   gatekeeperCfg.Seedbox.BandwidthLimitMbps,
-			},
+			Details: details,
 		}
 	}
 
@@ -93,24 +111,53 @@ func (g *Gatekeeper) CanStartJob(fileSize int64) interfaces.GateDecision {
 		}
 	}
 
+	// Rule 2b: Keep an absolute amount of cache space free, which a percentage
+	// can't express across disks of different sizes
+	minFreeBytes := int64(gatekeeperCfg.CacheDisk.MinFreeBytes)
+	if minFreeBytes > 0 && g.cacheFree >= 0 && g.cacheFree < minFreeBytes {
+		return interfaces.GateDecision{
+			Allowed: false,
+			Reason:  "Cache disk free space too low",
+			Details: map[string]interface{}{
+				"free_bytes":     g.cacheFree,
+				"min_free_bytes": minFreeBytes,
+			},
+		}
+	}
+
 	// Rule 3: Check if filesize fits in available space
 	if gatekeeperCfg.Rules.RequireFilesizeCheck && fileSize > 0 {
-		stat, err := g.getCacheDiskStats()
+		usage, err := g.DiskUsage(gatekeeperCfg.CacheDisk.Path)
 		if err != nil {
-			slog.Error("failed to check cache disk stats", "error", err)
+			err = fmt.Errorf("failed to stat cache disk: %w", err)
+			if gatekeeperCfg.Rules.FailOpenOnStatError {
+				slog.Error("cache disk unavailable, allowing job without disk space check (fail_open_on_stat_error)",
+					"path", gatekeeperCfg.CacheDisk.Path, "file_size", fileSize, "error", err)
+				return interfaces.GateDecision{
+					Allowed: true,
+					Reason:  "Disk space unverified",
+					Details: map[string]interface{}{
+						"error": err.Error(),
+					},
+				}
+			}
+
+			slog.Error("cache disk unavailable, blocking job until disk space can be verified",
+				"path", gatekeeperCfg.CacheDisk.Path, "file_size", fileSize, "error", err)
 			return interfaces.GateDecision{
 				Allowed: false,
 				Reason:  "Unable to verify disk space",
+				Details: map[string]interface{}{
+					"error": err.Error(),
+				},
 			}
 		}
 
-		availableBytes := int64(stat.Bavail * uint64(stat.Bsize))
+		availableBytes := usage.FreeBytes
 
 		// Calculate what usage would be after this job
-		totalBytes := int64(stat.Blocks * uint64(stat.Bsize))
-		usedBytes := totalBytes - availableBytes
-		projectedUsedBytes := usedBytes + fileSize
-		projectedUsagePercent := float64(projectedUsedBytes) / float64(totalBytes) * 100
+		projectedUsedBytes := usage.UsedBytes + fileSize
+		projectedUsagePercent := float64(projectedUsedBytes) / float64(usage.TotalBytes) * 100
 
 		if projectedUsagePercent > cacheMaxPercent {
 			return interfaces.GateDecision{
@@ -124,6 +171,19 @@ func (g *Gatekeeper) CanStartJob(fileSize int64) interfaces.GateDecision {
 				},
 			}
 		}
+
+		if minFreeBytes > 0 && availableBytes-fileSize < minFreeBytes {
+			return interfaces.GateDecision{
+				Allowed: false,
+				Reason:  "File size would leave too little free space",
+				Details: map[string]interface{}{
+					"file_size_bytes":      fileSize,
+					"available_bytes":      availableBytes,
+					"projected_free_bytes": availableBytes - fileSize,
+					"min_free_bytes":       minFreeBytes,
+				},
+			}
+		}
 	}
 
 	return interfaces.GateDecision{
@@ -140,30 +200,33 @@ func (g *Gatekeeper) GetResourceStatus() interfaces.GatekeeperResourceStatus {
 	gatekeeperCfg := g.config.GetGatekeeper()
 
 	var cacheFreeBytes, cacheTotalBytes int64
-	if stat, err := g.getCacheDiskStats(); err == nil {
-		cacheFreeBytes = int64(stat.Bavail * uint64(stat.Bsize))
-		cacheTotalBytes = int64(stat.Blocks * uint64(stat.Bsize))
+	if usage, err := g.DiskUsage(gatekeeperCfg.CacheDisk.Path); err == nil {
+		cacheFreeBytes = usage.FreeBytes
+		cacheTotalBytes = usage.TotalBytes
 	}
 
 	return interfaces.GatekeeperResourceStatus{
 		BandwidthUsageMbps: g.bandwidthUsage,
-		BandwidthLimitMbps: gatekeeperCfg.Seedbox.BandwidthLimitMbps,
+		BandwidthLimitMbps: gatekeeperCfg.Seedbox.BandwidthLimitAt(g.now()),
 		CacheUsagePercent:  g.cacheUsage,
 		CacheMaxPercent:    gatekeeperCfg.CacheDisk.MaxUsagePercent,
+		CacheMinFreeBytes:  int64(gatekeeperCfg.CacheDisk.MinFreeBytes),
 		CacheFreeBytes:     cacheFreeBytes,
 		CacheTotalBytes:    cacheTotalBytes,
 	}
 }
 
-func (g *Gatekeeper) monitorLoop() {
-	gatekeeperCfg := g.config.GetGatekeeper()
-
-	// Use the shorter of the two check intervals
-	checkInterval := gatekeeperCfg.Seedbox.CheckInterval
-	if gatekeeperCfg.CacheDisk.CheckInterval < checkInterval {
-		checkInterval = gatekeeperCfg.CacheDisk.CheckInterval
-	}
+// RefreshNow re-checks resource usage immediately instead of waiting for the
+// next monitor tick, and returns the updated status.
+func (g *Gatekeeper) RefreshNow() interfaces.GatekeeperResourceStatus {
+	g.updateResourceStatus()
+	return g.GetResourceStatus()
+}
 
+// monitorLoop re-checks resources every check interval. A config change
+// re-checks at once, since limits may have moved, and picks up a new interval.
+func (g *Gatekeeper) monitorLoop(configChanges <-chan struct{}) {
+	checkInterval := g.checkInterval()
 	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 
@@ -173,10 +236,27 @@ func (g *Gatekeeper) monitorLoop() {
 			return
 		case <-ticker.C:
 			g.updateResourceStatus()
+		case <-configChanges:
+			if interval := g.checkInterval(); interval != checkInterval {
+				checkInterval = interval
+				ticker.Reset(checkInterval)
+			}
+			g.updateResourceStatus()
 		}
 	}
 }
 
+// checkInterval returns the shorter of the two check intervals.
+func (g *Gatekeeper) checkInterval() time.Duration {
+	gatekeeperCfg := g.config.GetGatekeeper()
+
+	checkInterval := gatekeeperCfg.Seedbox.CheckInterval
+	if gatekeeperCfg.CacheDisk.CheckInterval < checkInterval {
+		checkInterval = gatekeeperCfg.CacheDisk.CheckInterval
+	}
+	return checkInterval
+}
+
 func (g *Gatekeeper) updateResourceStatus() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -184,43 +264,49 @@ func (g *Gatekeeper) updateResourceStatus() {
 	g.lastCheck = time.Now()
 
 	// Update cache usage
-	cacheUsage, err := g.checkCacheUsage()
+	cacheUsage, cacheFree, err := g.checkCacheUsage()
 	if err != nil {
 		slog.Error("failed to check cache usage", "error", err)
 		// Keep previous value
 	} else {
 		g.cacheUsage = cacheUsage
+		g.cacheFree = cacheFree
 	}
 
 	slog.Debug("resource status updated",
 		"bandwidth_mbps", g.bandwidthUsage,
 		"cache_percent", g.cacheUsage,
+		"cache_free_bytes", g.cacheFree,
 	)
 }
 
-func (g *Gatekeeper) checkCacheUsage() (float64, error) {
-	stat, err := g.getCacheDiskStats()
+// checkCacheUsage returns the cache disk's usage percentage and free bytes.
+func (g *Gatekeeper) checkCacheUsage() (float64, int64, error) {
+	usage, err := g.DiskUsage(g.config.GetGatekeeper().CacheDisk.Path)
 	if err != nil {
-		return 0, err
+		return 0, 0, fmt.Errorf("failed to stat cache disk: %w", err)
 	}
-
-	totalBytes := stat.Blocks * uint64(stat.Bsize)
-	availableBytes := stat.Bavail * uint64(stat.Bsize)
-	usedBytes := totalBytes - availableBytes
-
-	usagePercent := float64(usedBytes) / float64(totalBytes) * 100
-
-	return usagePercent, nil
+	return usage.UsedPercent, usage.FreeBytes, nil
 }
 
-func (g *Gatekeeper) getCacheDiskStats() (*unix.Statfs_t, error) {
-	gatekeeperCfg := g.config.GetGatekeeper()
-
+// DiskUsage reports total, used and free space for the filesystem holding
+// path. Free space is what an unprivileged user can still write, so used
+// includes any blocks reserved for root.
+func (g *Gatekeeper) DiskUsage(path string) (interfaces.DiskUsage, error) {
 	var stat unix.Statfs_t
-	err := unix.Statfs(gatekeeperCfg.CacheDisk.Path, &stat)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stat cache disk: %w", err)
+	if err := g.statfs(path, &stat); err != nil {
+		return interfaces.DiskUsage{}, err
 	}
 
-	return &stat, nil
+	total := int64(stat.Blocks * uint64(stat.Bsize))
+	free := int64(stat.Bavail * uint64(stat.Bsize))
+	usage := interfaces.DiskUsage{
+		TotalBytes: total,
+		UsedBytes:  total - free,
+		FreeBytes:  free,
+	}
+	if total > 0 {
+		usage.UsedPercent = float64(usage.UsedBytes) / float64(total) * 100
+	}
+	return usage, nil
 }
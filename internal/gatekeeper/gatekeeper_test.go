@@ -1,10 +1,14 @@
 package gatekeeper
 
 import (
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"grabarr/internal/config"
+
+	"golang.org/x/sys/unix"
 )
 
 func createTestConfig() *config.Config {
@@ -101,3 +105,288 @@ func TestGetResourceStatus(t *testing.T) {
 		t.Errorf("Expected cache max 80%%, got: %d", status.CacheMaxPercent)
 	}
 }
+
+func failingStatfs(path string, buf *unix.Statfs_t) error {
+	return errors.New("no such device")
+}
+
+func TestCanStartJob_StatError_FailClosed(t *testing.T) {
+	cfg := createTestConfig()
+
+	gk := New(cfg)
+	gk.statfs = failingStatfs
+
+	decision := gk.CanStartJob(1024)
+
+	if decision.Allowed {
+		t.Error("Expected job to be blocked when disk stat fails and fail-open is disabled")
+	}
+
+	if decision.Reason != "Unable to verify disk space" {
+		t.Errorf("Expected reason 'Unable to verify disk space', got: %s", decision.Reason)
+	}
+}
+
+func TestCanStartJob_StatError_FailOpen(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Gatekeeper.Rules.FailOpenOnStatError = true
+
+	gk := New(cfg)
+	gk.statfs = failingStatfs
+
+	decision := gk.CanStartJob(1024)
+
+	if !decision.Allowed {
+		t.Errorf("Expected job to be allowed when disk stat fails and fail-open is enabled, got: %s", decision.Reason)
+	}
+
+	if decision.Reason != "Disk space unverified" {
+		t.Errorf("Expected reason 'Disk space unverified', got: %s", decision.Reason)
+	}
+}
+
+func TestGetResourceStatus_StatError(t *testing.T) {
+	cfg := createTestConfig()
+
+	gk := New(cfg)
+	gk.statfs = failingStatfs
+
+	status := gk.GetResourceStatus()
+
+	if status.CacheFreeBytes != 0 || status.CacheTotalBytes != 0 {
+		t.Errorf("Expected zero cache bytes on stat error, got free=%d total=%d", status.CacheFreeBytes, status.CacheTotalBytes)
+	}
+}
+
+func TestCanStartJob_BandwidthSchedule(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Gatekeeper.Seedbox.BandwidthLimitMbps = 100
+	cfg.Gatekeeper.Seedbox.BandwidthSchedule = []config.BandwidthWindow{
+		{Name: "night", Start: "23:00", End: "08:00", BandwidthLimitMbps: 500},
+		{Name: "lunch", Start: "12:00", End: "13:00", BandwidthLimitMbps: 250},
+	}
+
+	gk := New(cfg)
+	gk.bandwidthUsage = 200
+
+	tests := []struct {
+		clock   string
+		allowed bool
+		limit   int
+		window  string
+	}{
+		{clock: "22:59", allowed: false, limit: 100},
+		{clock: "23:00", allowed: true, limit: 500, window: "night"},
+		{clock: "03:30", allowed: true, limit: 500, window: "night"},
+		{clock: "07:59", allowed: true, limit: 500, window: "night"},
+		{clock: "08:00", allowed: false, limit: 100},
+		{clock: "12:30", allowed: true, limit: 250, window: "lunch"},
+		{clock: "13:00", allowed: false, limit: 100},
+	}
+
+	for _, tt := range tests {
+		now, err := time.Parse("15:04", tt.clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gk.now = func() time.Time { return now }
+
+		decision := gk.CanStartJob(0)
+		if decision.Allowed != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got %v (%s)", tt.clock, tt.allowed, decision.Allowed, decision.Reason)
+		}
+		if !tt.allowed {
+			if decision.Details["limit_mbps"] != tt.limit {
+				t.Errorf("%s: expected limit %d, got %v", tt.clock, tt.limit, decision.Details["limit_mbps"])
+			}
+			if _, ok := decision.Details["bandwidth_window"]; ok {
+				t.Errorf("%s: expected no bandwidth window outside the schedule", tt.clock)
+			}
+		}
+
+		if status := gk.GetResourceStatus(); status.BandwidthLimitMbps != tt.limit {
+			t.Errorf("%s: expected status limit %d, got %d", tt.clock, tt.limit, status.BandwidthLimitMbps)
+		}
+	}
+}
+
+func TestCanStartJob_BandwidthScheduleReportsWindow(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Gatekeeper.Seedbox.BandwidthSchedule = []config.BandwidthWindow{
+		{Name: "day", Start: "08:00", End: "23:00", BandwidthLimitMbps: 100},
+	}
+
+	gk := New(cfg)
+	gk.bandwidthUsage = 200
+	gk.now = func() time.Time { return time.Date(2024, 1, 1, 15, 0, 0, 0, time.Local) }
+
+	decision := gk.CanStartJob(0)
+	if decision.Allowed {
+		t.Fatal("Expected job to be blocked by the day window limit")
+	}
+	if decision.Details["bandwidth_window"] != "day" {
+		t.Errorf("Expected bandwidth_window 'day', got: %v", decision.Details["bandwidth_window"])
+	}
+}
+
+func TestRefreshNow_UpdatesStatus(t *testing.T) {
+	cfg := createTestConfig()
+	gk := New(cfg)
+
+	// 1000 blocks of 4096 bytes; usage depends on how many are free
+	free := uint64(900)
+	gk.statfs = func(path string, buf *unix.Statfs_t) error {
+		buf.Bsize = 4096
+		buf.Blocks = 1000
+		buf.Bfree = free
+		buf.Bavail = free
+		return nil
+	}
+
+	gk.updateResourceStatus()
+	if got := gk.GetResourceStatus().CacheUsagePercent; got != 10 {
+		t.Fatalf("Expected initial cache usage 10%%, got: %f", got)
+	}
+
+	// Space is freed between ticks; the cached status is stale until refreshed
+	free = 500
+	if got := gk.GetResourceStatus().CacheUsagePercent; got != 10 {
+		t.Fatalf("Expected cache usage to stay 10%% before refresh, got: %f", got)
+	}
+
+	status := gk.RefreshNow()
+
+	if status.CacheUsagePercent != 50 {
+		t.Errorf("Expected refreshed cache usage 50%%, got: %f", status.CacheUsagePercent)
+	}
+	if status.CacheFreeBytes != 500*4096 {
+		t.Errorf("Expected free bytes %d, got: %d", 500*4096, status.CacheFreeBytes)
+	}
+	if gk.CanStartJob(0).Reason != "All checks passed" {
+		t.Error("Expected refreshed status to be used by CanStartJob")
+	}
+}
+
+func TestMonitorLoop_ConfigChangeRechecks(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Gatekeeper.Seedbox.CheckInterval = time.Hour
+	cfg.Gatekeeper.CacheDisk.CheckInterval = time.Hour
+	gk := New(cfg)
+	defer gk.Stop()
+
+	var free atomic.Uint64
+	free.Store(900)
+	gk.statfs = func(path string, buf *unix.Statfs_t) error {
+		buf.Bsize = 4096
+		buf.Blocks = 1000
+		buf.Bfree = free.Load()
+		buf.Bavail = free.Load()
+		return nil
+	}
+	gk.updateResourceStatus()
+
+	changes := make(chan struct{}, 1)
+	go gk.monitorLoop(changes)
+
+	// No tick is due for an hour; the change alone triggers a check
+	free.Store(500)
+	changes <- struct{}{}
+
+	deadline := time.Now().Add(time.Second)
+	for gk.GetResourceStatus().CacheUsagePercent != 50 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected cache usage 50%% after config change, got: %f", gk.GetResourceStatus().CacheUsagePercent)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// fixedStatfs reports a 1000-block disk of 1 MiB blocks with the given number free.
+func fixedStatfs(freeBlocks uint64) func(string, *unix.Statfs_t) error {
+	return func(path string, buf *unix.Statfs_t) error {
+		buf.Bsize = 1 << 20
+		buf.Blocks = 1000
+		buf.Bfree = freeBlocks
+		buf.Bavail = freeBlocks
+		return nil
+	}
+}
+
+func TestCanStartJob_MinFreeBytes(t *testing.T) {
+	const mib = 1 << 20
+
+	tests := []struct {
+		name         string
+		freeBlocks   uint64
+		maxPercent   int
+		minFreeBytes config.ByteSize
+		fileSize     int64
+		reason       string
+	}{
+		{name: "both rules pass", freeBlocks: 500, maxPercent: 80, minFreeBytes: 100 * mib, reason: "All checks passed"},
+		{name: "percent rule only", freeBlocks: 100, maxPercent: 80, minFreeBytes: 50 * mib, reason: "Cache disk usage too high"},
+		{name: "absolute rule only", freeBlocks: 300, maxPercent: 80, minFreeBytes: 400 * mib, reason: "Cache disk free space too low"},
+		{name: "absolute rule disabled", freeBlocks: 300, maxPercent: 80, reason: "All checks passed"},
+		{name: "file would breach absolute rule", freeBlocks: 500, maxPercent: 80, minFreeBytes: 300 * mib, fileSize: 250 * mib, reason: "File size would leave too little free space"},
+		{name: "file would breach percent rule", freeBlocks: 300, maxPercent: 80, minFreeBytes: 10 * mib, fileSize: 150 * mib, reason: "File size would exceed cache limit"},
+		{name: "file fits both rules", freeBlocks: 500, maxPercent: 80, minFreeBytes: 300 * mib, fileSize: 150 * mib, reason: "All checks passed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTestConfig()
+			cfg.Gatekeeper.CacheDisk.MaxUsagePercent = tt.maxPercent
+			cfg.Gatekeeper.CacheDisk.MinFreeBytes = tt.minFreeBytes
+
+			gk := New(cfg)
+			gk.statfs = fixedStatfs(tt.freeBlocks)
+			gk.updateResourceStatus()
+
+			decision := gk.CanStartJob(tt.fileSize)
+			if decision.Reason != tt.reason {
+				t.Errorf("Expected reason %q, got: %q", tt.reason, decision.Reason)
+			}
+			if decision.Allowed != (tt.reason == "All checks passed") {
+				t.Errorf("Expected allowed=%v, got %v", !decision.Allowed, decision.Allowed)
+			}
+		})
+	}
+}
+
+func TestCanStartJob_MinFreeBytesBeforeFirstCheck(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Gatekeeper.CacheDisk.MinFreeBytes = 1 << 30
+	cfg.Gatekeeper.Rules.RequireFilesizeCheck = false
+
+	// Free space is unknown until the first successful check, so the
+	// absolute rule doesn't block on the zero value
+	gk := New(cfg)
+
+	if decision := gk.CanStartJob(0); !decision.Allowed {
+		t.Errorf("Expected job to be allowed before the first check, got: %s", decision.Reason)
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	gk := New(createTestConfig())
+	gk.statfs = fixedStatfs(250)
+
+	usage, err := gk.DiskUsage("/anywhere")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.TotalBytes != 1000<<20 || usage.FreeBytes != 250<<20 || usage.UsedBytes != 750<<20 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+	if usage.UsedPercent != 75 {
+		t.Errorf("Expected 75%% used, got: %f", usage.UsedPercent)
+	}
+}
+
+func TestDiskUsage_MissingPath(t *testing.T) {
+	gk := New(createTestConfig())
+
+	if _, err := gk.DiskUsage(t.TempDir() + "/does-not-exist"); err == nil {
+		t.Error("Expected an error for a path that doesn't exist")
+	}
+}
@@ -0,0 +1,95 @@
+// Package hooks runs user-configured shell commands when jobs change state.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"grabarr/internal/models"
+)
+
+// maxOutput caps how much of a command's combined output is kept.
+const maxOutput = 4096
+
+// waitDelay bounds how long a timed-out command's output is waited on, since
+// background children of the shell can keep its pipes open.
+const waitDelay = time.Second
+
+// Result is the outcome of one hook command.
+type Result struct {
+	Command  string
+	Output   string
+	Duration time.Duration
+	Err      error
+}
+
+// Run runs each command with sh -c, in order, with the job's fields exposed as
+// GRABARR_* environment variables. Each command gets its own timeout; a failing
+// command does not stop the ones after it.
+func Run(ctx context.Context, commands []string, job *models.Job, timeout time.Duration) []Result {
+	env := append(os.Environ(), Env(job)...)
+
+	results := make([]Result, 0, len(commands))
+	for _, command := range commands {
+		cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+		cmd := exec.CommandContext(cmdCtx, "sh", "-c", command)
+		cmd.Env = env
+		cmd.WaitDelay = waitDelay
+
+		start := time.Now()
+		output, err := cmd.CombinedOutput()
+		if err != nil && cmdCtx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s: %w", timeout, err)
+		}
+		cancel()
+
+		out := strings.TrimSpace(string(output))
+		if len(out) > maxOutput {
+			out = "..." + out[len(out)-maxOutput:]
+		}
+		results = append(results, Result{
+			Command:  command,
+			Output:   out,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+	return results
+}
+
+// Env returns the GRABARR_* environment variables describing job.
+func Env(job *models.Job) []string {
+	return []string{
+		"GRABARR_JOB_ID=" + strconv.FormatInt(job.ID, 10),
+		"GRABARR_JOB_NAME=" + job.Name,
+		"GRABARR_JOB_STATUS=" + string(job.Status),
+		"GRABARR_REMOTE_PATH=" + job.RemotePath,
+		"GRABARR_LOCAL_PATH=" + job.LocalPath,
+		"GRABARR_FILE_SIZE=" + strconv.FormatInt(job.FileSize, 10),
+		"GRABARR_GROUP_ID=" + job.GroupID,
+		"GRABARR_CATEGORY=" + job.Metadata.Category,
+		"GRABARR_TORRENT_NAME=" + job.Metadata.TorrentName,
+		"GRABARR_QBITTORRENT_HASH=" + job.Metadata.QBittorrentHash,
+	}
+}
+
+// FormatLog renders results for a job attempt log.
+func FormatLog(results []Result) string {
+	var b strings.Builder
+	for _, r := range results {
+		status := "ok"
+		if r.Err != nil {
+			status = "failed: " + r.Err.Error()
+		}
+		fmt.Fprintf(&b, "hook %q (%s): %s\n", r.Command, r.Duration.Round(time.Millisecond), status)
+		if r.Output != "" {
+			fmt.Fprintf(&b, "%s\n", r.Output)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"grabarr/internal/models"
+)
+
+func TestRun_ExposesJobEnvironment(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "done")
+	job := &models.Job{
+		ID:        7,
+		Name:      "Movie.2024.mkv",
+		LocalPath: "/downloads/movies/Movie.2024.mkv",
+		Metadata:  models.JobMetadata{Category: "movies"},
+	}
+
+	results := Run(context.Background(), []string{
+		`printf '%s|%s|%s|%s' "$GRABARR_JOB_ID" "$GRABARR_JOB_NAME" "$GRABARR_LOCAL_PATH" "$GRABARR_CATEGORY" > ` + marker,
+		"echo hello",
+	}, job, time.Minute)
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.NoError(t, results[1].Err)
+	assert.Equal(t, "hello", results[1].Output)
+
+	data, err := os.ReadFile(marker)
+	require.NoError(t, err)
+	assert.Equal(t, "7|Movie.2024.mkv|/downloads/movies/Movie.2024.mkv|movies", string(data))
+}
+
+func TestRun_FailureDoesNotStopLaterCommands(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "second")
+
+	results := Run(context.Background(), []string{"echo oops >&2; exit 3", "touch " + marker}, &models.Job{}, time.Minute)
+
+	require.Len(t, results, 2)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, "oops", results[0].Output)
+	assert.NoError(t, results[1].Err)
+	assert.FileExists(t, marker)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	results := Run(context.Background(), []string{"sleep 5"}, &models.Job{}, 50*time.Millisecond)
+
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err)
+	assert.Contains(t, results[0].Err.Error(), "timed out")
+}
+
+func TestFormatLog(t *testing.T) {
+	log := FormatLog([]Result{
+		{Command: "scan-plex", Output: "scanning", Duration: 1500 * time.Millisecond},
+		{Command: "false", Err: assert.AnError},
+	})
+
+	assert.Contains(t, log, `hook "scan-plex" (1.5s): ok`)
+	assert.Contains(t, log, "scanning")
+	assert.Contains(t, log, `hook "false" (0s): failed: `)
+}
@@ -0,0 +1,59 @@
+// Package httpclient builds the HTTP clients used for outbound requests.
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"grabarr/internal/buildinfo"
+	"grabarr/internal/config"
+)
+
+const DefaultTimeout = 30 * time.Second
+
+// Option adjusts a client built by New.
+type Option func(*http.Client)
+
+// WithoutTimeout drops the overall request timeout, for downloads that can
+// legitimately run for hours. Such requests are bounded by their context.
+func WithoutTimeout() Option {
+	return func(c *http.Client) {
+		c.Timeout = 0
+	}
+}
+
+// New returns an HTTP client that applies the configured timeout and sets the
+// User-Agent header on every request, defaulting to "grabarr/<version>".
+func New(cfg config.OutboundHTTPConfig, opts ...Option) *http.Client {
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = buildinfo.UserAgent()
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &userAgentTransport{
+			base:      http.DefaultTransport,
+			userAgent: userAgent,
+		},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
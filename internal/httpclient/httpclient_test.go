@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grabarr/internal/buildinfo"
+	"grabarr/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_PropagatesUserAgentAndTimeout(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(config.OutboundHTTPConfig{
+		UserAgent: "grabarr/2.3.4 (+test)",
+		Timeout:   5 * time.Second,
+	})
+
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "grabarr/2.3.4 (+test)", gotUserAgent)
+}
+
+func TestNew_Defaults(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := New(config.OutboundHTTPConfig{})
+
+	assert.Equal(t, DefaultTimeout, client.Timeout)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, buildinfo.UserAgent(), gotUserAgent)
+}
+
+func TestNew_TimeoutAborts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := New(config.OutboundHTTPConfig{Timeout: 20 * time.Millisecond})
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestNew_WithoutTimeout(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// The configured timeout is dropped; the User-Agent still applies
+	client := New(config.OutboundHTTPConfig{UserAgent: "grabarr/2.3.4 (+test)", Timeout: 20 * time.Millisecond}, WithoutTimeout())
+	assert.Zero(t, client.Timeout)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "grabarr/2.3.4 (+test)", gotUserAgent)
+}
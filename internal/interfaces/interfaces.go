@@ -2,10 +2,21 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"grabarr/internal/models"
 )
 
+// ErrQueueFull is returned by JobQueue.Enqueue when jobs.max_queue_depth jobs are already waiting.
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrMaxConcurrent is returned by JobQueue.ForceSchedule when jobs.max_concurrent jobs are already running.
+var ErrMaxConcurrent = errors.New("max concurrent jobs already running")
+
+// ErrJobsActive is returned by JobQueue.Vacuum while any job is running.
+var ErrJobsActive = errors.New("jobs are active")
+
 // JobQueue manages the job queue, scheduling, and execution
 type JobQueue interface {
 	Start(ctx context.Context) error
@@ -14,10 +25,28 @@ type JobQueue interface {
 	GetJob(id int64) (*models.Job, error)
 	GetJobs(filter models.JobFilter) ([]*models.Job, error)
 	CountJobs(filter models.JobFilter) (int, error)
+	GetJobsByIDs(ids []int64) ([]*models.Job, error)
+	// Context variants abort the underlying query when ctx is done; API handlers
+	// pass the request context so abandoned requests stop hitting the database.
+	GetJobContext(ctx context.Context, id int64) (*models.Job, error)
+	GetJobsContext(ctx context.Context, filter models.JobFilter) ([]*models.Job, error)
+	CountJobsContext(ctx context.Context, filter models.JobFilter) (int, error)
+	GetJobByHash(hash string) (*models.Job, error)
+	GetActiveJobByRemotePath(remotePath string) (*models.Job, error)
 	CancelJob(id int64) error
 	DeleteJob(id int64) error
+	RestoreJob(id int64) error
+	PurgeJobs(statuses []models.JobStatus, before time.Time) (int, error)
 	RetryJob(id int64) error
+	ForceSchedule(id int64) error
 	GetSummary() (*models.JobSummary, error)
+	GetJobStatsByCategory() ([]*models.CategoryStats, error)
+	GetTransferStats(since time.Time) (*models.TransferStats, error)
+	GetLifetimeStats() (*models.LifetimeStats, error)
+	GetJobAttempts(jobID int64) ([]*models.JobAttempt, error)
+	GetJobAudit(jobID int64) ([]*models.AuditEntry, error)
+	Vacuum() (*models.MaintenanceResult, error)
+	Ping(ctx context.Context) error // checks the database is reachable
 	SetJobExecutor(executor JobExecutor)
 }
 
@@ -32,13 +61,15 @@ type Gatekeeper interface {
 	Stop() error
 	CanStartJob(fileSize int64) GateDecision
 	GetResourceStatus() GatekeeperResourceStatus
+	RefreshNow() GatekeeperResourceStatus
+	DiskUsage(path string) (DiskUsage, error)
 }
 
 // GateDecision represents whether an operation can proceed
 type GateDecision struct {
-	Allowed bool
-	Reason  string
-	Details map[string]interface{}
+	Allowed bool                   `json:"allowed"`
+	Reason  string                 `json:"reason"`
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
 // GatekeeperResourceStatus provides current resource status
@@ -47,10 +78,19 @@ type GatekeeperResourceStatus struct {
 	BandwidthLimitMbps int     `json:"bandwidth_limit_mbps"`
 	CacheUsagePercent  float64 `json:"cache_usage_percent"`
 	CacheMaxPercent    int     `json:"cache_max_percent"`
+	CacheMinFreeBytes  int64   `json:"cache_min_free_bytes,omitempty"`
 	CacheFreeBytes     int64   `json:"cache_free_bytes"`
 	CacheTotalBytes    int64   `json:"cache_total_bytes"`
 }
 
+// DiskUsage describes space on the filesystem holding a path
+type DiskUsage struct {
+	TotalBytes  int64   `json:"total_bytes"`
+	UsedBytes   int64   `json:"used_bytes"`
+	FreeBytes   int64   `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+}
+
 // JobRepository provides database access for jobs
 type JobRepository interface {
 	UpdateJob(job *models.Job) error
@@ -65,4 +105,5 @@ type Notifier interface {
 	NotifyJobFailed(job *models.Job) error
 	NotifyJobCompleted(job *models.Job) error
 	NotifySystemAlert(title, message string, priority int) error
+	TestNotification() error
 }
@@ -67,6 +67,62 @@ func (_c *MockGatekeeper_CanStartJob_Call) RunAndReturn(run func(int64) interfac
 	return _c
 }
 
+// DiskUsage provides a mock function with given fields: path
+func (_m *MockGatekeeper) DiskUsage(path string) (interfaces.DiskUsage, error) {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiskUsage")
+	}
+
+	var r0 interfaces.DiskUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (interfaces.DiskUsage, error)); ok {
+		return rf(path)
+	}
+	if rf, ok := ret.Get(0).(func(string) interfaces.DiskUsage); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(interfaces.DiskUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGatekeeper_DiskUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiskUsage'
+type MockGatekeeper_DiskUsage_Call struct {
+	*mock.Call
+}
+
+// DiskUsage is a helper method to define mock.On call
+//   - path string
+func (_e *MockGatekeeper_Expecter) DiskUsage(path interface{}) *MockGatekeeper_DiskUsage_Call {
+	return &MockGatekeeper_DiskUsage_Call{Call: _e.mock.On("DiskUsage", path)}
+}
+
+func (_c *MockGatekeeper_DiskUsage_Call) Run(run func(path string)) *MockGatekeeper_DiskUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockGatekeeper_DiskUsage_Call) Return(_a0 interfaces.DiskUsage, _a1 error) *MockGatekeeper_DiskUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGatekeeper_DiskUsage_Call) RunAndReturn(run func(string) (interfaces.DiskUsage, error)) *MockGatekeeper_DiskUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetResourceStatus provides a mock function with no fields
 func (_m *MockGatekeeper) GetResourceStatus() interfaces.GatekeeperResourceStatus {
 	ret := _m.Called()
@@ -112,6 +168,51 @@ func (_c *MockGatekeeper_GetResourceStatus_Call) RunAndReturn(run func() interfa
 	return _c
 }
 
+// RefreshNow provides a mock function with no fields
+func (_m *MockGatekeeper) RefreshNow() interfaces.GatekeeperResourceStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshNow")
+	}
+
+	var r0 interfaces.GatekeeperResourceStatus
+	if rf, ok := ret.Get(0).(func() interfaces.GatekeeperResourceStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(interfaces.GatekeeperResourceStatus)
+	}
+
+	return r0
+}
+
+// MockGatekeeper_RefreshNow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshNow'
+type MockGatekeeper_RefreshNow_Call struct {
+	*mock.Call
+}
+
+// RefreshNow is a helper method to define mock.On call
+func (_e *MockGatekeeper_Expecter) RefreshNow() *MockGatekeeper_RefreshNow_Call {
+	return &MockGatekeeper_RefreshNow_Call{Call: _e.mock.On("RefreshNow")}
+}
+
+func (_c *MockGatekeeper_RefreshNow_Call) Run(run func()) *MockGatekeeper_RefreshNow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockGatekeeper_RefreshNow_Call) Return(_a0 interfaces.GatekeeperResourceStatus) *MockGatekeeper_RefreshNow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockGatekeeper_RefreshNow_Call) RunAndReturn(run func() interfaces.GatekeeperResourceStatus) *MockGatekeeper_RefreshNow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with no fields
 func (_m *MockGatekeeper) Start() error {
 	ret := _m.Called()
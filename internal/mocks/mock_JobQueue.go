@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "grabarr/internal/models"
+
+	time "time"
 )
 
 // MockJobQueue is an autogenerated mock type for the JobQueue type
@@ -126,6 +128,63 @@ func (_c *MockJobQueue_CountJobs_Call) RunAndReturn(run func(models.JobFilter) (
 	return _c
 }
 
+// CountJobsContext provides a mock function with given fields: ctx, filter
+func (_m *MockJobQueue) CountJobsContext(ctx context.Context, filter models.JobFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountJobsContext")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.JobFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.JobFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.JobFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_CountJobsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountJobsContext'
+type MockJobQueue_CountJobsContext_Call struct {
+	*mock.Call
+}
+
+// CountJobsContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.JobFilter
+func (_e *MockJobQueue_Expecter) CountJobsContext(ctx interface{}, filter interface{}) *MockJobQueue_CountJobsContext_Call {
+	return &MockJobQueue_CountJobsContext_Call{Call: _e.mock.On("CountJobsContext", ctx, filter)}
+}
+
+func (_c *MockJobQueue_CountJobsContext_Call) Run(run func(ctx context.Context, filter models.JobFilter)) *MockJobQueue_CountJobsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.JobFilter))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_CountJobsContext_Call) Return(_a0 int, _a1 error) *MockJobQueue_CountJobsContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_CountJobsContext_Call) RunAndReturn(run func(context.Context, models.JobFilter) (int, error)) *MockJobQueue_CountJobsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteJob provides a mock function with given fields: id
 func (_m *MockJobQueue) DeleteJob(id int64) error {
 	ret := _m.Called(id)
@@ -218,6 +277,110 @@ func (_c *MockJobQueue_Enqueue_Call) RunAndReturn(run func(*models.Job) error) *
 	return _c
 }
 
+// ForceSchedule provides a mock function with given fields: id
+func (_m *MockJobQueue) ForceSchedule(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobQueue_ForceSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceSchedule'
+type MockJobQueue_ForceSchedule_Call struct {
+	*mock.Call
+}
+
+// ForceSchedule is a helper method to define mock.On call
+//   - id int64
+func (_e *MockJobQueue_Expecter) ForceSchedule(id interface{}) *MockJobQueue_ForceSchedule_Call {
+	return &MockJobQueue_ForceSchedule_Call{Call: _e.mock.On("ForceSchedule", id)}
+}
+
+func (_c *MockJobQueue_ForceSchedule_Call) Run(run func(id int64)) *MockJobQueue_ForceSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_ForceSchedule_Call) Return(_a0 error) *MockJobQueue_ForceSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobQueue_ForceSchedule_Call) RunAndReturn(run func(int64) error) *MockJobQueue_ForceSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveJobByRemotePath provides a mock function with given fields: remotePath
+func (_m *MockJobQueue) GetActiveJobByRemotePath(remotePath string) (*models.Job, error) {
+	ret := _m.Called(remotePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveJobByRemotePath")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Job, error)); ok {
+		return rf(remotePath)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Job); ok {
+		r0 = rf(remotePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(remotePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetActiveJobByRemotePath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveJobByRemotePath'
+type MockJobQueue_GetActiveJobByRemotePath_Call struct {
+	*mock.Call
+}
+
+// GetActiveJobByRemotePath is a helper method to define mock.On call
+//   - remotePath string
+func (_e *MockJobQueue_Expecter) GetActiveJobByRemotePath(remotePath interface{}) *MockJobQueue_GetActiveJobByRemotePath_Call {
+	return &MockJobQueue_GetActiveJobByRemotePath_Call{Call: _e.mock.On("GetActiveJobByRemotePath", remotePath)}
+}
+
+func (_c *MockJobQueue_GetActiveJobByRemotePath_Call) Run(run func(remotePath string)) *MockJobQueue_GetActiveJobByRemotePath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetActiveJobByRemotePath_Call) Return(_a0 *models.Job, _a1 error) *MockJobQueue_GetActiveJobByRemotePath_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetActiveJobByRemotePath_Call) RunAndReturn(run func(string) (*models.Job, error)) *MockJobQueue_GetActiveJobByRemotePath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetJob provides a mock function with given fields: id
 func (_m *MockJobQueue) GetJob(id int64) (*models.Job, error) {
 	ret := _m.Called(id)
@@ -276,29 +439,29 @@ func (_c *MockJobQueue_GetJob_Call) RunAndReturn(run func(int64) (*models.Job, e
 	return _c
 }
 
-// GetJobs provides a mock function with given fields: filter
-func (_m *MockJobQueue) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
-	ret := _m.Called(filter)
+// GetJobAttempts provides a mock function with given fields: jobID
+func (_m *MockJobQueue) GetJobAttempts(jobID int64) ([]*models.JobAttempt, error) {
+	ret := _m.Called(jobID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetJobs")
+		panic("no return value specified for GetJobAttempts")
 	}
 
-	var r0 []*models.Job
+	var r0 []*models.JobAttempt
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.JobFilter) ([]*models.Job, error)); ok {
-		return rf(filter)
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.JobAttempt, error)); ok {
+		return rf(jobID)
 	}
-	if rf, ok := ret.Get(0).(func(models.JobFilter) []*models.Job); ok {
-		r0 = rf(filter)
+	if rf, ok := ret.Get(0).(func(int64) []*models.JobAttempt); ok {
+		r0 = rf(jobID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Job)
+			r0 = ret.Get(0).([]*models.JobAttempt)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(models.JobFilter) error); ok {
-		r1 = rf(filter)
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(jobID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -306,57 +469,57 @@ func (_m *MockJobQueue) GetJobs(filter models.JobFilter) ([]*models.Job, error)
 	return r0, r1
 }
 
-// MockJobQueue_GetJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobs'
-type MockJobQueue_GetJobs_Call struct {
+// MockJobQueue_GetJobAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobAttempts'
+type MockJobQueue_GetJobAttempts_Call struct {
 	*mock.Call
 }
 
-// GetJobs is a helper method to define mock.On call
-//   - filter models.JobFilter
-func (_e *MockJobQueue_Expecter) GetJobs(filter interface{}) *MockJobQueue_GetJobs_Call {
-	return &MockJobQueue_GetJobs_Call{Call: _e.mock.On("GetJobs", filter)}
+// GetJobAttempts is a helper method to define mock.On call
+//   - jobID int64
+func (_e *MockJobQueue_Expecter) GetJobAttempts(jobID interface{}) *MockJobQueue_GetJobAttempts_Call {
+	return &MockJobQueue_GetJobAttempts_Call{Call: _e.mock.On("GetJobAttempts", jobID)}
 }
 
-func (_c *MockJobQueue_GetJobs_Call) Run(run func(filter models.JobFilter)) *MockJobQueue_GetJobs_Call {
+func (_c *MockJobQueue_GetJobAttempts_Call) Run(run func(jobID int64)) *MockJobQueue_GetJobAttempts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(models.JobFilter))
+		run(args[0].(int64))
 	})
 	return _c
 }
 
-func (_c *MockJobQueue_GetJobs_Call) Return(_a0 []*models.Job, _a1 error) *MockJobQueue_GetJobs_Call {
+func (_c *MockJobQueue_GetJobAttempts_Call) Return(_a0 []*models.JobAttempt, _a1 error) *MockJobQueue_GetJobAttempts_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockJobQueue_GetJobs_Call) RunAndReturn(run func(models.JobFilter) ([]*models.Job, error)) *MockJobQueue_GetJobs_Call {
+func (_c *MockJobQueue_GetJobAttempts_Call) RunAndReturn(run func(int64) ([]*models.JobAttempt, error)) *MockJobQueue_GetJobAttempts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSummary provides a mock function with no fields
-func (_m *MockJobQueue) GetSummary() (*models.JobSummary, error) {
-	ret := _m.Called()
+// GetJobAudit provides a mock function with given fields: jobID
+func (_m *MockJobQueue) GetJobAudit(jobID int64) ([]*models.AuditEntry, error) {
+	ret := _m.Called(jobID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSummary")
+		panic("no return value specified for GetJobAudit")
 	}
 
-	var r0 *models.JobSummary
+	var r0 []*models.AuditEntry
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*models.JobSummary, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(int64) ([]*models.AuditEntry, error)); ok {
+		return rf(jobID)
 	}
-	if rf, ok := ret.Get(0).(func() *models.JobSummary); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(int64) []*models.AuditEntry); ok {
+		r0 = rf(jobID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.JobSummary)
+			r0 = ret.Get(0).([]*models.AuditEntry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(jobID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -364,138 +527,809 @@ func (_m *MockJobQueue) GetSummary() (*models.JobSummary, error) {
 	return r0, r1
 }
 
-// MockJobQueue_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
-type MockJobQueue_GetSummary_Call struct {
+// MockJobQueue_GetJobAudit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobAudit'
+type MockJobQueue_GetJobAudit_Call struct {
 	*mock.Call
 }
 
-// GetSummary is a helper method to define mock.On call
-func (_e *MockJobQueue_Expecter) GetSummary() *MockJobQueue_GetSummary_Call {
-	return &MockJobQueue_GetSummary_Call{Call: _e.mock.On("GetSummary")}
+// GetJobAudit is a helper method to define mock.On call
+//   - jobID int64
+func (_e *MockJobQueue_Expecter) GetJobAudit(jobID interface{}) *MockJobQueue_GetJobAudit_Call {
+	return &MockJobQueue_GetJobAudit_Call{Call: _e.mock.On("GetJobAudit", jobID)}
 }
 
-func (_c *MockJobQueue_GetSummary_Call) Run(run func()) *MockJobQueue_GetSummary_Call {
+func (_c *MockJobQueue_GetJobAudit_Call) Run(run func(jobID int64)) *MockJobQueue_GetJobAudit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(int64))
 	})
 	return _c
 }
 
-func (_c *MockJobQueue_GetSummary_Call) Return(_a0 *models.JobSummary, _a1 error) *MockJobQueue_GetSummary_Call {
+func (_c *MockJobQueue_GetJobAudit_Call) Return(_a0 []*models.AuditEntry, _a1 error) *MockJobQueue_GetJobAudit_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockJobQueue_GetSummary_Call) RunAndReturn(run func() (*models.JobSummary, error)) *MockJobQueue_GetSummary_Call {
+func (_c *MockJobQueue_GetJobAudit_Call) RunAndReturn(run func(int64) ([]*models.AuditEntry, error)) *MockJobQueue_GetJobAudit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RetryJob provides a mock function with given fields: id
-func (_m *MockJobQueue) RetryJob(id int64) error {
-	ret := _m.Called(id)
+// GetJobByHash provides a mock function with given fields: hash
+func (_m *MockJobQueue) GetJobByHash(hash string) (*models.Job, error) {
+	ret := _m.Called(hash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetryJob")
+		panic("no return value specified for GetJobByHash")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Job, error)); ok {
+		return rf(hash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Job); ok {
+		r0 = rf(hash)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockJobQueue_RetryJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryJob'
-type MockJobQueue_RetryJob_Call struct {
+// MockJobQueue_GetJobByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobByHash'
+type MockJobQueue_GetJobByHash_Call struct {
 	*mock.Call
 }
 
-// RetryJob is a helper method to define mock.On call
-//   - id int64
-func (_e *MockJobQueue_Expecter) RetryJob(id interface{}) *MockJobQueue_RetryJob_Call {
-	return &MockJobQueue_RetryJob_Call{Call: _e.mock.On("RetryJob", id)}
+// GetJobByHash is a helper method to define mock.On call
+//   - hash string
+func (_e *MockJobQueue_Expecter) GetJobByHash(hash interface{}) *MockJobQueue_GetJobByHash_Call {
+	return &MockJobQueue_GetJobByHash_Call{Call: _e.mock.On("GetJobByHash", hash)}
 }
 
-func (_c *MockJobQueue_RetryJob_Call) Run(run func(id int64)) *MockJobQueue_RetryJob_Call {
+func (_c *MockJobQueue_GetJobByHash_Call) Run(run func(hash string)) *MockJobQueue_GetJobByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *MockJobQueue_RetryJob_Call) Return(_a0 error) *MockJobQueue_RetryJob_Call {
-	_c.Call.Return(_a0)
+func (_c *MockJobQueue_GetJobByHash_Call) Return(_a0 *models.Job, _a1 error) *MockJobQueue_GetJobByHash_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockJobQueue_RetryJob_Call) RunAndReturn(run func(int64) error) *MockJobQueue_RetryJob_Call {
+func (_c *MockJobQueue_GetJobByHash_Call) RunAndReturn(run func(string) (*models.Job, error)) *MockJobQueue_GetJobByHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetJobExecutor provides a mock function with given fields: executor
-func (_m *MockJobQueue) SetJobExecutor(executor interfaces.JobExecutor) {
-	_m.Called(executor)
+// GetJobContext provides a mock function with given fields: ctx, id
+func (_m *MockJobQueue) GetJobContext(ctx context.Context, id int64) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobContext")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockJobQueue_SetJobExecutor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetJobExecutor'
-type MockJobQueue_SetJobExecutor_Call struct {
+// MockJobQueue_GetJobContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobContext'
+type MockJobQueue_GetJobContext_Call struct {
 	*mock.Call
 }
 
-// SetJobExecutor is a helper method to define mock.On call
-//   - executor interfaces.JobExecutor
-func (_e *MockJobQueue_Expecter) SetJobExecutor(executor interface{}) *MockJobQueue_SetJobExecutor_Call {
-	return &MockJobQueue_SetJobExecutor_Call{Call: _e.mock.On("SetJobExecutor", executor)}
+// GetJobContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockJobQueue_Expecter) GetJobContext(ctx interface{}, id interface{}) *MockJobQueue_GetJobContext_Call {
+	return &MockJobQueue_GetJobContext_Call{Call: _e.mock.On("GetJobContext", ctx, id)}
 }
 
-func (_c *MockJobQueue_SetJobExecutor_Call) Run(run func(executor interfaces.JobExecutor)) *MockJobQueue_SetJobExecutor_Call {
+func (_c *MockJobQueue_GetJobContext_Call) Run(run func(ctx context.Context, id int64)) *MockJobQueue_GetJobContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(interfaces.JobExecutor))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *MockJobQueue_SetJobExecutor_Call) Return() *MockJobQueue_SetJobExecutor_Call {
-	_c.Call.Return()
+func (_c *MockJobQueue_GetJobContext_Call) Return(_a0 *models.Job, _a1 error) *MockJobQueue_GetJobContext_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockJobQueue_SetJobExecutor_Call) RunAndReturn(run func(interfaces.JobExecutor)) *MockJobQueue_SetJobExecutor_Call {
-	_c.Run(run)
+func (_c *MockJobQueue_GetJobContext_Call) RunAndReturn(run func(context.Context, int64) (*models.Job, error)) *MockJobQueue_GetJobContext_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Start provides a mock function with given fields: ctx
-func (_m *MockJobQueue) Start(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// GetJobStatsByCategory provides a mock function with no fields
+func (_m *MockJobQueue) GetJobStatsByCategory() ([]*models.CategoryStats, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Start")
+		panic("no return value specified for GetJobStatsByCategory")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 []*models.CategoryStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*models.CategoryStats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*models.CategoryStats); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CategoryStats)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockJobQueue_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
-type MockJobQueue_Start_Call struct {
+// MockJobQueue_GetJobStatsByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobStatsByCategory'
+type MockJobQueue_GetJobStatsByCategory_Call struct {
 	*mock.Call
 }
 
-// Start is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockJobQueue_Expecter) Start(ctx interface{}) *MockJobQueue_Start_Call {
+// GetJobStatsByCategory is a helper method to define mock.On call
+func (_e *MockJobQueue_Expecter) GetJobStatsByCategory() *MockJobQueue_GetJobStatsByCategory_Call {
+	return &MockJobQueue_GetJobStatsByCategory_Call{Call: _e.mock.On("GetJobStatsByCategory")}
+}
+
+func (_c *MockJobQueue_GetJobStatsByCategory_Call) Run(run func()) *MockJobQueue_GetJobStatsByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobStatsByCategory_Call) Return(_a0 []*models.CategoryStats, _a1 error) *MockJobQueue_GetJobStatsByCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobStatsByCategory_Call) RunAndReturn(run func() ([]*models.CategoryStats, error)) *MockJobQueue_GetJobStatsByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobs provides a mock function with given fields: filter
+func (_m *MockJobQueue) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.JobFilter) ([]*models.Job, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(models.JobFilter) []*models.Job); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.JobFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobs'
+type MockJobQueue_GetJobs_Call struct {
+	*mock.Call
+}
+
+// GetJobs is a helper method to define mock.On call
+//   - filter models.JobFilter
+func (_e *MockJobQueue_Expecter) GetJobs(filter interface{}) *MockJobQueue_GetJobs_Call {
+	return &MockJobQueue_GetJobs_Call{Call: _e.mock.On("GetJobs", filter)}
+}
+
+func (_c *MockJobQueue_GetJobs_Call) Run(run func(filter models.JobFilter)) *MockJobQueue_GetJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.JobFilter))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobs_Call) Return(_a0 []*models.Job, _a1 error) *MockJobQueue_GetJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobs_Call) RunAndReturn(run func(models.JobFilter) ([]*models.Job, error)) *MockJobQueue_GetJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobsByIDs provides a mock function with given fields: ids
+func (_m *MockJobQueue) GetJobsByIDs(ids []int64) ([]*models.Job, error) {
+	ret := _m.Called(ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobsByIDs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int64) ([]*models.Job, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]int64) []*models.Job); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetJobsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobsByIDs'
+type MockJobQueue_GetJobsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetJobsByIDs is a helper method to define mock.On call
+//   - ids []int64
+func (_e *MockJobQueue_Expecter) GetJobsByIDs(ids interface{}) *MockJobQueue_GetJobsByIDs_Call {
+	return &MockJobQueue_GetJobsByIDs_Call{Call: _e.mock.On("GetJobsByIDs", ids)}
+}
+
+func (_c *MockJobQueue_GetJobsByIDs_Call) Run(run func(ids []int64)) *MockJobQueue_GetJobsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobsByIDs_Call) Return(_a0 []*models.Job, _a1 error) *MockJobQueue_GetJobsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobsByIDs_Call) RunAndReturn(run func([]int64) ([]*models.Job, error)) *MockJobQueue_GetJobsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobsContext provides a mock function with given fields: ctx, filter
+func (_m *MockJobQueue) GetJobsContext(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobsContext")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.JobFilter) ([]*models.Job, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.JobFilter) []*models.Job); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.JobFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetJobsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobsContext'
+type MockJobQueue_GetJobsContext_Call struct {
+	*mock.Call
+}
+
+// GetJobsContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.JobFilter
+func (_e *MockJobQueue_Expecter) GetJobsContext(ctx interface{}, filter interface{}) *MockJobQueue_GetJobsContext_Call {
+	return &MockJobQueue_GetJobsContext_Call{Call: _e.mock.On("GetJobsContext", ctx, filter)}
+}
+
+func (_c *MockJobQueue_GetJobsContext_Call) Run(run func(ctx context.Context, filter models.JobFilter)) *MockJobQueue_GetJobsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.JobFilter))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobsContext_Call) Return(_a0 []*models.Job, _a1 error) *MockJobQueue_GetJobsContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetJobsContext_Call) RunAndReturn(run func(context.Context, models.JobFilter) ([]*models.Job, error)) *MockJobQueue_GetJobsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLifetimeStats provides a mock function with no fields
+func (_m *MockJobQueue) GetLifetimeStats() (*models.LifetimeStats, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLifetimeStats")
+	}
+
+	var r0 *models.LifetimeStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*models.LifetimeStats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *models.LifetimeStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LifetimeStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetLifetimeStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLifetimeStats'
+type MockJobQueue_GetLifetimeStats_Call struct {
+	*mock.Call
+}
+
+// GetLifetimeStats is a helper method to define mock.On call
+func (_e *MockJobQueue_Expecter) GetLifetimeStats() *MockJobQueue_GetLifetimeStats_Call {
+	return &MockJobQueue_GetLifetimeStats_Call{Call: _e.mock.On("GetLifetimeStats")}
+}
+
+func (_c *MockJobQueue_GetLifetimeStats_Call) Run(run func()) *MockJobQueue_GetLifetimeStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetLifetimeStats_Call) Return(_a0 *models.LifetimeStats, _a1 error) *MockJobQueue_GetLifetimeStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetLifetimeStats_Call) RunAndReturn(run func() (*models.LifetimeStats, error)) *MockJobQueue_GetLifetimeStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSummary provides a mock function with no fields
+func (_m *MockJobQueue) GetSummary() (*models.JobSummary, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *models.JobSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*models.JobSummary, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *models.JobSummary); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type MockJobQueue_GetSummary_Call struct {
+	*mock.Call
+}
+
+// GetSummary is a helper method to define mock.On call
+func (_e *MockJobQueue_Expecter) GetSummary() *MockJobQueue_GetSummary_Call {
+	return &MockJobQueue_GetSummary_Call{Call: _e.mock.On("GetSummary")}
+}
+
+func (_c *MockJobQueue_GetSummary_Call) Run(run func()) *MockJobQueue_GetSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetSummary_Call) Return(_a0 *models.JobSummary, _a1 error) *MockJobQueue_GetSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetSummary_Call) RunAndReturn(run func() (*models.JobSummary, error)) *MockJobQueue_GetSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferStats provides a mock function with given fields: since
+func (_m *MockJobQueue) GetTransferStats(since time.Time) (*models.TransferStats, error) {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferStats")
+	}
+
+	var r0 *models.TransferStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (*models.TransferStats, error)); ok {
+		return rf(since)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) *models.TransferStats); ok {
+		r0 = rf(since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_GetTransferStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferStats'
+type MockJobQueue_GetTransferStats_Call struct {
+	*mock.Call
+}
+
+// GetTransferStats is a helper method to define mock.On call
+//   - since time.Time
+func (_e *MockJobQueue_Expecter) GetTransferStats(since interface{}) *MockJobQueue_GetTransferStats_Call {
+	return &MockJobQueue_GetTransferStats_Call{Call: _e.mock.On("GetTransferStats", since)}
+}
+
+func (_c *MockJobQueue_GetTransferStats_Call) Run(run func(since time.Time)) *MockJobQueue_GetTransferStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_GetTransferStats_Call) Return(_a0 *models.TransferStats, _a1 error) *MockJobQueue_GetTransferStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_GetTransferStats_Call) RunAndReturn(run func(time.Time) (*models.TransferStats, error)) *MockJobQueue_GetTransferStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *MockJobQueue) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobQueue_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockJobQueue_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobQueue_Expecter) Ping(ctx interface{}) *MockJobQueue_Ping_Call {
+	return &MockJobQueue_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockJobQueue_Ping_Call) Run(run func(ctx context.Context)) *MockJobQueue_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_Ping_Call) Return(_a0 error) *MockJobQueue_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobQueue_Ping_Call) RunAndReturn(run func(context.Context) error) *MockJobQueue_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeJobs provides a mock function with given fields: statuses, before
+func (_m *MockJobQueue) PurgeJobs(statuses []models.JobStatus, before time.Time) (int, error) {
+	ret := _m.Called(statuses, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeJobs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]models.JobStatus, time.Time) (int, error)); ok {
+		return rf(statuses, before)
+	}
+	if rf, ok := ret.Get(0).(func([]models.JobStatus, time.Time) int); ok {
+		r0 = rf(statuses, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func([]models.JobStatus, time.Time) error); ok {
+		r1 = rf(statuses, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_PurgeJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeJobs'
+type MockJobQueue_PurgeJobs_Call struct {
+	*mock.Call
+}
+
+// PurgeJobs is a helper method to define mock.On call
+//   - statuses []models.JobStatus
+//   - before time.Time
+func (_e *MockJobQueue_Expecter) PurgeJobs(statuses interface{}, before interface{}) *MockJobQueue_PurgeJobs_Call {
+	return &MockJobQueue_PurgeJobs_Call{Call: _e.mock.On("PurgeJobs", statuses, before)}
+}
+
+func (_c *MockJobQueue_PurgeJobs_Call) Run(run func(statuses []models.JobStatus, before time.Time)) *MockJobQueue_PurgeJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.JobStatus), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_PurgeJobs_Call) Return(_a0 int, _a1 error) *MockJobQueue_PurgeJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_PurgeJobs_Call) RunAndReturn(run func([]models.JobStatus, time.Time) (int, error)) *MockJobQueue_PurgeJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreJob provides a mock function with given fields: id
+func (_m *MockJobQueue) RestoreJob(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobQueue_RestoreJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreJob'
+type MockJobQueue_RestoreJob_Call struct {
+	*mock.Call
+}
+
+// RestoreJob is a helper method to define mock.On call
+//   - id int64
+func (_e *MockJobQueue_Expecter) RestoreJob(id interface{}) *MockJobQueue_RestoreJob_Call {
+	return &MockJobQueue_RestoreJob_Call{Call: _e.mock.On("RestoreJob", id)}
+}
+
+func (_c *MockJobQueue_RestoreJob_Call) Run(run func(id int64)) *MockJobQueue_RestoreJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_RestoreJob_Call) Return(_a0 error) *MockJobQueue_RestoreJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobQueue_RestoreJob_Call) RunAndReturn(run func(int64) error) *MockJobQueue_RestoreJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryJob provides a mock function with given fields: id
+func (_m *MockJobQueue) RetryJob(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobQueue_RetryJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryJob'
+type MockJobQueue_RetryJob_Call struct {
+	*mock.Call
+}
+
+// RetryJob is a helper method to define mock.On call
+//   - id int64
+func (_e *MockJobQueue_Expecter) RetryJob(id interface{}) *MockJobQueue_RetryJob_Call {
+	return &MockJobQueue_RetryJob_Call{Call: _e.mock.On("RetryJob", id)}
+}
+
+func (_c *MockJobQueue_RetryJob_Call) Run(run func(id int64)) *MockJobQueue_RetryJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_RetryJob_Call) Return(_a0 error) *MockJobQueue_RetryJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobQueue_RetryJob_Call) RunAndReturn(run func(int64) error) *MockJobQueue_RetryJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetJobExecutor provides a mock function with given fields: executor
+func (_m *MockJobQueue) SetJobExecutor(executor interfaces.JobExecutor) {
+	_m.Called(executor)
+}
+
+// MockJobQueue_SetJobExecutor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetJobExecutor'
+type MockJobQueue_SetJobExecutor_Call struct {
+	*mock.Call
+}
+
+// SetJobExecutor is a helper method to define mock.On call
+//   - executor interfaces.JobExecutor
+func (_e *MockJobQueue_Expecter) SetJobExecutor(executor interface{}) *MockJobQueue_SetJobExecutor_Call {
+	return &MockJobQueue_SetJobExecutor_Call{Call: _e.mock.On("SetJobExecutor", executor)}
+}
+
+func (_c *MockJobQueue_SetJobExecutor_Call) Run(run func(executor interfaces.JobExecutor)) *MockJobQueue_SetJobExecutor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interfaces.JobExecutor))
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_SetJobExecutor_Call) Return() *MockJobQueue_SetJobExecutor_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJobQueue_SetJobExecutor_Call) RunAndReturn(run func(interfaces.JobExecutor)) *MockJobQueue_SetJobExecutor_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *MockJobQueue) Start(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobQueue_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockJobQueue_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobQueue_Expecter) Start(ctx interface{}) *MockJobQueue_Start_Call {
 	return &MockJobQueue_Start_Call{Call: _e.mock.On("Start", ctx)}
 }
 
@@ -561,6 +1395,63 @@ func (_c *MockJobQueue_Stop_Call) RunAndReturn(run func() error) *MockJobQueue_S
 	return _c
 }
 
+// Vacuum provides a mock function with no fields
+func (_m *MockJobQueue) Vacuum() (*models.MaintenanceResult, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Vacuum")
+	}
+
+	var r0 *models.MaintenanceResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*models.MaintenanceResult, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *models.MaintenanceResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MaintenanceResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobQueue_Vacuum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Vacuum'
+type MockJobQueue_Vacuum_Call struct {
+	*mock.Call
+}
+
+// Vacuum is a helper method to define mock.On call
+func (_e *MockJobQueue_Expecter) Vacuum() *MockJobQueue_Vacuum_Call {
+	return &MockJobQueue_Vacuum_Call{Call: _e.mock.On("Vacuum")}
+}
+
+func (_c *MockJobQueue_Vacuum_Call) Run(run func()) *MockJobQueue_Vacuum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJobQueue_Vacuum_Call) Return(_a0 *models.MaintenanceResult, _a1 error) *MockJobQueue_Vacuum_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobQueue_Vacuum_Call) RunAndReturn(run func() (*models.MaintenanceResult, error)) *MockJobQueue_Vacuum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockJobQueue creates a new instance of MockJobQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockJobQueue(t interface {
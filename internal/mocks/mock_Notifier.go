@@ -206,6 +206,51 @@ func (_c *MockNotifier_NotifySystemAlert_Call) RunAndReturn(run func(string, str
 	return _c
 }
 
+// TestNotification provides a mock function with no fields
+func (_m *MockNotifier) TestNotification() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifier_TestNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestNotification'
+type MockNotifier_TestNotification_Call struct {
+	*mock.Call
+}
+
+// TestNotification is a helper method to define mock.On call
+func (_e *MockNotifier_Expecter) TestNotification() *MockNotifier_TestNotification_Call {
+	return &MockNotifier_TestNotification_Call{Call: _e.mock.On("TestNotification")}
+}
+
+func (_c *MockNotifier_TestNotification_Call) Run(run func()) *MockNotifier_TestNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockNotifier_TestNotification_Call) Return(_a0 error) *MockNotifier_TestNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifier_TestNotification_Call) RunAndReturn(run func() error) *MockNotifier_TestNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockNotifier creates a new instance of MockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockNotifier(t interface {
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Placeholders accepted in a dest_template besides extra.<field>, which reads a
+// scalar from the job's metadata.extra_fields. year, month and day come from the
+// job's creation date.
+var destTemplateKeys = map[string]bool{
+	"category":     true,
+	"name":         true,
+	"torrent_name": true,
+	"year":         true,
+	"month":        true,
+	"day":          true,
+}
+
+const destTemplateExtraPrefix = "extra."
+
+var destTemplateDateLayouts = map[string]string{"year": "2006", "month": "01", "day": "02"}
+
+// destTemplatePart is a literal run of text or, when key is set, a placeholder.
+type destTemplatePart struct {
+	literal string
+	key     string
+}
+
+// parseDestTemplate splits a template such as "{category}/{year}" into literal
+// text and placeholders, rejecting unbalanced braces, unknown keys, absolute
+// templates and literal ".." segments.
+func parseDestTemplate(tmpl string) ([]destTemplatePart, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		return nil, fmt.Errorf("dest_template is empty")
+	}
+	if strings.HasPrefix(tmpl, "/") || strings.Contains(tmpl, `\`) {
+		return nil, fmt.Errorf("dest_template must be a relative path using '/' separators: %s", tmpl)
+	}
+
+	var parts []destTemplatePart
+	rest := tmpl
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if close := strings.IndexByte(rest, '}'); close >= 0 && (open < 0 || close < open) {
+			return nil, fmt.Errorf("dest_template has an unmatched '}': %s", tmpl)
+		}
+		if open < 0 {
+			parts = append(parts, destTemplatePart{literal: rest})
+			break
+		}
+		if open > 0 {
+			parts = append(parts, destTemplatePart{literal: rest[:open]})
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("dest_template has an unmatched '{': %s", tmpl)
+		}
+		key := rest[open+1 : open+end]
+		if !destTemplateKeys[key] && (!strings.HasPrefix(key, destTemplateExtraPrefix) || key == destTemplateExtraPrefix) {
+			return nil, fmt.Errorf("dest_template has unknown placeholder {%s}", key)
+		}
+		parts = append(parts, destTemplatePart{key: key})
+		rest = rest[open+end+1:]
+	}
+
+	for _, part := range parts {
+		for _, segment := range strings.Split(part.literal, "/") {
+			if segment == ".." {
+				return nil, fmt.Errorf("dest_template must not contain '..': %s", tmpl)
+			}
+		}
+	}
+
+	return parts, nil
+}
+
+// ValidateDestTemplate checks a dest_template's syntax without expanding it.
+func ValidateDestTemplate(tmpl string) error {
+	_, err := parseDestTemplate(tmpl)
+	return err
+}
+
+// ExpandDestTemplate fills a dest_template from the job and returns the cleaned
+// subdirectory it names, relative to the job's LocalPath. Every placeholder must
+// have a value, and a value must be a single path segment, so metadata cannot
+// add directories or climb out of LocalPath.
+func ExpandDestTemplate(tmpl string, job *Job) (string, error) {
+	parts, err := parseDestTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		if part.key == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		value, ok := destTemplateValue(job, part.key)
+		if !ok || strings.TrimSpace(value) == "" {
+			return "", fmt.Errorf("dest_template placeholder {%s} has no value for this job", part.key)
+		}
+		if strings.ContainsAny(value, `/\`) || value == "." || value == ".." {
+			return "", fmt.Errorf("dest_template placeholder {%s} is not a single path segment: %q", part.key, value)
+		}
+		b.WriteString(value)
+	}
+
+	expanded := path.Clean(b.String())
+	if expanded == "." || expanded == ".." || strings.HasPrefix(expanded, "../") || path.IsAbs(expanded) {
+		return "", fmt.Errorf("dest_template expands outside the download directory: %q", b.String())
+	}
+	return expanded, nil
+}
+
+// DestSubpathField is the extra_fields key recording the subdirectory a
+// dest_template appended to LocalPath, so it is only ever applied once.
+const DestSubpathField = "dest_subpath"
+
+// ApplyDestTemplate expands tmpl, appends it to the job's LocalPath and records
+// it under DestSubpathField. An empty template, or a job it was already applied
+// to, is left unchanged, so a retried job isn't nested twice.
+func (j *Job) ApplyDestTemplate(tmpl string) error {
+	if _, done := j.Metadata.ExtraFields[DestSubpathField]; done || tmpl == "" {
+		return nil
+	}
+
+	subpath, err := ExpandDestTemplate(tmpl, j)
+	if err != nil {
+		return err
+	}
+
+	j.LocalPath = filepath.Join(j.LocalPath, filepath.FromSlash(subpath))
+	if j.Metadata.ExtraFields == nil {
+		j.Metadata.ExtraFields = make(map[string]interface{})
+	}
+	j.Metadata.ExtraFields[DestSubpathField] = subpath
+	return nil
+}
+
+func destTemplateValue(job *Job, key string) (string, bool) {
+	switch key {
+	case "category":
+		return job.Metadata.Category, true
+	case "name":
+		return job.Name, true
+	case "torrent_name":
+		return job.Metadata.TorrentName, true
+	case "year", "month", "day":
+		if job.CreatedAt.IsZero() {
+			return "", false
+		}
+		return job.CreatedAt.Format(destTemplateDateLayouts[key]), true
+	}
+
+	field := strings.TrimPrefix(key, destTemplateExtraPrefix)
+	switch v := job.Metadata.ExtraFields[field].(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	}
+	return "", false
+}
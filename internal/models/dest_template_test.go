@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDestTemplate(t *testing.T) {
+	valid := []string{
+		"{category}/{year}",
+		"{year}-{month}",
+		"library/{extra.show}/Season {extra.season}",
+		"fixed",
+	}
+	for _, tmpl := range valid {
+		assert.NoError(t, ValidateDestTemplate(tmpl), tmpl)
+	}
+
+	invalid := map[string]string{
+		"":                  "dest_template is empty",
+		"/abs/{year}":       "dest_template must be a relative path using '/' separators: /abs/{year}",
+		`{category}\{year}`: `dest_template must be a relative path using '/' separators: {category}\{year}`,
+		"{category":         "dest_template has an unmatched '{': {category",
+		"category}":         "dest_template has an unmatched '}': category}",
+		"{season}":          "dest_template has unknown placeholder {season}",
+		"{extra.}":          "dest_template has unknown placeholder {extra.}",
+		"{category}/../x":   "dest_template must not contain '..': {category}/../x",
+	}
+	for tmpl, want := range invalid {
+		err := ValidateDestTemplate(tmpl)
+		if assert.Error(t, err, tmpl) {
+			assert.Equal(t, want, err.Error())
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 // DownloadConfig represents configurable rclone download settings
@@ -31,6 +33,9 @@ type DownloadConfig struct {
 	IgnoreExisting *bool `json:"ignore_existing,omitempty"`
 	NoTraverse     *bool `json:"no_traverse,omitempty"`
 	UpdateOlder    *bool `json:"update_older,omitempty"`
+
+	// Destination layout: subdirectory of local_path, e.g. "{category}/{year}"
+	DestTemplate *string `json:"dest_template,omitempty"`
 }
 
 // DefaultDownloadConfig returns the default download configuration used by the system
@@ -185,6 +190,73 @@ func (dc *DownloadConfig) ToRCloneConfig() map[string]interface{} {
 	return config
 }
 
+// Accepted ranges for the numeric DownloadConfig fields.
+const (
+	MaxTransfers          = 32
+	MaxCheckers           = 64
+	MaxSftpConcurrency    = 64
+	MaxMultiThreadStreams = 64
+)
+
+// bwLimitPattern matches a bandwidth rate: KiB/s, or with a K, M or G suffix.
+// rsync --bwlimit accepts exactly these.
+var bwLimitPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[KkMmGg]?$`)
+
+// sizePattern matches a size such as "32M" or "10G".
+var sizePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[KkMmGgTt]?$`)
+
+// IsValidBwLimit reports whether limit is a bandwidth rate or "off".
+func IsValidBwLimit(limit string) bool {
+	return strings.EqualFold(limit, "off") || bwLimitPattern.MatchString(limit)
+}
+
+// Validate checks the fields that are set and returns errors keyed by JSON
+// field name, or nil if the config is valid.
+func (dc *DownloadConfig) Validate() map[string]string {
+	if dc == nil {
+		return nil
+	}
+
+	errs := make(map[string]string)
+	checkRange := func(field string, v *int, min, max int) {
+		if v != nil && (*v < min || *v > max) {
+			errs[field] = fmt.Sprintf("%s must be between %d and %d", field, min, max)
+		}
+	}
+	checkRange("transfers", dc.Transfers, 1, MaxTransfers)
+	checkRange("checkers", dc.Checkers, 1, MaxCheckers)
+	checkRange("sftp_concurrency", dc.SftpConcurrency, 1, MaxSftpConcurrency)
+	checkRange("multi_thread_streams", dc.MultiThreadStreams, 0, MaxMultiThreadStreams)
+
+	checkRate := func(field string, v *string) {
+		if v != nil && !IsValidBwLimit(*v) {
+			errs[field] = fmt.Sprintf("%s must be a rate like 10M or 512K, or off", field)
+		}
+	}
+	checkRate("bw_limit", dc.BwLimit)
+	checkRate("bw_limit_file", dc.BwLimitFile)
+
+	checkSize := func(field string, v *string) {
+		if v != nil && !sizePattern.MatchString(*v) {
+			errs[field] = fmt.Sprintf("%s must be a size like 32M", field)
+		}
+	}
+	checkSize("buffer_size", dc.BufferSize)
+	checkSize("sftp_chunk_size", dc.SftpChunkSize)
+	checkSize("multi_thread_cutoff", dc.MultiThreadCutoff)
+
+	if dc.DestTemplate != nil {
+		if err := ValidateDestTemplate(*dc.DestTemplate); err != nil {
+			errs["dest_template"] = err.Error()
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 // Database value methods for custom types
 func (dc DownloadConfig) Value() (driver.Value, error) {
 	return json.Marshal(dc)
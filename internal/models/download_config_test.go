@@ -192,3 +192,33 @@ func TestDownloadConfig_Scan_InvalidType(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot scan")
 }
+
+func TestDownloadConfig_Validate(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	var nilConfig *DownloadConfig
+	assert.Nil(t, nilConfig.Validate())
+	assert.Nil(t, (&DownloadConfig{}).Validate())
+	assert.Nil(t, DefaultDownloadConfig().Validate())
+	assert.Nil(t, (&DownloadConfig{Transfers: intPtr(MaxTransfers), BwLimit: strPtr("off"), BwLimitFile: strPtr("1.5M")}).Validate())
+
+	errs := (&DownloadConfig{
+		Transfers:          intPtr(MaxTransfers + 1),
+		Checkers:           intPtr(0),
+		SftpConcurrency:    intPtr(-2),
+		MultiThreadStreams: intPtr(MaxMultiThreadStreams + 1),
+		BwLimit:            strPtr("10M:1M"),
+		BufferSize:         strPtr("32 MB"),
+		MultiThreadCutoff:  strPtr("10G"),
+		DestTemplate:       strPtr("{category}/{season}"),
+	}).Validate()
+	assert.Len(t, errs, 7)
+	assert.Equal(t, "transfers must be between 1 and 32", errs["transfers"])
+	assert.Contains(t, errs, "checkers")
+	assert.Contains(t, errs, "sftp_concurrency")
+	assert.Contains(t, errs, "multi_thread_streams")
+	assert.Contains(t, errs, "bw_limit")
+	assert.Contains(t, errs, "buffer_size")
+	assert.Equal(t, "dest_template has unknown placeholder {season}", errs["dest_template"])
+}
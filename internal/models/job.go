@@ -4,6 +4,8 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 )
 
@@ -18,10 +20,23 @@ const (
 	JobStatusCancelled JobStatus = "cancelled"
 )
 
+// FailureCategory classifies why a job's last attempt failed, so clients can
+// tell configuration problems from transient ones without parsing ErrorMessage.
+type FailureCategory string
+
+const (
+	FailureCategoryAuth     FailureCategory = "auth"     // SSH authentication or host key rejected
+	FailureCategoryDisk     FailureCategory = "disk"     // local disk full or not writable
+	FailureCategoryNetwork  FailureCategory = "network"  // connection lost, refused, or timed out
+	FailureCategoryNotFound FailureCategory = "notfound" // remote path does not exist
+	FailureCategoryUnknown  FailureCategory = "unknown"
+)
+
 type Job struct {
 	ID               int64           `json:"id" db:"id"`
 	Name             string          `json:"name" db:"name"`
 	RemotePath       string          `json:"remote_path" db:"remote_path"`
+	SourceURL        string          `json:"source_url,omitempty" db:"source_url"` // HTTP(S) source; set instead of RemotePath for copyurl jobs
 	LocalPath        string          `json:"local_path" db:"local_path"`
 	Status           JobStatus       `json:"status" db:"status"`
 	Priority         int             `json:"priority" db:"priority"`
@@ -38,10 +53,31 @@ type Job struct {
 	FileSize         int64           `json:"file_size,omitempty" db:"file_size"`
 	TransferredBytes int64           `json:"transferred_bytes" db:"transferred_bytes"`
 	TransferSpeed    int64           `json:"transfer_speed,omitempty" db:"transfer_speed"`
+	GroupID          string          `json:"group_id,omitempty" db:"group_id"`
+	FailureCategory  FailureCategory `json:"failure_category,omitempty" db:"failure_category"`
+	DeletedAt        *time.Time      `json:"deleted_at,omitempty" db:"deleted_at"`
+	EvictedAt        *time.Time      `json:"evicted_at,omitempty" db:"evicted_at"` // set when cache eviction removed the local files
+
+	// SortKey is the job's value for the sort of the listing it came from, as
+	// the database compares it; set by GetJobs for keyset cursors.
+	SortKey interface{} `json:"-" db:"-"`
+}
+
+// Cursor returns the keyset position just after j in the listing it came from.
+func (j *Job) Cursor() *JobCursor {
+	return &JobCursor{ID: j.ID, SortKey: j.SortKey}
+}
+
+// JobCursor is a keyset position in a sorted job listing: the last job seen and
+// its sort key. Pages resume from these values rather than from the job's
+// current row, so deleting or updating that job doesn't disturb the listing.
+type JobCursor struct {
+	ID      int64
+	SortKey interface{} // int64, float64 or string, as read from the database
 }
 
 type JobProgress struct {
-	Percentage       float64    `json:"percentage"`
+	Percentage       float64    `json:"percentage"` // highest percentage reported for the current attempt; see ObservePercentage
 	TransferredBytes int64      `json:"transferred_bytes"`
 	TotalBytes       int64      `json:"total_bytes"`
 	TransferSpeed    int64      `json:"transfer_speed"`
@@ -52,6 +88,17 @@ type JobProgress struct {
 	LastUpdateTime   time.Time  `json:"last_update_time"`
 }
 
+// ObservePercentage records a raw percentage reported by a transfer. The
+// stored percentage is clamped to [0, 100] and never decreases, since
+// transfers that discover files as they go can report a shrinking percentage
+// when the total grows.
+func (p *JobProgress) ObservePercentage(raw float64) {
+	raw = math.Max(0, math.Min(100, raw))
+	if raw > p.Percentage {
+		p.Percentage = raw
+	}
+}
+
 type JobMetadata struct {
 	QBittorrentHash string                 `json:"qbittorrent_hash,omitempty"`
 	Category        string                 `json:"category,omitempty"`
@@ -74,6 +121,28 @@ type JobAttempt struct {
 	LogData      string     `json:"log_data,omitempty" db:"log_data"`
 }
 
+// AuditEvent names a job lifecycle transition recorded in the audit log.
+type AuditEvent string
+
+const (
+	AuditEventCreated   AuditEvent = "created"
+	AuditEventStarted   AuditEvent = "started"
+	AuditEventCompleted AuditEvent = "completed"
+	AuditEventFailed    AuditEvent = "failed"
+	AuditEventCancelled AuditEvent = "cancelled"
+	AuditEventRetried   AuditEvent = "retried"
+	AuditEventEvicted   AuditEvent = "evicted"
+)
+
+// AuditEntry is one append-only record of a job state transition.
+type AuditEntry struct {
+	ID        int64      `json:"id" db:"id"`
+	JobID     int64      `json:"job_id" db:"job_id"`
+	Event     AuditEvent `json:"event" db:"event"`
+	Message   string     `json:"message,omitempty" db:"message"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
 // Database value methods for custom types
 func (jp JobProgress) Value() (driver.Value, error) {
 	return json.Marshal(jp)
@@ -150,6 +219,7 @@ func (j *Job) MarkStarted() {
 func (j *Job) MarkCompleted() {
 	now := time.Now()
 	j.Status = JobStatusCompleted
+	j.FailureCategory = ""
 	j.CompletedAt = &now
 	j.UpdatedAt = now
 	j.Progress.Percentage = 100.0
@@ -188,6 +258,12 @@ func (j *Job) IsExtractionJob() bool {
 	return false
 }
 
+// IsCopyURLJob returns true if this job downloads from SourceURL over HTTP(S)
+// rather than from the seedbox.
+func (j *Job) IsCopyURLJob() bool {
+	return j.SourceURL != ""
+}
+
 func (j *Job) IncrementRetry() {
 	j.Retries++
 	j.Status = JobStatusQueued
@@ -197,14 +273,38 @@ func (j *Job) IncrementRetry() {
 
 // JobFilter represents filtering options for job queries
 type JobFilter struct {
-	Status      []JobStatus `json:"status,omitempty"`
-	Category    string      `json:"category,omitempty"`
-	MinPriority *int        `json:"min_priority,omitempty"`
-	MaxPriority *int        `json:"max_priority,omitempty"`
-	Limit       int         `json:"limit,omitempty"`
-	Offset      int         `json:"offset,omitempty"`
-	SortBy      string      `json:"sort_by,omitempty"`
-	SortOrder   string      `json:"sort_order,omitempty"`
+	IDs            []int64     `json:"ids,omitempty"`
+	Status         []JobStatus `json:"status,omitempty"`
+	Categories     []string    `json:"categories,omitempty"` // metadata categories; a job matching any of them is included
+	GroupID        string      `json:"group_id,omitempty"`
+	Hash           string      `json:"hash,omitempty"` // qBittorrent torrent hash, case-insensitive
+	MinPriority    *int        `json:"min_priority,omitempty"`
+	MaxPriority    *int        `json:"max_priority,omitempty"`
+	IncludeDeleted bool        `json:"include_deleted,omitempty"` // also return soft-deleted jobs
+	Limit          int         `json:"limit,omitempty"`
+	Offset         int         `json:"offset,omitempty"`
+	After          *JobCursor  `json:"after,omitempty"` // keyset cursor: only jobs sorting after this position; Offset is ignored
+	SortBy         string      `json:"sort_by,omitempty"`
+	SortOrder      string      `json:"sort_order,omitempty"`
+}
+
+// JobSortFields lists the accepted JobFilter.SortBy values.
+var JobSortFields = []string{"created_at", "updated_at", "started_at", "completed_at", "priority", "progress", "name", "status", "file_size"}
+
+// IsValidJobSortField reports whether field is an accepted JobFilter.SortBy value.
+func IsValidJobSortField(field string) bool {
+	for _, f := range JobSortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSortOrder reports whether order is "asc" or "desc" (case-insensitive).
+func IsValidSortOrder(order string) bool {
+	upper := strings.ToUpper(order)
+	return upper == "ASC" || upper == "DESC"
 }
 
 // JobSummary represents aggregated job statistics
@@ -216,4 +316,119 @@ type JobSummary struct {
 	CompletedJobs int `json:"completed_jobs"`
 	FailedJobs    int `json:"failed_jobs"`
 	CancelledJobs int `json:"cancelled_jobs"`
+	// TotalActiveSpeedBytesPerSec is the combined transfer speed of running jobs.
+	TotalActiveSpeedBytesPerSec int64 `json:"total_active_speed_bytes_per_sec"`
+}
+
+// CategoryStats is a JobSummary for the jobs of one metadata.category.
+type CategoryStats struct {
+	Category         string `json:"category"` // empty for jobs without a category
+	TotalJobs        int    `json:"total_jobs"`
+	QueuedJobs       int    `json:"queued_jobs"`
+	PendingJobs      int    `json:"pending_jobs"`
+	RunningJobs      int    `json:"running_jobs"`
+	CompletedJobs    int    `json:"completed_jobs"`
+	FailedJobs       int    `json:"failed_jobs"`
+	CancelledJobs    int    `json:"cancelled_jobs"`
+	TransferredBytes int64  `json:"transferred_bytes"`
+}
+
+// JobGroup represents a set of related jobs and their rolled-up status
+type JobGroup struct {
+	GroupID          string     `json:"group_id"`
+	Status           JobStatus  `json:"status"`
+	Percentage       float64    `json:"percentage"`
+	TotalBytes       int64      `json:"total_bytes"`
+	TransferredBytes int64      `json:"transferred_bytes"`
+	Summary          JobSummary `json:"summary"`
+	Jobs             []*Job     `json:"jobs"`
+}
+
+// NewJobGroup aggregates the progress and status of jobs sharing a group ID.
+// The group is running while any job runs, waiting while any job is queued or
+// pending, and otherwise reports failed, cancelled, or completed in that order.
+func NewJobGroup(groupID string, jobs []*Job) *JobGroup {
+	group := &JobGroup{
+		GroupID: groupID,
+		Jobs:    jobs,
+	}
+
+	for _, job := range jobs {
+		group.Summary.TotalJobs++
+		switch job.Status {
+		case JobStatusQueued:
+			group.Summary.QueuedJobs++
+		case JobStatusPending:
+			group.Summary.PendingJobs++
+		case JobStatusRunning:
+			group.Summary.RunningJobs++
+			group.Summary.TotalActiveSpeedBytesPerSec += job.TransferSpeed
+		case JobStatusCompleted:
+			group.Summary.CompletedJobs++
+		case JobStatusFailed:
+			group.Summary.FailedJobs++
+		case JobStatusCancelled:
+			group.Summary.CancelledJobs++
+		}
+
+		size := job.FileSize
+		if size == 0 {
+			size = job.Progress.TotalBytes
+		}
+		transferred := job.TransferredBytes
+		if job.Status == JobStatusCompleted && size > 0 {
+			transferred = size
+		}
+		group.TotalBytes += size
+		group.TransferredBytes += transferred
+	}
+
+	if group.TotalBytes > 0 {
+		group.Percentage = float64(group.TransferredBytes) / float64(group.TotalBytes) * 100
+	} else if group.Summary.TotalJobs > 0 {
+		group.Percentage = float64(group.Summary.CompletedJobs) / float64(group.Summary.TotalJobs) * 100
+	}
+
+	s := group.Summary
+	switch {
+	case s.RunningJobs > 0:
+		group.Status = JobStatusRunning
+	case s.PendingJobs > 0:
+		group.Status = JobStatusPending
+	case s.QueuedJobs > 0:
+		group.Status = JobStatusQueued
+	case s.FailedJobs > 0:
+		group.Status = JobStatusFailed
+	case s.CancelledJobs > 0:
+		group.Status = JobStatusCancelled
+	default:
+		group.Status = JobStatusCompleted
+	}
+
+	return group
+}
+
+// MaintenanceResult reports what a database maintenance run did
+type MaintenanceResult struct {
+	CheckpointedFrames int   `json:"checkpointed_frames"` // WAL frames copied back into the database; -1 when not in WAL mode
+	Vacuumed           bool  `json:"vacuumed"`
+	SizeBefore         int64 `json:"size_before_bytes"`
+	SizeAfter          int64 `json:"size_after_bytes"`
+	ReclaimedBytes     int64 `json:"reclaimed_bytes"`
+}
+
+// LifetimeStats represents running totals that survive job cleanup
+type LifetimeStats struct {
+	BytesTransferred int64 `json:"bytes_transferred"`
+	CompletedJobs    int64 `json:"completed_jobs"`
+}
+
+// TransferStats represents aggregate transfer totals across completed jobs
+type TransferStats struct {
+	CompletedJobs          int     `json:"completed_jobs"`
+	TotalBytes             int64   `json:"total_bytes"`
+	TotalDurationSeconds   float64 `json:"total_duration_seconds"`
+	AverageSpeed           int64   `json:"average_speed"` // bytes/sec over total transfer time
+	AverageJobSize         int64   `json:"average_job_size"`
+	AverageDurationSeconds float64 `json:"average_duration_seconds"`
 }
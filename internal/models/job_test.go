@@ -252,6 +252,18 @@ func TestJob_UpdateProgress(t *testing.T) {
 	assert.True(t, job.UpdatedAt.After(beforeUpdate) || job.UpdatedAt.Equal(beforeUpdate))
 }
 
+func TestJobProgress_ObservePercentage(t *testing.T) {
+	var p JobProgress
+
+	raw := []float64{10, 35, 20, 34.9, 50, -5, 120, 99}
+	want := []float64{10, 35, 35, 35, 50, 50, 100, 100}
+
+	for i, r := range raw {
+		p.ObservePercentage(r)
+		assert.Equal(t, want[i], p.Percentage, "after raw value %v", r)
+	}
+}
+
 func TestJob_MarkStarted(t *testing.T) {
 	job := &Job{Status: JobStatusQueued}
 	beforeMark := time.Now()
@@ -328,6 +340,60 @@ func TestJob_ArchiveGroup(t *testing.T) {
 	})
 }
 
+func TestNewJobGroup(t *testing.T) {
+	t.Run("rolls up progress and running status", func(t *testing.T) {
+		jobs := []*Job{
+			{Status: JobStatusCompleted, FileSize: 100, TransferredBytes: 100, TransferSpeed: 999},
+			{Status: JobStatusRunning, FileSize: 200, TransferredBytes: 50, TransferSpeed: 2048},
+			{Status: JobStatusQueued, FileSize: 100},
+		}
+
+		group := NewJobGroup("season-1", jobs)
+
+		assert.Equal(t, "season-1", group.GroupID)
+		assert.Equal(t, JobStatusRunning, group.Status)
+		assert.Equal(t, int64(400), group.TotalBytes)
+		assert.Equal(t, int64(150), group.TransferredBytes)
+		assert.InDelta(t, 37.5, group.Percentage, 0.001)
+		assert.Equal(t, 3, group.Summary.TotalJobs)
+		assert.Equal(t, 1, group.Summary.CompletedJobs)
+		assert.Equal(t, 1, group.Summary.RunningJobs)
+		assert.Equal(t, 1, group.Summary.QueuedJobs)
+		assert.Equal(t, int64(2048), group.Summary.TotalActiveSpeedBytesPerSec)
+		assert.Len(t, group.Jobs, 3)
+	})
+
+	tests := []struct {
+		name     string
+		statuses []JobStatus
+		expected JobStatus
+	}{
+		{"all completed", []JobStatus{JobStatusCompleted, JobStatusCompleted}, JobStatusCompleted},
+		{"waiting beats failed", []JobStatus{JobStatusFailed, JobStatusQueued}, JobStatusQueued},
+		{"pending beats queued", []JobStatus{JobStatusQueued, JobStatusPending}, JobStatusPending},
+		{"failed beats cancelled", []JobStatus{JobStatusCancelled, JobStatusFailed, JobStatusCompleted}, JobStatusFailed},
+		{"cancelled", []JobStatus{JobStatusCancelled, JobStatusCompleted}, JobStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobs []*Job
+			for _, status := range tt.statuses {
+				jobs = append(jobs, &Job{Status: status})
+			}
+			assert.Equal(t, tt.expected, NewJobGroup("g", jobs).Status)
+		})
+	}
+
+	t.Run("falls back to job counts without sizes", func(t *testing.T) {
+		group := NewJobGroup("g", []*Job{
+			{Status: JobStatusCompleted},
+			{Status: JobStatusQueued},
+		})
+		assert.InDelta(t, 50.0, group.Percentage, 0.001)
+	})
+}
+
 func TestJob_IsExtractionJob(t *testing.T) {
 	t.Run("true for extraction jobs", func(t *testing.T) {
 		job := &Job{
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// NormalizeRemotePath cleans a user-supplied seedbox path: surrounding whitespace
+// is trimmed, Windows backslash separators (including UNC "\\host\share"
+// prefixes) become forward slashes, duplicate slashes are collapsed, and a
+// leading slash is ensured.
+// A single trailing slash is kept because rsync treats "dir/" (copy contents)
+// differently from "dir". Paths containing ".." segments are rejected.
+func NormalizeRemotePath(p string) (string, error) {
+	p = strings.ReplaceAll(strings.TrimSpace(p), `\`, "/")
+	if p == "" {
+		return "", fmt.Errorf("remote path is empty")
+	}
+
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("remote path must not contain '..': %s", p)
+		}
+	}
+
+	trailingSlash := strings.HasSuffix(p, "/")
+
+	cleaned := path.Clean("/" + p)
+	if trailingSlash && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return cleaned, nil
+}
+
+// ResolveRemotePath normalizes a seedbox path supplied relative to root and
+// returns it joined under root, so "movies/x" with root "/downloads" becomes
+// "/downloads/movies/x". An empty root returns the normalized path unchanged.
+// The trailing-slash rule of NormalizeRemotePath is preserved, and any path
+// that would escape root is rejected.
+func ResolveRemotePath(root, p string) (string, error) {
+	normalized, err := NormalizeRemotePath(p)
+	if err != nil {
+		return "", err
+	}
+	if root == "" {
+		return normalized, nil
+	}
+
+	root = path.Clean("/" + root)
+	joined := path.Join(root, normalized)
+	if joined != root && !strings.HasPrefix(joined, strings.TrimSuffix(root, "/")+"/") {
+		return "", fmt.Errorf("remote path escapes remote root %s: %s", root, p)
+	}
+	if strings.HasSuffix(normalized, "/") && joined != "/" {
+		joined += "/"
+	}
+
+	return joined, nil
+}
+
+// SourceURLFileName validates a copyurl source and returns the name the download
+// is saved as: the URL's last path element. Only absolute http and https URLs
+// naming a file are accepted. Errors name the URL only in its redacted form.
+func SourceURLFileName(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid source URL: %s", RedactURL(raw))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("source URL must use http or https: %s", RedactURL(raw))
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("source URL has no host: %s", RedactURL(raw))
+	}
+
+	name := path.Base(u.Path)
+	if strings.HasSuffix(u.Path, "/") || name == "." || name == "/" || name == ".." {
+		return "", fmt.Errorf("source URL does not name a file: %s", RedactURL(raw))
+	}
+	return name, nil
+}
+
+// RedactURL strips the userinfo, query string and fragment from a URL so it can
+// be logged or sent in notifications; signed download links carry their
+// credentials there. A URL that doesn't parse is replaced entirely.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "[invalid URL]"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
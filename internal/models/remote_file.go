@@ -26,6 +26,13 @@ type RemoteFile struct {
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
+// RemoteSize is the total size of the files under a path on the seedbox.
+type RemoteSize struct {
+	Path       string `json:"path"`
+	TotalBytes int64  `json:"total_bytes"`
+	FileCount  int    `json:"file_count"`
+}
+
 // RemoteFileFilter is used to filter remote file queries
 type RemoteFileFilter struct {
 	Status      FileStatus
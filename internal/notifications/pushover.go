@@ -2,15 +2,16 @@ package notifications
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"grabarr/internal/config"
+	"grabarr/internal/httpclient"
 	"grabarr/internal/models"
 )
 
@@ -19,6 +20,11 @@ type PushoverNotifier struct {
 	httpClient *http.Client
 	enabled    bool
 	apiURL     string
+
+	// lastSent records when each job event was last notified, for dedup_window.
+	dedupMu  sync.Mutex
+	lastSent map[string]time.Time
+	now      func() time.Time // overridable in tests
 }
 
 type pushoverRequest struct {
@@ -47,12 +53,12 @@ const pushoverAPIURL = "https://api.pushover.net/1/messages.json"
 
 func NewPushoverNotifier(cfg *config.Config) *PushoverNotifier {
 	return &PushoverNotifier{
-		config: cfg,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		enabled: cfg.GetNotifications().Pushover.Enabled,
-		apiURL:  pushoverAPIURL,
+		config:     cfg,
+		httpClient: httpclient.New(cfg.GetNotifications().HTTP),
+		enabled:    cfg.GetNotifications().Pushover.Enabled,
+		apiURL:     pushoverAPIURL,
+		lastSent:   make(map[string]time.Time),
+		now:        time.Now,
 	}
 }
 
@@ -61,7 +67,11 @@ func (p *PushoverNotifier) IsEnabled() bool {
 }
 
 func (p *PushoverNotifier) NotifyJobFailed(job *models.Job) error {
-	if !p.enabled {
+	if !p.enabled || !p.shouldNotify(job, config.NotifyEventJobFailed) {
+		return nil
+	}
+	if p.isDuplicate(job.ID, config.NotifyEventJobFailed) {
+		slog.Debug("suppressing duplicate notification", "job_id", job.ID, "event", config.NotifyEventJobFailed)
 		return nil
 	}
 
@@ -92,17 +102,19 @@ func (p *PushoverNotifier) NotifyJobFailed(job *models.Job) error {
 		req.Expire = int(cfg.ExpireTime.Seconds())
 	}
 
-	return p.sendNotification(req)
+	if err := p.sendNotification(req); err != nil {
+		p.forgetSent(job.ID, config.NotifyEventJobFailed)
+		return err
+	}
+	return nil
 }
 
 func (p *PushoverNotifier) NotifyJobCompleted(job *models.Job) error {
-	if !p.enabled {
+	if !p.enabled || !p.shouldNotify(job, config.NotifyEventJobCompleted) {
 		return nil
 	}
-
-	// Only notify for important jobs or if explicitly requested
-	// You might want to add configuration for this
-	if job.Priority < 5 {
+	if p.isDuplicate(job.ID, config.NotifyEventJobCompleted) {
+		slog.Debug("suppressing duplicate notification", "job_id", job.ID, "event", config.NotifyEventJobCompleted)
 		return nil
 	}
 
@@ -121,7 +133,11 @@ func (p *PushoverNotifier) NotifyJobCompleted(job *models.Job) error {
 		Sound:     "none", // Silent for completions
 	}
 
-	return p.sendNotification(req)
+	if err := p.sendNotification(req); err != nil {
+		p.forgetSent(job.ID, config.NotifyEventJobCompleted)
+		return err
+	}
+	return nil
 }
 
 func (p *PushoverNotifier) NotifySystemAlert(title, message string, priority int) error {
@@ -160,22 +176,120 @@ func (p *PushoverNotifier) NotifySystemAlert(title, message string, priority int
 	return p.sendNotification(req)
 }
 
+// TestNotification sends a test message using the current Pushover settings so
+// configuration changes can be verified without a restart.
+func (p *PushoverNotifier) TestNotification() error {
+	if !p.enabled {
+		return fmt.Errorf("pushover notifications are disabled")
+	}
+
+	cfg := p.config.GetNotifications().Pushover
+
+	req := pushoverRequest{
+		Token:     cfg.Token,
+		User:      cfg.User,
+		Message:   "This is a test notification from Grabarr.",
+		Title:     "Grabarr Test Notification",
+		Priority:  cfg.Priority,
+		Timestamp: time.Now().Unix(),
+		Sound:     "pushover",
+	}
+
+	// Emergency priority requires retry/expire; a test should never page repeatedly
+	if req.Priority == 2 {
+		req.Priority = 1
+	}
+
+	return p.sendNotification(req)
+}
+
+// shouldNotify decides whether a job event is worth a notification. Events not listed
+// in notify_on are dropped; completions must also meet the priority and size thresholds.
+// Failures are always reported so problems are never silenced by thresholds.
+func (p *PushoverNotifier) shouldNotify(job *models.Job, event string) bool {
+	cfg := p.config.GetNotifications()
+
+	if len(cfg.NotifyOn) > 0 && !containsEvent(cfg.NotifyOn, event) {
+		return false
+	}
+
+	if event != config.NotifyEventJobCompleted {
+		return true
+	}
+
+	minPriority := config.DefaultNotifyMinPriority
+	if cfg.MinPriority != nil {
+		minPriority = *cfg.MinPriority
+	}
+	if job.Priority < minPriority {
+		return false
+	}
+
+	size := job.FileSize
+	if size == 0 {
+		size = job.TransferredBytes
+	}
+	return size >= cfg.MinSizeBytes
+}
+
+// isDuplicate reports whether the same event was already notified for the job within
+// dedup_window. Otherwise it records the event as sent now, so concurrent callers
+// cannot both slip through; forgetSent undoes that if the send then fails.
+func (p *PushoverNotifier) isDuplicate(jobID int64, event string) bool {
+	window := p.config.GetNotifications().DedupWindow
+	if window <= 0 {
+		return false
+	}
+
+	p.dedupMu.Lock()
+	defer p.dedupMu.Unlock()
+
+	now := p.now()
+	for key, sent := range p.lastSent {
+		if now.Sub(sent) >= window {
+			delete(p.lastSent, key)
+		}
+	}
+
+	key := dedupKey(jobID, event)
+	if _, ok := p.lastSent[key]; ok {
+		return true
+	}
+	p.lastSent[key] = now
+	return false
+}
+
+func (p *PushoverNotifier) forgetSent(jobID int64, event string) {
+	p.dedupMu.Lock()
+	defer p.dedupMu.Unlock()
+	delete(p.lastSent, dedupKey(jobID, event))
+}
+
+func dedupKey(jobID int64, event string) string {
+	return fmt.Sprintf("%d:%s", jobID, event)
+}
+
+func containsEvent(events []string, event string) bool {
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *PushoverNotifier) sendNotification(req pushoverRequest) error {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal pushover request: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.apiURL, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequest("POST", p.apiURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("User-Agent", "grabarr/1.0")
 
 	slog.Debug("sending pushover notification",
 		"title", req.Title,
@@ -207,7 +321,7 @@ func (p *PushoverNotifier) buildJobFailedMessage(job *models.Job) string {
 	var msg strings.Builder
 
 	msg.WriteString(fmt.Sprintf("Job: %s\n", job.Name))
-	msg.WriteString(fmt.Sprintf("Remote Path: %s\n", job.RemotePath))
+	msg.WriteString(sourceLine(job))
 	msg.WriteString(fmt.Sprintf("Status: %s\n", job.Status))
 	msg.WriteString(fmt.Sprintf("Retry: %d/%d\n", job.Retries, job.MaxRetries))
 
@@ -240,18 +354,34 @@ func (p *PushoverNotifier) buildJobCompletedMessage(job *models.Job) string {
 	var msg strings.Builder
 
 	msg.WriteString(fmt.Sprintf("Job: %s\n", job.Name))
-	msg.WriteString(fmt.Sprintf("Remote Path: %s\n", job.RemotePath))
+	msg.WriteString(sourceLine(job))
 
-	if job.StartedAt != nil && job.CompletedAt != nil {
-		duration := job.CompletedAt.Sub(*job.StartedAt)
-		msg.WriteString(fmt.Sprintf("Duration: %s\n", duration.Round(time.Second)))
+	var duration time.Duration
+	if job.StartedAt != nil && job.CompletedAt != nil && !job.StartedAt.IsZero() {
+		duration = job.CompletedAt.Sub(*job.StartedAt)
+	}
+	if duration > 0 {
+		msg.WriteString(fmt.Sprintf("Duration: %s\n", formatDuration(duration)))
+	}
+
+	totalBytes := job.Progress.TotalBytes
+	if totalBytes == 0 {
+		totalBytes = job.Progress.TransferredBytes
+	}
+	if totalBytes > 0 {
+		msg.WriteString(fmt.Sprintf("Size: %s\n", formatBytes(totalBytes)))
 	}
 
-	if job.Progress.TotalBytes > 0 {
-		msg.WriteString(fmt.Sprintf("Size: %s\n", formatBytes(job.Progress.TotalBytes)))
+	if job.Progress.FilesTotal > 0 {
+		msg.WriteString(fmt.Sprintf("Files: %d\n", job.Progress.FilesTotal))
 	}
 
-	if job.Progress.TransferSpeed > 0 {
+	// Prefer the average over the whole transfer; the last sampled speed is
+	// only a fallback when the duration is unknown.
+	if duration >= time.Second && totalBytes > 0 {
+		avg := int64(float64(totalBytes) / duration.Seconds())
+		msg.WriteString(fmt.Sprintf("Avg Speed: %s/s\n", formatBytes(avg)))
+	} else if job.Progress.TransferSpeed > 0 {
 		msg.WriteString(fmt.Sprintf("Avg Speed: %s/s\n", formatBytes(job.Progress.TransferSpeed)))
 	}
 
@@ -264,6 +394,37 @@ func (p *PushoverNotifier) buildJobCompletedMessage(job *models.Job) string {
 	return msg.String()
 }
 
+// sourceLine names where a job downloads from: its seedbox path, or the
+// redacted URL of a copyurl job.
+func sourceLine(job *models.Job) string {
+	if job.IsCopyURLJob() {
+		return fmt.Sprintf("Source URL: %s\n", models.RedactURL(job.SourceURL))
+	}
+	return fmt.Sprintf("Remote Path: %s\n", job.RemotePath)
+}
+
+// formatDuration renders d compactly at its two most significant units, e.g.
+// "1h23m", "4m05s" or "42s". Durations under a second render as "0s".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d <= 0 {
+		return "0s"
+	}
+
+	hours := int64(d / time.Hour)
+	minutes := int64(d % time.Hour / time.Minute)
+	seconds := int64(d % time.Minute / time.Second)
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
 func formatBytes(bytes int64) string {
 	if bytes == 0 {
 		return "0 B"
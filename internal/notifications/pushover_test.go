@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"grabarr/internal/buildinfo"
 	"grabarr/internal/config"
 	"grabarr/internal/models"
 
@@ -39,7 +40,7 @@ func createMockPushoverServer(t *testing.T, expectedToken, expectedUser string,
 
 		// Verify headers
 		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
-		assert.Equal(t, "grabarr/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, buildinfo.UserAgent(), r.Header.Get("User-Agent"))
 
 		// Parse request body
 		body, err := io.ReadAll(r.Body)
@@ -311,6 +312,183 @@ func TestNotifyJobCompleted_LowPriority(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestShouldNotify(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name     string
+		cfg      config.NotificationsConfig
+		job      *models.Job
+		event    string
+		expected bool
+	}{
+		{
+			name:     "default completion threshold met",
+			job:      &models.Job{Priority: 5},
+			event:    config.NotifyEventJobCompleted,
+			expected: true,
+		},
+		{
+			name:     "default completion threshold not met",
+			job:      &models.Job{Priority: 4},
+			event:    config.NotifyEventJobCompleted,
+			expected: false,
+		},
+		{
+			name:     "failures ignore priority threshold",
+			job:      &models.Job{Priority: 0},
+			event:    config.NotifyEventJobFailed,
+			expected: true,
+		},
+		{
+			name:     "configured min priority",
+			cfg:      config.NotificationsConfig{MinPriority: intPtr(0)},
+			job:      &models.Job{Priority: 0},
+			event:    config.NotifyEventJobCompleted,
+			expected: true,
+		},
+		{
+			name:     "below min size",
+			cfg:      config.NotificationsConfig{MinPriority: intPtr(0), MinSizeBytes: 1000},
+			job:      &models.Job{FileSize: 999},
+			event:    config.NotifyEventJobCompleted,
+			expected: false,
+		},
+		{
+			name:     "at min size",
+			cfg:      config.NotificationsConfig{MinPriority: intPtr(0), MinSizeBytes: 1000},
+			job:      &models.Job{FileSize: 1000},
+			event:    config.NotifyEventJobCompleted,
+			expected: true,
+		},
+		{
+			name:     "size falls back to transferred bytes",
+			cfg:      config.NotificationsConfig{MinPriority: intPtr(0), MinSizeBytes: 1000},
+			job:      &models.Job{TransferredBytes: 2000},
+			event:    config.NotifyEventJobCompleted,
+			expected: true,
+		},
+		{
+			name:     "failures ignore size threshold",
+			cfg:      config.NotificationsConfig{MinSizeBytes: 1000},
+			job:      &models.Job{FileSize: 10},
+			event:    config.NotifyEventJobFailed,
+			expected: true,
+		},
+		{
+			name:     "event not in notify_on",
+			cfg:      config.NotificationsConfig{NotifyOn: []string{config.NotifyEventJobCompleted}},
+			job:      &models.Job{Priority: 10},
+			event:    config.NotifyEventJobFailed,
+			expected: false,
+		},
+		{
+			name:     "event in notify_on",
+			cfg:      config.NotificationsConfig{NotifyOn: []string{config.NotifyEventJobFailed}},
+			job:      &models.Job{},
+			event:    config.NotifyEventJobFailed,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Notifications: tt.cfg}
+			notifier := NewPushoverNotifier(cfg)
+			assert.Equal(t, tt.expected, notifier.shouldNotify(tt.job, tt.event))
+		})
+	}
+}
+
+func TestNotifyJobFailed_FilteredByNotifyOn(t *testing.T) {
+	cfg := createTestConfig(true)
+	cfg.Notifications.NotifyOn = []string{config.NotifyEventJobCompleted}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("notification should not be sent")
+	}))
+	defer server.Close()
+
+	notifier := NewPushoverNotifier(cfg)
+	notifier.apiURL = server.URL
+
+	err := notifier.NotifyJobFailed(&models.Job{ID: 1, Name: "test-job"})
+	assert.NoError(t, err)
+}
+
+func TestNotifyJobFailed_DedupWindow(t *testing.T) {
+	cfg := createTestConfig(true)
+	cfg.Notifications.DedupWindow = 10 * time.Minute
+
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		json.NewEncoder(w).Encode(pushoverResponse{Status: 1})
+	}))
+	defer server.Close()
+
+	notifier := NewPushoverNotifier(cfg)
+	notifier.apiURL = server.URL
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	notifier.now = func() time.Time { return now }
+
+	job := &models.Job{ID: 1, Name: "test-job", Retries: 1, MaxRetries: 3}
+
+	require.NoError(t, notifier.NotifyJobFailed(job))
+	assert.Equal(t, 1, sent)
+
+	// Same job and event inside the window is suppressed
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, notifier.NotifyJobFailed(job))
+	assert.Equal(t, 1, sent)
+
+	// A different job is not affected
+	require.NoError(t, notifier.NotifyJobFailed(&models.Job{ID: 2, Name: "other-job", Retries: 1, MaxRetries: 3}))
+	assert.Equal(t, 2, sent)
+
+	// Once the window has passed the event is sent again
+	now = now.Add(10 * time.Minute)
+	require.NoError(t, notifier.NotifyJobFailed(job))
+	assert.Equal(t, 3, sent)
+}
+
+func TestNotifyJobCompleted_DedupWindowRetriesAfterSendFailure(t *testing.T) {
+	cfg := createTestConfig(true)
+	cfg.Notifications.DedupWindow = 10 * time.Minute
+	minPriority := 0
+	cfg.Notifications.MinPriority = &minPriority
+
+	fail := true
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(pushoverResponse{Status: 0, Errors: []string{"boom"}})
+			return
+		}
+		sent++
+		json.NewEncoder(w).Encode(pushoverResponse{Status: 1})
+	}))
+	defer server.Close()
+
+	notifier := NewPushoverNotifier(cfg)
+	notifier.apiURL = server.URL
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	notifier.now = func() time.Time { return now }
+
+	job := &models.Job{ID: 1, Name: "test-job"}
+
+	assert.Error(t, notifier.NotifyJobCompleted(job))
+
+	// A failed send does not count towards the window
+	fail = false
+	require.NoError(t, notifier.NotifyJobCompleted(job))
+	assert.Equal(t, 1, sent)
+
+	require.NoError(t, notifier.NotifyJobCompleted(job))
+	assert.Equal(t, 1, sent)
+}
+
 // NotifySystemAlert Tests
 
 func TestNotifySystemAlert_Success(t *testing.T) {
@@ -339,6 +517,49 @@ func TestNotifySystemAlert_Disabled(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTestNotification_Success(t *testing.T) {
+	cfg := createTestConfig(true)
+
+	mockServer := createMockPushoverServer(t, "test-token", "test-user", http.StatusOK, pushoverResponse{
+		Status:  1,
+		Request: "test-request-id",
+	})
+	defer mockServer.Close()
+
+	notifier := NewPushoverNotifier(cfg)
+	notifier.apiURL = mockServer.URL
+
+	assert.NoError(t, notifier.TestNotification())
+}
+
+func TestTestNotification_Disabled(t *testing.T) {
+	cfg := createTestConfig(false)
+	notifier := NewPushoverNotifier(cfg)
+
+	err := notifier.TestNotification()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disabled")
+}
+
+func TestTestNotification_APIError(t *testing.T) {
+	cfg := createTestConfig(true)
+
+	mockServer := createMockPushoverServer(t, "test-token", "test-user", http.StatusOK, pushoverResponse{
+		Status: 0,
+		Errors: []string{"application token is invalid"},
+	})
+	defer mockServer.Close()
+
+	notifier := NewPushoverNotifier(cfg)
+	notifier.apiURL = mockServer.URL
+
+	err := notifier.TestNotification()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "application token is invalid")
+}
+
 func TestNotifySystemAlert_Priorities(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -576,8 +797,8 @@ func TestBuildJobCompletedMessage(t *testing.T) {
 	cfg := createTestConfig(true)
 	notifier := NewPushoverNotifier(cfg)
 
-	startTime := time.Now().Add(-10 * time.Minute)
 	completedTime := time.Now()
+	startTime := completedTime.Add(-50 * time.Second)
 	job := &models.Job{
 		ID:          456,
 		Name:        "completed-job",
@@ -586,7 +807,8 @@ func TestBuildJobCompletedMessage(t *testing.T) {
 		CompletedAt: &completedTime,
 		Progress: models.JobProgress{
 			TotalBytes:    1024 * 1024 * 500, // 500 MB
-			TransferSpeed: 1024 * 1024 * 10,  // 10 MB/s
+			TransferSpeed: 1024 * 1024 * 25,  // last sample, not the average
+			FilesTotal:    3,
 		},
 		Metadata: models.JobMetadata{
 			Category: "tv",
@@ -598,11 +820,62 @@ func TestBuildJobCompletedMessage(t *testing.T) {
 	assert.Contains(t, message, "completed-job")
 	assert.Contains(t, message, "/remote/path/complete.mkv")
 	assert.Contains(t, message, "500.0 MB")
-	assert.Contains(t, message, "10.0 MB/s")
+	assert.Contains(t, message, "Duration: 50s")
+	assert.Contains(t, message, "Avg Speed: 10.0 MB/s")
+	assert.Contains(t, message, "Files: 3")
 	assert.Contains(t, message, "tv")
 	assert.Contains(t, message, "456")
 }
 
+func TestBuildJobCompletedMessage_NoTimes(t *testing.T) {
+	notifier := NewPushoverNotifier(createTestConfig(true))
+
+	var zero time.Time
+	completedTime := time.Now()
+	for _, job := range []*models.Job{
+		{ID: 1, Progress: models.JobProgress{TotalBytes: 1024, TransferSpeed: 512}},
+		{ID: 2, StartedAt: &zero, CompletedAt: &completedTime, Progress: models.JobProgress{TotalBytes: 1024, TransferSpeed: 512}},
+	} {
+		message := notifier.buildJobCompletedMessage(job)
+		assert.NotContains(t, message, "Duration")
+		assert.Contains(t, message, "Avg Speed: 512 B/s")
+	}
+}
+
+func TestBuildJobMessages_RedactSourceURL(t *testing.T) {
+	notifier := NewPushoverNotifier(createTestConfig(true))
+	job := &models.Job{ID: 1, Name: "movie", SourceURL: "https://user:pw@example.com/files/movie.mkv?token=abc"}
+
+	for _, message := range []string{notifier.buildJobCompletedMessage(job), notifier.buildJobFailedMessage(job)} {
+		assert.Contains(t, message, "Source URL: https://example.com/files/movie.mkv\n")
+		assert.NotContains(t, message, "token")
+		assert.NotContains(t, message, "pw@")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected string
+	}{
+		{name: "zero", duration: 0, expected: "0s"},
+		{name: "negative", duration: -time.Minute, expected: "0s"},
+		{name: "sub-second", duration: 400 * time.Millisecond, expected: "0s"},
+		{name: "seconds", duration: 42 * time.Second, expected: "42s"},
+		{name: "minutes", duration: 4*time.Minute + 5*time.Second, expected: "4m05s"},
+		{name: "hours", duration: time.Hour + 23*time.Minute + 40*time.Second, expected: "1h23m"},
+		{name: "rounds to the second", duration: 59*time.Second + 600*time.Millisecond, expected: "1m00s"},
+		{name: "days", duration: 27*time.Hour + 5*time.Minute, expected: "27h05m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatDuration(tt.duration))
+		})
+	}
+}
+
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
 		name     string
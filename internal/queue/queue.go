@@ -2,8 +2,11 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,9 +15,11 @@ import (
 	"grabarr/internal/archive"
 	"grabarr/internal/config"
 	"grabarr/internal/executor"
+	"grabarr/internal/hooks"
 	"grabarr/internal/interfaces"
 	"grabarr/internal/models"
 	"grabarr/internal/repository"
+	"grabarr/internal/rsync"
 )
 
 type queue struct {
@@ -28,25 +33,55 @@ type queue struct {
 	running         bool
 	activeJobs      map[int64]context.CancelFunc
 	jobQueue        chan *models.Job
+	queuedMu        sync.Mutex
+	queuedIDs       map[int64]struct{} // job IDs currently buffered in jobQueue
 	schedulerCtx    context.Context
 	schedulerCancel context.CancelFunc
+	jobsCtx         context.Context // parent of job contexts; outlives the scheduler so drain mode can finish them
 
 	// Resource management
 	gatekeeper interfaces.Gatekeeper
 
 	// Cleanup
 	lastCleanup time.Time
+
+	// Database maintenance
+	maintenanceMu sync.Mutex // serializes scheduled and manual maintenance
+	lastVacuum    time.Time
+
+	// Long-running job alerts
+	longRunningMu      sync.Mutex
+	longRunningAlerted map[int64]struct{} // running jobs already alerted on
+
+	// Job summary cache
+	summaryMu       sync.Mutex
+	summaryCache    *models.JobSummary
+	summaryCachedAt time.Time
+	now             func() time.Time
+
+	// removeAll is os.RemoveAll; overridable for cache eviction tests
+	removeAll func(path string) error
 }
 
+// longRunningCheckInterval is how often running jobs are compared against
+// jobs.notify_if_running_longer_than.
+const longRunningCheckInterval = time.Minute
+
 func New(repo *repository.Repository, config *config.Config, gatekeeper interfaces.Gatekeeper, notifier interfaces.Notifier) interfaces.JobQueue {
 	return &queue{
 		repo:        repo,
 		config:      config,
 		activeJobs:  make(map[int64]context.CancelFunc),
 		jobQueue:    make(chan *models.Job, 1000), // Buffered channel for job queue
+		queuedIDs:   make(map[int64]struct{}),
 		gatekeeper:  gatekeeper,
 		notifier:    notifier,
 		lastCleanup: time.Now(),
+		lastVacuum:  time.Now(),
+		now:         time.Now,
+		removeAll:   os.RemoveAll,
+
+		longRunningAlerted: make(map[int64]struct{}),
 	}
 }
 
@@ -70,6 +105,7 @@ func (q *queue) Start(ctx context.Context) error {
 
 	q.running = true
 	q.schedulerCtx, q.schedulerCancel = context.WithCancel(ctx)
+	q.jobsCtx = ctx
 
 	// Load existing queued/pending jobs from database
 	if err := q.loadExistingJobs(); err != nil {
@@ -82,10 +118,26 @@ func (q *queue) Start(ctx context.Context) error {
 	// Start cleanup goroutine
 	go q.cleanupRoutine()
 
+	// Start periodic WAL checkpoint and vacuum
+	go q.maintenanceRoutine()
+
+	// Start watchdog for pending jobs that fell out of the in-memory queue
+	go q.pendingWatchdog(q.config.WatchForChanges())
+
+	// Start watchdog alerting on jobs that run longer than expected
+	go q.longRunningWatchdog()
+
+	// Start cache eviction; it idles while eviction is disabled
+	go q.evictionRoutine(q.config.WatchForChanges())
+
 	slog.Info("job queue started")
 	return nil
 }
 
+// drainCancelGrace bounds how long Stop waits for jobs it cancelled after a
+// drain timed out.
+const drainCancelGrace = 5 * time.Second
+
 func (q *queue) Stop() error {
 	q.mu.Lock()
 
@@ -96,17 +148,46 @@ func (q *queue) Stop() error {
 
 	q.running = false
 
-	// Cancel scheduler
+	// Cancel scheduler; active jobs run under jobsCtx and are unaffected
 	if q.schedulerCancel != nil {
 		q.schedulerCancel()
 	}
+	q.mu.Unlock()
 
-	// Mark all active jobs as queued before cancelling them
+	timeout := q.config.GetServer().ShutdownTimeout
+
+	if q.config.GetServer().ShutdownMode == config.ShutdownModeDrain {
+		slog.Info("draining active jobs before shutdown", "timeout", timeout)
+		if q.waitForActiveJobs(timeout) {
+			slog.Info("all jobs finished, queue stopped")
+			return nil
+		}
+		slog.Warn("timeout draining jobs, re-queuing remaining jobs", "active_jobs", q.activeJobCount())
+		q.requeueActiveJobs()
+		q.waitForActiveJobs(drainCancelGrace)
+		return nil
+	}
+
+	q.requeueActiveJobs()
+
+	// Wait for jobs to finish or timeout
+	if q.waitForActiveJobs(timeout) {
+		slog.Info("all jobs finished, queue stopped")
+	} else {
+		slog.Warn("timeout waiting for jobs to finish", "active_jobs", q.activeJobCount())
+	}
+	return nil
+}
+
+// requeueActiveJobs marks all running jobs as queued so they restart on the
+// next startup, then cancels them.
+func (q *queue) requeueActiveJobs() {
+	q.mu.RLock()
 	interruptedJobIDs := make([]int64, 0, len(q.activeJobs))
 	for jobID := range q.activeJobs {
 		interruptedJobIDs = append(interruptedJobIDs, jobID)
 	}
-	q.mu.Unlock()
+	q.mu.RUnlock()
 
 	// Update job statuses outside the lock to avoid deadlock
 	for _, jobID := range interruptedJobIDs {
@@ -119,7 +200,7 @@ func (q *queue) Stop() error {
 		if job.Status == models.JobStatusRunning {
 			job.Status = models.JobStatusQueued
 			job.UpdatedAt = time.Now()
-			if err := q.repo.UpdateJob(job); err != nil {
+			if err := q.updateJob(job); err != nil {
 				slog.Error("failed to mark job as queued during shutdown", "job_id", jobID, "error", err)
 			} else {
 				slog.Info("marked interrupted job as queued", "job_id", jobID, "name", job.Name)
@@ -134,32 +215,33 @@ func (q *queue) Stop() error {
 		cancel()
 	}
 	q.mu.Unlock()
+}
 
-	// Wait for jobs to finish or timeout
-	timeout := time.After(q.config.GetServer().ShutdownTimeout)
+// waitForActiveJobs polls until no jobs are active, returning false if timeout
+// elapses first.
+func (q *queue) waitForActiveJobs(timeout time.Duration) bool {
+	deadline := time.After(timeout)
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
+		if q.activeJobCount() == 0 {
+			return true
+		}
 		select {
-		case <-timeout:
-			q.mu.RLock()
-			activeCount := len(q.activeJobs)
-			q.mu.RUnlock()
-			slog.Warn("timeout waiting for jobs to finish", "active_jobs", activeCount)
-			return nil
+		case <-deadline:
+			return false
 		case <-ticker.C:
-			q.mu.RLock()
-			activeCount := len(q.activeJobs)
-			q.mu.RUnlock()
-			if activeCount == 0 {
-				slog.Info("all jobs finished, queue stopped")
-				return nil
-			}
 		}
 	}
 }
 
+func (q *queue) activeJobCount() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.activeJobs)
+}
+
 func (q *queue) Enqueue(job *models.Job) error {
 	// Set defaults
 	if job.Status == "" {
@@ -168,6 +250,22 @@ func (q *queue) Enqueue(job *models.Job) error {
 	if job.MaxRetries == 0 {
 		job.MaxRetries = q.config.GetJobs().MaxRetries
 	}
+	if job.Priority == 0 {
+		job.Priority = q.config.GetJobs().DefaultPriority
+	}
+
+	if maxDepth := q.config.GetJobs().MaxQueueDepth; maxDepth > 0 && job.Status == models.JobStatusQueued {
+		waiting, err := q.repo.CountJobs(models.JobFilter{
+			Status: []models.JobStatus{models.JobStatusQueued, models.JobStatusPending},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to count waiting jobs: %w", err)
+		}
+		if waiting >= maxDepth {
+			slog.Warn("rejecting job, queue at capacity", "name", job.Name, "waiting", waiting, "max_queue_depth", maxDepth)
+			return fmt.Errorf("%w: %d jobs waiting (max %d)", interfaces.ErrQueueFull, waiting, maxDepth)
+		}
+	}
 
 	// Create job in database
 	if err := q.repo.CreateJob(job); err != nil {
@@ -185,17 +283,30 @@ func (q *queue) Enqueue(job *models.Job) error {
 
 		return fmt.Errorf(errMsg)
 	}
+	q.invalidateSummary()
 
-	// Add to in-memory queue
-	select {
-	case q.jobQueue <- job:
-		slog.Info("job enqueued", "job_id", job.ID, "name", job.Name)
+	message := ""
+	if job.Status != models.JobStatusQueued {
+		message = fmt.Sprintf("created as %s", job.Status)
+	}
+	q.recordAudit(job.ID, models.AuditEventCreated, message)
+
+	// Jobs created in a terminal state (e.g. skipped because the file is
+	// already present locally) are recorded but never scheduled.
+	if job.Status != models.JobStatusQueued {
+		slog.Info("job recorded without scheduling", "job_id", job.ID, "name", job.Name, "status", job.Status)
 		return nil
-	default:
+	}
+
+	// Add to in-memory queue
+	if !q.pushJob(job) {
 		// Queue is full, job is still in database but not in memory queue
 		slog.Warn("job queue full, job saved to database", "job_id", job.ID)
 		return nil
 	}
+
+	slog.Info("job enqueued", "job_id", job.ID, "name", job.Name)
+	return nil
 }
 
 func (q *queue) GetJob(id int64) (*models.Job, error) {
@@ -210,6 +321,32 @@ func (q *queue) CountJobs(filter models.JobFilter) (int, error) {
 	return q.repo.CountJobs(filter)
 }
 
+func (q *queue) GetJobsByIDs(ids []int64) ([]*models.Job, error) {
+	return q.repo.GetJobsByIDs(ids)
+}
+
+func (q *queue) GetJobContext(ctx context.Context, id int64) (*models.Job, error) {
+	return q.repo.GetJobContext(ctx, id)
+}
+
+func (q *queue) GetJobsContext(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	return q.repo.GetJobsContext(ctx, filter)
+}
+
+func (q *queue) CountJobsContext(ctx context.Context, filter models.JobFilter) (int, error) {
+	return q.repo.CountJobsContext(ctx, filter)
+}
+
+// GetJobByHash returns the most recent job for a qBittorrent torrent hash.
+func (q *queue) GetJobByHash(hash string) (*models.Job, error) {
+	return q.repo.GetJobByHash(hash)
+}
+
+// GetActiveJobByRemotePath returns the most recent unfinished job for a remote path.
+func (q *queue) GetActiveJobByRemotePath(remotePath string) (*models.Job, error) {
+	return q.repo.GetActiveJobByRemotePath(remotePath)
+}
+
 func (q *queue) CancelJob(id int64) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -228,9 +365,10 @@ func (q *queue) CancelJob(id int64) error {
 
 	if !job.IsCompleted() {
 		job.MarkCancelled()
-		if err := q.repo.UpdateJob(job); err != nil {
+		if err := q.updateJob(job); err != nil {
 			return fmt.Errorf("failed to update job status: %w", err)
 		}
+		q.recordAudit(id, models.AuditEventCancelled, "")
 	}
 
 	slog.Info("job cancelled", "job_id", id)
@@ -247,15 +385,48 @@ func (q *queue) DeleteJob(id int64) error {
 		delete(q.activeJobs, id)
 	}
 
-	// Delete job from database
+	// Deleted jobs are kept until cleanup purges them, so make sure an
+	// unfinished one can't be picked up again
+	if job, err := q.repo.GetJob(id); err == nil && !job.IsCompleted() {
+		job.MarkCancelled()
+		if err := q.updateJob(job); err != nil {
+			return fmt.Errorf("failed to cancel job: %w", err)
+		}
+		q.recordAudit(id, models.AuditEventCancelled, "job deleted")
+	}
+
 	if err := q.repo.DeleteJob(id); err != nil {
 		return fmt.Errorf("failed to delete job: %w", err)
 	}
+	q.invalidateSummary()
 
 	slog.Info("job deleted", "job_id", id)
 	return nil
 }
 
+// RestoreJob undoes DeleteJob. A job that was unfinished when deleted comes
+// back cancelled.
+func (q *queue) RestoreJob(id int64) error {
+	if err := q.repo.RestoreJob(id); err != nil {
+		return err
+	}
+	q.invalidateSummary()
+
+	slog.Info("job restored", "job_id", id)
+	return nil
+}
+
+func (q *queue) PurgeJobs(statuses []models.JobStatus, before time.Time) (int, error) {
+	count, err := q.repo.PurgeJobs(statuses, before)
+	if err != nil {
+		return 0, err
+	}
+	q.invalidateSummary()
+
+	slog.Info("jobs purged", "count", count, "statuses", statuses, "before", before)
+	return count, nil
+}
+
 func (q *queue) RetryJob(id int64) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -277,25 +448,122 @@ func (q *queue) RetryJob(id int64) error {
 	job.Retries = 0 // Reset retry counter for manual retry
 
 	// Update job in database
-	if err := q.repo.UpdateJob(job); err != nil {
+	if err := q.updateJob(job); err != nil {
 		return fmt.Errorf("failed to update job status: %w", err)
 	}
 
+	q.recordAudit(id, models.AuditEventRetried, "manual retry")
+
 	// Re-enqueue the job
-	select {
-	case q.jobQueue <- job:
-		slog.Info("job retried", "job_id", id, "retries", job.Retries)
-	default:
+	if !q.pushJob(job) {
 		return fmt.Errorf("job queue is full, cannot retry job")
 	}
 
+	slog.Info("job retried", "job_id", id, "retries", job.Retries)
+	return nil
+}
+
+// ForceSchedule starts a queued or pending job now, skipping the gatekeeper.
+// jobs.max_concurrent still applies.
+func (q *queue) ForceSchedule(id int64) error {
+	q.mu.RLock()
+	running := q.running
+	q.mu.RUnlock()
+	if !running {
+		return fmt.Errorf("job queue is not running")
+	}
+
+	job, err := q.repo.GetJob(id)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if job.Status != models.JobStatusQueued && job.Status != models.JobStatusPending {
+		return fmt.Errorf("job is not queued or pending (current status: %s)", job.Status)
+	}
+
+	if !q.canScheduleNewJob() {
+		return fmt.Errorf("%w (max %d)", interfaces.ErrMaxConcurrent, q.config.GetJobs().MaxConcurrent)
+	}
+
+	slog.Warn("force-starting job, bypassing gatekeeper", "job_id", job.ID, "name", job.Name, "status", job.Status)
+	q.scheduleJob(job)
 	return nil
 }
 
+// GetSummary returns job counts by status. Results are cached briefly since
+// status and metrics endpoints poll it; queue-side job changes invalidate it.
 func (q *queue) GetSummary() (*models.JobSummary, error) {
-	return q.repo.GetJobSummary()
+	ttl := q.config.GetJobs().SummaryCacheTTL
+
+	q.summaryMu.Lock()
+	defer q.summaryMu.Unlock()
+
+	if q.summaryCache != nil && q.now().Sub(q.summaryCachedAt) < ttl {
+		summary := *q.summaryCache
+		return &summary, nil
+	}
+
+	summary, err := q.repo.GetJobSummary()
+	if err != nil {
+		return nil, err
+	}
+
+	cached := *summary
+	q.summaryCache = &cached
+	q.summaryCachedAt = q.now()
+	return summary, nil
 }
 
+// invalidateSummary drops the cached job summary so the next call re-reads it.
+func (q *queue) invalidateSummary() {
+	q.summaryMu.Lock()
+	defer q.summaryMu.Unlock()
+	q.summaryCache = nil
+}
+
+// updateJob persists a job and invalidates the cached summary, since any
+// queue-side update may be a status transition.
+func (q *queue) updateJob(job *models.Job) error {
+	err := q.repo.UpdateJob(job)
+	q.invalidateSummary()
+	return err
+}
+
+func (q *queue) GetLifetimeStats() (*models.LifetimeStats, error) {
+	return q.repo.GetLifetimeStats()
+}
+
+func (q *queue) GetJobAttempts(jobID int64) ([]*models.JobAttempt, error) {
+	return q.repo.GetJobAttempts(jobID)
+}
+
+func (q *queue) GetJobAudit(jobID int64) ([]*models.AuditEntry, error) {
+	return q.repo.GetAudit(jobID)
+}
+
+// recordAudit appends a transition to the job's audit log. Failures are
+// logged rather than returned so auditing never blocks a state change.
+func (q *queue) recordAudit(jobID int64, event models.AuditEvent, message string) {
+	entry := &models.AuditEntry{JobID: jobID, Event: event, Message: message}
+	if err := q.repo.AppendAudit(entry); err != nil {
+		slog.Error("failed to append audit entry", "job_id", jobID, "event", event, "error", err)
+	}
+}
+
+// GetJobStatsByCategory returns job counts and transferred bytes per category.
+func (q *queue) GetJobStatsByCategory() ([]*models.CategoryStats, error) {
+	return q.repo.GetJobStatsByCategory()
+}
+
+func (q *queue) GetTransferStats(since time.Time) (*models.TransferStats, error) {
+	return q.repo.GetTransferStats(since)
+}
+
+// interruptedJobMessage is the error recorded on jobs failed by the "fail"
+// recovery mode.
+const interruptedJobMessage = "interrupted: grabarr stopped while the job was running"
+
 func (q *queue) loadExistingJobs() error {
 	// Load jobs that need to be recovered: queued, pending, and running
 	jobs, err := q.repo.GetJobs(models.JobFilter{
@@ -307,26 +575,45 @@ func (q *queue) loadExistingJobs() error {
 		return err
 	}
 
+	recoveryMode := q.config.GetJobs().RecoveryMode
+	loaded := 0
 	for _, job := range jobs {
+		// Running jobs were interrupted mid-transfer; recovery mode decides
+		// whether they run again
+		if job.Status == models.JobStatusRunning {
+			switch recoveryMode {
+			case config.RecoveryModeFail:
+				job.MarkFailed(interruptedJobMessage)
+				if err := q.updateJob(job); err != nil {
+					slog.Error("failed to mark interrupted job as failed", "job_id", job.ID, "error", err)
+				} else {
+					slog.Warn("marked interrupted job as failed", "job_id", job.ID, "name", job.Name)
+				}
+				continue
+			case config.RecoveryModeLeave:
+				slog.Warn("leaving interrupted job for manual review", "job_id", job.ID, "name", job.Name)
+				continue
+			}
+		}
+
 		// Reset pending and running jobs to queued for recovery
 		if job.Status == models.JobStatusPending || job.Status == models.JobStatusRunning {
 			oldStatus := job.Status
 			job.Status = models.JobStatusQueued
-			if err := q.repo.UpdateJob(job); err != nil {
+			if err := q.updateJob(job); err != nil {
 				slog.Error("failed to reset job to queued", "job_id", job.ID, "old_status", oldStatus, "error", err)
 				continue
 			}
 			slog.Info("recovered interrupted job", "job_id", job.ID, "name", job.Name, "previous_status", oldStatus)
 		}
 
-		select {
-		case q.jobQueue <- job:
-		default:
+		if !q.pushJob(job) {
 			slog.Warn("job queue full during startup, some jobs may be delayed", "job_id", job.ID)
 		}
+		loaded++
 	}
 
-	slog.Info("loaded existing jobs", "count", len(jobs))
+	slog.Info("loaded existing jobs", "count", loaded)
 	return nil
 }
 
@@ -341,19 +628,22 @@ func (q *queue) scheduler() {
 		case <-ticker.C:
 			q.processQueue()
 		case job := <-q.jobQueue:
+			q.markDequeued(job.ID)
+			if !q.stillWaiting(job) {
+				continue
+			}
+
 			// Process job immediately if resources allow
 			if q.canScheduleNewJob() && q.canStartJobNow(job) {
 				q.scheduleJob(job)
 			} else {
 				// Put job back in queue for later
 				job.Status = models.JobStatusPending
-				if err := q.repo.UpdateJob(job); err != nil {
+				if err := q.updateJob(job); err != nil {
 					slog.Error("failed to update job status to pending", "job_id", job.ID, "error", err)
 				}
 
-				select {
-				case q.jobQueue <- job:
-				default:
+				if !q.pushJob(job) {
 					slog.Error("failed to re-queue job", "job_id", job.ID)
 				}
 			}
@@ -370,15 +660,18 @@ func (q *queue) processQueue() {
 	for q.canScheduleNewJob() {
 		select {
 		case job := <-q.jobQueue:
+			q.markDequeued(job.ID)
+			if !q.stillWaiting(job) {
+				continue
+			}
+
 			if q.canStartJobNow(job) {
 				q.scheduleJob(job)
 			} else {
 				// Put back in queue
-				select {
-				case q.jobQueue <- job:
-				default:
+				if !q.pushJob(job) {
 					job.Status = models.JobStatusPending
-					q.repo.UpdateJob(job)
+					q.updateJob(job)
 				}
 				return
 			}
@@ -412,6 +705,325 @@ func (q *queue) processQueue() {
 	}
 }
 
+// pushJob adds a job to the in-memory queue. Jobs already buffered are not added
+// twice. Returns false if the queue is full.
+func (q *queue) pushJob(job *models.Job) bool {
+	q.queuedMu.Lock()
+	defer q.queuedMu.Unlock()
+
+	if _, queued := q.queuedIDs[job.ID]; queued {
+		return true
+	}
+
+	select {
+	case q.jobQueue <- job:
+		q.queuedIDs[job.ID] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+// markDequeued records that a job has been taken off the in-memory queue.
+func (q *queue) markDequeued(jobID int64) {
+	q.queuedMu.Lock()
+	defer q.queuedMu.Unlock()
+	delete(q.queuedIDs, jobID)
+}
+
+// stillWaiting reports whether a job taken from the in-memory queue is still
+// queued or pending in the database. A buffered copy goes stale when the job
+// is force-started, cancelled or deleted while it waits.
+func (q *queue) stillWaiting(job *models.Job) bool {
+	current, err := q.repo.GetJob(job.ID)
+	if err != nil {
+		// Let the scheduler decide as before; executeJob reports database errors
+		return true
+	}
+	if current.DeletedAt != nil || (current.Status != models.JobStatusQueued && current.Status != models.JobStatusPending) {
+		slog.Debug("skipping stale queued job", "job_id", job.ID, "status", current.Status)
+		return false
+	}
+	return true
+}
+
+// pendingWatchdog periodically re-queues pending jobs that are neither running nor
+// buffered in the in-memory queue, e.g. after a failed re-queue in the scheduler.
+func (q *queue) pendingWatchdog(configChanges <-chan struct{}) {
+	interval := q.pendingWatchdogInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.schedulerCtx.Done():
+			return
+		case <-ticker.C:
+			q.recoverStuckPendingJobs()
+		case <-configChanges:
+			if next := q.pendingWatchdogInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (q *queue) pendingWatchdogInterval() time.Duration {
+	if interval := q.config.GetJobs().PendingWatchdogInterval; interval > 0 {
+		return interval
+	}
+	return 1 * time.Minute
+}
+
+func (q *queue) longRunningWatchdog() {
+	ticker := time.NewTicker(longRunningCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.schedulerCtx.Done():
+			return
+		case <-ticker.C:
+			q.alertLongRunningJobs()
+		}
+	}
+}
+
+// evictionRoutine runs evictCache every cache_disk check interval while
+// eviction is enabled. Config changes can turn eviction on or off and move the
+// interval without a restart.
+func (q *queue) evictionRoutine(configChanges <-chan struct{}) {
+	interval := q.config.GetGatekeeper().CacheDisk.CheckInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.schedulerCtx.Done():
+			return
+		case <-ticker.C:
+			if q.config.GetGatekeeper().CacheDisk.Eviction.Enabled {
+				q.evictCache()
+			}
+		case <-configChanges:
+			if next := q.config.GetGatekeeper().CacheDisk.CheckInterval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// evictCache deletes the local files of the oldest completed downloads, one at
+// a time, until cache usage drops below the eviction threshold, and returns how
+// many were evicted. Downloads completed within eviction.min_age are never
+// touched, however full the disk is.
+func (q *queue) evictCache() int {
+	diskCfg := q.config.GetGatekeeper().CacheDisk
+	threshold := float64(diskCfg.Eviction.ThresholdPercent)
+	if threshold <= 0 {
+		threshold = float64(diskCfg.MaxUsagePercent)
+	}
+	if threshold <= 0 {
+		return 0
+	}
+	minAge := diskCfg.Eviction.MinAge
+
+	usage := q.gatekeeper.GetResourceStatus().CacheUsagePercent
+	if usage < threshold {
+		return 0
+	}
+
+	ids, err := q.repo.GetEvictionCandidates(q.now().Add(-minAge))
+	if err != nil {
+		slog.Error("cache eviction: failed to load candidates", "error", err)
+		return 0
+	}
+
+	evicted := 0
+	for _, id := range ids {
+		if usage < threshold {
+			break
+		}
+
+		job, err := q.repo.GetJob(id)
+		if err != nil {
+			slog.Error("cache eviction: failed to load job", "job_id", id, "error", err)
+			continue
+		}
+		path, ok := evictionPath(job)
+		if !ok {
+			slog.Warn("cache eviction: skipping job without a single local path",
+				"job_id", id, "remote_path", job.RemotePath, "local_path", job.LocalPath)
+			continue
+		}
+		newerID, err := q.newerDownloadOf(job, path)
+		if err != nil {
+			slog.Error("cache eviction: failed to check for newer downloads", "job_id", id, "error", err)
+			continue
+		}
+		if newerID != 0 {
+			slog.Info("cache eviction: skipping job whose files a newer job downloaded again",
+				"job_id", id, "newer_job_id", newerID, "path", path)
+			continue
+		}
+
+		if err := q.removeAll(path); err != nil {
+			slog.Error("cache eviction: failed to remove local files", "job_id", id, "path", path, "error", err)
+			continue
+		}
+		if err := q.repo.MarkJobEvicted(id); err != nil {
+			slog.Error("cache eviction: failed to mark job evicted", "job_id", id, "error", err)
+		}
+		q.recordAudit(id, models.AuditEventEvicted, fmt.Sprintf("removed %s at %.1f%% cache usage", path, usage))
+		slog.Info("evicted completed download from cache", "job_id", id, "path", path, "cache_percent", usage)
+		evicted++
+
+		usage = q.gatekeeper.RefreshNow().CacheUsagePercent
+	}
+
+	if usage >= threshold {
+		slog.Warn("cache usage still above eviction threshold",
+			"cache_percent", usage, "threshold_percent", threshold, "evicted", evicted)
+	}
+	return evicted
+}
+
+// newerDownloadOf returns the ID of a later completed, non-evicted job that
+// wrote path, or 0 if there is none. Evicting job would delete that job's files,
+// however recent it is.
+func (q *queue) newerDownloadOf(job *models.Job, path string) (int64, error) {
+	newer, err := q.repo.GetNewerCompletedJobs(job.LocalPath, *job.CompletedAt, job.ID)
+	if err != nil {
+		return 0, err
+	}
+	for _, other := range newer {
+		if otherPath, ok := evictionPath(other); ok && otherPath == path {
+			return other.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// evictionPath returns the file or directory a completed download wrote. rsync
+// copies the remote path's last element into LocalPath, except for a remote
+// path ending in a slash, whose contents are mixed into LocalPath itself and
+// so can't be removed on their own. copyurl jobs write the URL's file name.
+func evictionPath(job *models.Job) (string, bool) {
+	if job.IsCopyURLJob() {
+		name, err := models.SourceURLFileName(job.SourceURL)
+		if err != nil || !filepath.IsAbs(job.LocalPath) {
+			return "", false
+		}
+		return filepath.Join(job.LocalPath, name), true
+	}
+
+	if !filepath.IsAbs(job.LocalPath) || strings.HasSuffix(job.RemotePath, "/") {
+		return "", false
+	}
+	name := filepath.Base(job.RemotePath)
+	if name == "." || name == ".." || name == "/" {
+		return "", false
+	}
+	return filepath.Join(job.LocalPath, name), true
+}
+
+// alertLongRunningJobs sends a system alert for each running job that has
+// exceeded jobs.notify_if_running_longer_than and returns how many were sent.
+// Each job is alerted on once per run; jobs that stop running are forgotten.
+func (q *queue) alertLongRunningJobs() int {
+	threshold := q.config.GetJobs().NotifyIfRunningLongerThan
+	if threshold <= 0 || q.notifier == nil || !q.notifier.IsEnabled() {
+		return 0
+	}
+
+	jobs, err := q.repo.GetJobs(models.JobFilter{Status: []models.JobStatus{models.JobStatusRunning}})
+	if err != nil {
+		slog.Error("long-running check: failed to load running jobs", "error", err)
+		return 0
+	}
+
+	q.longRunningMu.Lock()
+	defer q.longRunningMu.Unlock()
+
+	running := make(map[int64]struct{}, len(jobs))
+	alerted := 0
+	for _, job := range jobs {
+		running[job.ID] = struct{}{}
+		if job.StartedAt == nil {
+			continue
+		}
+		if _, done := q.longRunningAlerted[job.ID]; done {
+			continue
+		}
+
+		elapsed := q.now().Sub(*job.StartedAt)
+		if elapsed <= threshold {
+			continue
+		}
+
+		slog.Warn("job running longer than expected", "job_id", job.ID, "name", job.Name, "elapsed", elapsed, "threshold", threshold)
+		if err := q.notifier.NotifySystemAlert(
+			"Long-Running Job",
+			fmt.Sprintf("Job '%s' has been running for %s (threshold %s).", job.Name, elapsed.Round(time.Minute), threshold),
+			0, // Normal priority
+		); err != nil {
+			slog.Error("failed to send long-running job alert", "job_id", job.ID, "error", err)
+			continue
+		}
+		q.longRunningAlerted[job.ID] = struct{}{}
+		alerted++
+	}
+
+	for id := range q.longRunningAlerted {
+		if _, ok := running[id]; !ok {
+			delete(q.longRunningAlerted, id)
+		}
+	}
+
+	return alerted
+}
+
+// recoverStuckPendingJobs re-injects dropped pending jobs and returns how many were recovered.
+func (q *queue) recoverStuckPendingJobs() int {
+	jobs, err := q.repo.GetJobs(models.JobFilter{
+		Status:    []models.JobStatus{models.JobStatusPending},
+		SortBy:    "priority",
+		SortOrder: "DESC",
+	})
+	if err != nil {
+		slog.Error("pending watchdog: failed to load pending jobs", "error", err)
+		return 0
+	}
+
+	recovered := 0
+	for _, job := range jobs {
+		q.mu.RLock()
+		_, active := q.activeJobs[job.ID]
+		q.mu.RUnlock()
+		if active {
+			continue
+		}
+
+		q.queuedMu.Lock()
+		_, queued := q.queuedIDs[job.ID]
+		q.queuedMu.Unlock()
+		if queued {
+			continue
+		}
+
+		if !q.pushJob(job) {
+			slog.Warn("pending watchdog: job queue full", "job_id", job.ID)
+			break
+		}
+		recovered++
+		slog.Info("pending watchdog: re-queued stuck job", "job_id", job.ID, "name", job.Name)
+	}
+
+	return recovered
+}
+
 // canStartJobNow checks with gatekeeper if a job can start now
 func (q *queue) canStartJobNow(job *models.Job) bool {
 	decision := q.gatekeeper.CanStartJob(job.FileSize)
@@ -437,8 +1049,21 @@ func (q *queue) scheduleJob(job *models.Job) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	// Create context for this job
-	ctx, cancel := context.WithCancel(q.schedulerCtx)
+	// A job can reach the scheduler twice (e.g. re-queued by the pending watchdog);
+	// never run two copies at once.
+	if _, exists := q.activeJobs[job.ID]; exists {
+		slog.Debug("job already active, skipping duplicate", "job_id", job.ID)
+		return
+	}
+
+	// Create context for this job, bounded by the max job duration if set
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if maxDuration := q.config.GetJobs().MaxJobDuration; maxDuration > 0 {
+		ctx, cancel = context.WithTimeout(q.jobsCtx, maxDuration)
+	} else {
+		ctx, cancel = context.WithCancel(q.jobsCtx)
+	}
 	q.activeJobs[job.ID] = cancel
 
 	// Start job execution in goroutine
@@ -458,10 +1083,11 @@ func (q *queue) scheduleJob(job *models.Job) {
 func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 	// Mark job as started
 	job.MarkStarted()
-	if err := q.repo.UpdateJob(job); err != nil {
+	if err := q.updateJob(job); err != nil {
 		slog.Error("failed to mark job as started", "job_id", job.ID, "error", err)
 		return
 	}
+	q.recordAudit(job.ID, models.AuditEventStarted, fmt.Sprintf("attempt %d", job.Retries+1))
 
 	// Create job attempt record
 	attempt := &models.JobAttempt{
@@ -477,6 +1103,13 @@ func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 	// Execute the job
 	err := q.executor.Execute(ctx, job)
 
+	// A deadline here can only come from MaxJobDuration; report it clearly
+	// instead of as a bare context error.
+	timedOut := err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if timedOut {
+		err = fmt.Errorf("job exceeded max duration of %s: %w", q.config.GetJobs().MaxJobDuration, err)
+	}
+
 	// Update attempt record
 	now := time.Now()
 	attempt.EndedAt = &now
@@ -486,13 +1119,19 @@ func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 
 		attempt.Status = models.JobStatusFailed
 		attempt.ErrorMessage = err.Error()
+		job.FailureCategory = executor.ClassifyFailure(err)
 
-		if executor.IsPermanent(err) {
+		// Timeouts are retried up to MaxRetries rather than indefinitely, so a
+		// transfer that keeps stalling can't hold a slot forever. Categories
+		// configured as non-retryable fail without using up a retry.
+		retryable := q.config.GetJobs().IsRetryableFailure(job.FailureCategory)
+		if executor.IsPermanent(err) || !retryable || (timedOut && job.Retries >= job.MaxRetries) {
 			slog.Warn("job failed permanently, not retrying", "job_id", job.ID, "error", err)
 			job.MarkFailed(err.Error())
-			if updateErr := q.repo.UpdateJob(job); updateErr != nil {
+			if updateErr := q.updateJob(job); updateErr != nil {
 				slog.Error("failed to mark job as failed", "job_id", job.ID, "error", updateErr)
 			}
+			q.recordAudit(job.ID, models.AuditEventFailed, err.Error())
 			if q.notifier != nil && q.notifier.IsEnabled() {
 				if notifyErr := q.notifier.NotifyJobFailed(job); notifyErr != nil {
 					slog.Error("failed to send job failure notification", "job_id", job.ID, "error", notifyErr)
@@ -501,9 +1140,10 @@ func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 		} else {
 			// Retryable — retry indefinitely
 			job.IncrementRetry()
-			if updateErr := q.repo.UpdateJob(job); updateErr != nil {
+			if updateErr := q.updateJob(job); updateErr != nil {
 				slog.Error("failed to update job for retry", "job_id", job.ID, "error", updateErr)
 			}
+			q.recordAudit(job.ID, models.AuditEventRetried, err.Error())
 			slog.Info("job queued for retry (retryable error)", "job_id", job.ID, "attempt", job.Retries, "error", err)
 		}
 	} else {
@@ -512,8 +1152,18 @@ func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 		attempt.Status = models.JobStatusCompleted
 		job.MarkCompleted()
 
-		if err := q.repo.UpdateJob(job); err != nil {
+		if err := q.updateJob(job); err != nil {
 			slog.Error("failed to mark job as completed", "job_id", job.ID, "error", err)
+		} else {
+			q.recordAudit(job.ID, models.AuditEventCompleted, "")
+			if err := q.repo.RecordJobCompletion(job.TransferredBytes); err != nil {
+				slog.Error("failed to record lifetime stats", "job_id", job.ID, "error", err)
+			}
+			if q.notifier != nil && q.notifier.IsEnabled() {
+				if notifyErr := q.notifier.NotifyJobCompleted(job); notifyErr != nil {
+					slog.Error("failed to send job completion notification", "job_id", job.ID, "error", notifyErr)
+				}
+			}
 		}
 
 		// Check if this completed job completes an archive group
@@ -522,12 +1172,73 @@ func (q *queue) executeJob(ctx context.Context, job *models.Job) {
 		}
 	}
 
+	attempt.LogData = attemptLog(job, err)
+	if err == nil {
+		attempt.LogData += q.runCompletionHooks(job)
+	}
+
 	// Update attempt record
 	if err := q.repo.UpdateJobAttempt(attempt); err != nil {
 		slog.Error("failed to update job attempt", "job_id", job.ID, "error", err)
 	}
 }
 
+// runCompletionHooks runs the hooks.on_job_completed commands for job and
+// returns their output for the attempt log.
+func (q *queue) runCompletionHooks(job *models.Job) string {
+	cfg := q.config.GetHooks()
+	if len(cfg.OnJobCompleted) == 0 {
+		return ""
+	}
+	results := hooks.Run(q.jobsCtx, cfg.OnJobCompleted, job, cfg.Timeout)
+	for _, r := range results {
+		if r.Err != nil {
+			slog.Warn("completion hook failed", "job_id", job.ID, "command", r.Command, "error", r.Err, "output", r.Output)
+		} else {
+			slog.Info("completion hook ran", "job_id", job.ID, "command", r.Command, "duration", r.Duration)
+		}
+	}
+	return hooks.FormatLog(results)
+}
+
+// maxAttemptStderr caps how much transfer stderr is kept in an attempt's log.
+const maxAttemptStderr = 4096
+
+// attemptLog summarizes how an execution attempt ended for the job_attempts log.
+func attemptLog(job *models.Job, err error) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "transferred: %d/%d bytes (%.1f%%)\n",
+		job.Progress.TransferredBytes, job.FileSize, job.Progress.Percentage)
+	if job.Progress.TransferSpeed > 0 {
+		fmt.Fprintf(&b, "last speed: %d B/s\n", job.Progress.TransferSpeed)
+	}
+
+	if err == nil {
+		b.WriteString("result: completed\n")
+		return b.String()
+	}
+
+	if executor.IsPermanent(err) {
+		b.WriteString("result: failed (permanent)\n")
+	} else {
+		b.WriteString("result: failed (retryable)\n")
+	}
+	fmt.Fprintf(&b, "error: %s\n", err.Error())
+
+	var transferErr *rsync.TransferError
+	if errors.As(err, &transferErr) {
+		if stderr := strings.TrimSpace(transferErr.Stderr); stderr != "" {
+			if len(stderr) > maxAttemptStderr {
+				stderr = "..." + stderr[len(stderr)-maxAttemptStderr:]
+			}
+			fmt.Fprintf(&b, "stderr:\n%s\n", stderr)
+		}
+	}
+
+	return b.String()
+}
+
 // checkArchiveGroupComplete checks if all download jobs in an archive group have
 // completed, and if so, creates an extraction job for the group.
 func (q *queue) checkArchiveGroupComplete(group string, completedJob *models.Job) {
@@ -632,18 +1343,99 @@ func (q *queue) performCleanup() {
 	completedBefore := now.Add(-cfg.CleanupCompletedAfter)
 	failedBefore := now.Add(-cfg.CleanupFailedAfter)
 
-	count, err := q.repo.CleanupOldJobs(completedBefore, failedBefore)
+	count, err := q.repo.CleanupOldJobs(completedBefore, failedBefore, cfg.CleanupExcludeCategories)
 	if err != nil {
 		slog.Error("failed to cleanup old jobs", "error", err)
 		return
 	}
 
 	if count > 0 {
+		q.invalidateSummary()
 		slog.Info("cleaned up old jobs", "count", count)
 	}
 
+	if purged, err := q.repo.PurgeDeletedJobs(now.Add(-cfg.DeletedJobRetention)); err != nil {
+		slog.Error("failed to purge deleted jobs", "error", err)
+	} else if purged > 0 {
+		slog.Info("purged deleted jobs", "count", purged)
+	}
+
 	// Update last cleanup time
 	if err := q.repo.SetConfig("last_cleanup", now.Format(time.RFC3339)); err != nil {
 		slog.Error("failed to update last cleanup time", "error", err)
 	}
 }
+
+func (q *queue) maintenanceRoutine() {
+	ticker := time.NewTicker(q.config.GetDatabase().MaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.schedulerCtx.Done():
+			return
+		case <-ticker.C:
+			q.performMaintenance()
+		}
+	}
+}
+
+// performMaintenance checkpoints the database WAL and, once every
+// database.vacuum_interval, vacuums the database. VACUUM holds an exclusive
+// lock for as long as it runs, so it is deferred to a later run while any
+// job is active. Returns nil if maintenance failed.
+func (q *queue) performMaintenance() *models.MaintenanceResult {
+	q.maintenanceMu.Lock()
+	defer q.maintenanceMu.Unlock()
+
+	vacuum := q.now().Sub(q.lastVacuum) >= q.config.GetDatabase().VacuumInterval
+	if vacuum {
+		if active := q.activeJobCount(); active > 0 {
+			slog.Info("deferring database vacuum while jobs are active", "active_jobs", active)
+			vacuum = false
+		}
+	}
+
+	result, err := q.maintain(vacuum)
+	if err != nil {
+		slog.Error("database maintenance failed", "vacuum", vacuum, "error", err)
+		return nil
+	}
+	return result
+}
+
+// Vacuum checkpoints the WAL and vacuums the database immediately, regardless
+// of database.vacuum_interval. It refuses to run while any job is active.
+func (q *queue) Vacuum() (*models.MaintenanceResult, error) {
+	q.maintenanceMu.Lock()
+	defer q.maintenanceMu.Unlock()
+
+	if q.activeJobCount() > 0 {
+		return nil, interfaces.ErrJobsActive
+	}
+	return q.maintain(true)
+}
+
+// Ping checks that the job database is reachable.
+func (q *queue) Ping(ctx context.Context) error {
+	return q.repo.Ping(ctx)
+}
+
+// maintain runs repository maintenance and records when the database was
+// last vacuumed. Callers must hold maintenanceMu.
+func (q *queue) maintain(vacuum bool) (*models.MaintenanceResult, error) {
+	result, err := q.repo.Maintain(vacuum)
+	if err != nil {
+		return nil, err
+	}
+	if vacuum {
+		q.lastVacuum = q.now()
+	}
+
+	slog.Info("database maintenance complete",
+		"checkpointed_frames", result.CheckpointedFrames,
+		"vacuumed", result.Vacuumed,
+		"size_bytes", result.SizeAfter,
+		"reclaimed_bytes", result.ReclaimedBytes)
+	return result, nil
+}
@@ -3,6 +3,9 @@ package queue
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +14,8 @@ import (
 	"grabarr/internal/interfaces"
 	"grabarr/internal/mocks"
 	"grabarr/internal/models"
+	"grabarr/internal/repository"
+	"grabarr/internal/rsync"
 	"grabarr/internal/testutil"
 
 	"github.com/stretchr/testify/assert"
@@ -70,6 +75,7 @@ func TestStart_Success(t *testing.T) {
 	mockChecker := mocks.NewMockGatekeeper(t)
 	mockExecutor := mocks.NewMockJobExecutor(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
@@ -98,6 +104,7 @@ func TestStart_AlreadyRunning(t *testing.T) {
 	mockChecker := mocks.NewMockGatekeeper(t)
 	mockExecutor := mocks.NewMockJobExecutor(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
@@ -117,6 +124,7 @@ func TestStart_NoExecutor(t *testing.T) {
 	cfg := &config.Config{}
 	mockChecker := mocks.NewMockGatekeeper(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 
 	ctx := context.Background()
@@ -138,6 +146,7 @@ func TestStop_Success(t *testing.T) {
 	mockChecker := mocks.NewMockGatekeeper(t)
 	mockExecutor := mocks.NewMockJobExecutor(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
@@ -165,6 +174,7 @@ func TestStop_MarksRunningJobsAsQueued(t *testing.T) {
 	mockChecker := mocks.NewMockGatekeeper(t)
 	mockExecutor := mocks.NewMockJobExecutor(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
@@ -212,6 +222,7 @@ func TestStop_HandlesMultipleRunningJobs(t *testing.T) {
 	mockChecker := mocks.NewMockGatekeeper(t)
 	mockExecutor := mocks.NewMockJobExecutor(t)
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
@@ -256,6 +267,89 @@ func TestStop_HandlesMultipleRunningJobs(t *testing.T) {
 	}
 }
 
+// startJobForShutdown runs a single job through scheduleJob on a queue that is
+// marked running, without the scheduler goroutines, so Stop can be exercised
+// against a real in-flight job. It returns once the executor is running, so the
+// test never touches the database while the job is still being marked started.
+func startJobForShutdown(t *testing.T, server config.ServerConfig, execute func(ctx context.Context, job *models.Job) error) (*queue, *models.Job) {
+	t.Helper()
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs:   config.JobsConfig{MaxConcurrent: 2},
+		Server: server,
+	}
+	mockChecker := mocks.NewMockGatekeeper(t)
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	started := make(chan struct{})
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, job *models.Job) error {
+		close(started)
+		return execute(ctx, job)
+	}).Once()
+
+	q := New(repo, cfg, mockChecker, nil)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+	queue.running = true
+	queue.schedulerCtx, queue.schedulerCancel = context.WithCancel(context.Background())
+	queue.jobsCtx = context.Background()
+
+	job := testutil.CreateTestJob()
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.scheduleJob(job)
+	<-started
+	return queue, job
+}
+
+func TestStop_RequeueModeInterruptsActiveJobs(t *testing.T) {
+	queue, job := startJobForShutdown(t, config.ServerConfig{
+		ShutdownTimeout: 5 * time.Second,
+	}, blockingExecute)
+
+	start := time.Now()
+	require.NoError(t, queue.Stop())
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	updated, err := queue.repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, updated.Status)
+}
+
+func TestStop_DrainModeWaitsForActiveJobs(t *testing.T) {
+	release := make(chan struct{})
+	queue, job := startJobForShutdown(t, config.ServerConfig{
+		ShutdownTimeout: 5 * time.Second,
+		ShutdownMode:    config.ShutdownModeDrain,
+	}, func(ctx context.Context, job *models.Job) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	time.AfterFunc(200*time.Millisecond, func() { close(release) })
+	require.NoError(t, queue.Stop())
+
+	updated, err := queue.repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusCompleted, updated.Status)
+}
+
+func TestStop_DrainModeRequeuesAfterTimeout(t *testing.T) {
+	queue, job := startJobForShutdown(t, config.ServerConfig{
+		ShutdownTimeout: 100 * time.Millisecond,
+		ShutdownMode:    config.ShutdownModeDrain,
+	}, blockingExecute)
+
+	require.NoError(t, queue.Stop())
+
+	updated, err := queue.repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, updated.Status)
+}
+
 // ========================================
 // 3. Enqueue Tests
 // ========================================
@@ -288,7 +382,8 @@ func TestEnqueue_SetsDefaults(t *testing.T) {
 	repo := testutil.SetupTestDB(t)
 	cfg := &config.Config{
 		Jobs: config.JobsConfig{
-			MaxRetries: 5,
+			MaxRetries:      5,
+			DefaultPriority: 3,
 		},
 	}
 	mockChecker := mocks.NewMockGatekeeper(t)
@@ -298,6 +393,7 @@ func TestEnqueue_SetsDefaults(t *testing.T) {
 	job := testutil.CreateTestJob(func(j *models.Job) {
 		j.Status = ""
 		j.MaxRetries = 0
+		j.Priority = 0
 	})
 
 	err := q.Enqueue(job)
@@ -305,6 +401,65 @@ func TestEnqueue_SetsDefaults(t *testing.T) {
 
 	assert.Equal(t, models.JobStatusQueued, job.Status)
 	assert.Equal(t, 5, job.MaxRetries)
+	assert.Equal(t, 3, job.Priority)
+
+	// An explicit priority is kept
+	prioritized := testutil.CreateTestJob(func(j *models.Job) { j.Priority = 8 })
+	require.NoError(t, q.Enqueue(prioritized))
+	assert.Equal(t, 8, prioritized.Priority)
+}
+
+func TestEnqueue_RejectsAtMaxQueueDepth(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxRetries:    3,
+			MaxQueueDepth: 2,
+		},
+	}
+	mockChecker := mocks.NewMockGatekeeper(t)
+
+	q := New(repo, cfg, mockChecker, nil)
+
+	require.NoError(t, q.Enqueue(testutil.CreateTestJob()))
+	require.NoError(t, q.Enqueue(testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusPending
+	})))
+
+	rejected := testutil.CreateTestJob()
+	err := q.Enqueue(rejected)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, interfaces.ErrQueueFull)
+	assert.Zero(t, rejected.ID, "rejected job should not be saved")
+
+	count, err := repo.CountJobs(models.JobFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Completed jobs don't count toward the limit, and terminal jobs bypass it
+	require.NoError(t, q.Enqueue(testutil.CreateTestJob(func(j *models.Job) {
+		j.MarkCompleted()
+	})))
+}
+
+func TestEnqueue_CompletedJobNotScheduled(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{}
+	mockChecker := mocks.NewMockGatekeeper(t)
+
+	q := New(repo, cfg, mockChecker, nil)
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.MarkCompleted()
+	})
+
+	err := q.Enqueue(job)
+	require.NoError(t, err)
+
+	savedJob, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusCompleted, savedJob.Status)
+	assert.Empty(t, q.(*queue).jobQueue)
 }
 
 // ========================================
@@ -383,10 +538,138 @@ func TestGetSummary_Success(t *testing.T) {
 	assert.Equal(t, 1, summary.CompletedJobs)
 }
 
+func TestGetSummary_CachedUntilTTL(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{SummaryCacheTTL: 2 * time.Second}}
+	mockChecker := mocks.NewMockGatekeeper(t)
+
+	q := New(repo, cfg, mockChecker, nil)
+	queue := q.(*queue)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	queue.now = func() time.Time { return now }
+
+	require.NoError(t, repo.CreateJob(testutil.CreateTestJob()))
+
+	summary, err := q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.TotalJobs)
+
+	// A write that bypasses the queue is not seen while the cache is fresh
+	require.NoError(t, repo.CreateJob(testutil.CreateTestJob()))
+	summary, err = q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.TotalJobs, "expected cache hit")
+
+	// After the TTL the summary is re-read
+	now = now.Add(2 * time.Second)
+	summary, err = q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.TotalJobs, "expected cache miss after TTL")
+}
+
+func TestGetSummary_InvalidatedByQueueChanges(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{SummaryCacheTTL: time.Hour}}
+	mockChecker := mocks.NewMockGatekeeper(t)
+
+	q := New(repo, cfg, mockChecker, nil)
+
+	require.NoError(t, q.Enqueue(testutil.CreateTestJob()))
+
+	summary, err := q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.TotalJobs)
+
+	job := testutil.CreateTestJob()
+	require.NoError(t, q.Enqueue(job))
+
+	summary, err = q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.TotalJobs)
+	assert.Equal(t, 2, summary.QueuedJobs)
+
+	require.NoError(t, q.CancelJob(job.ID))
+
+	summary, err = q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.QueuedJobs)
+	assert.Equal(t, 1, summary.CancelledJobs)
+
+	// Mutating a returned summary doesn't affect the cache
+	summary.TotalJobs = 99
+	summary, err = q.GetSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.TotalJobs)
+}
+
 // ========================================
 // 5. Cancel Tests
 // ========================================
 
+func TestForceSchedule_BypassesGatekeeper(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{MaxConcurrent: 1}}
+	// The gatekeeper would deny every job; ForceSchedule must not ask it
+	mockChecker := mocks.NewMockGatekeeper(t)
+	mockChecker.EXPECT().CanStartJob(mock.Anything).Return(interfaces.GateDecision{Allowed: false, Reason: "bandwidth"}).Maybe()
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).Return(nil).Once()
+
+	q := New(repo, cfg, mockChecker, nil).(*queue)
+	q.SetJobExecutor(mockExecutor)
+	q.running = true
+	q.jobsCtx = context.Background()
+
+	job := testutil.CreateTestJob(func(j *models.Job) { j.Status = models.JobStatusPending })
+	require.NoError(t, repo.CreateJob(job))
+
+	require.NoError(t, q.ForceSchedule(job.ID))
+	require.True(t, q.waitForActiveJobs(5*time.Second))
+
+	updated, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusCompleted, updated.Status)
+	mockChecker.AssertNotCalled(t, "CanStartJob", mock.Anything)
+
+	// Finished jobs can't be force-started again
+	err = q.ForceSchedule(job.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not queued or pending")
+}
+
+func TestForceSchedule_RespectsMaxConcurrent(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{MaxConcurrent: 1}}
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil).(*queue)
+	q.running = true
+	q.activeJobs[999] = func() {}
+
+	job := testutil.CreateTestJob(func(j *models.Job) { j.Status = models.JobStatusQueued })
+	require.NoError(t, repo.CreateJob(job))
+
+	err := q.ForceSchedule(job.ID)
+	assert.ErrorIs(t, err, interfaces.ErrMaxConcurrent)
+
+	updated, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, updated.Status)
+}
+
+func TestStillWaiting_SkipsStaleBufferedJob(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), nil).(*queue)
+
+	job := testutil.CreateTestJob(func(j *models.Job) { j.Status = models.JobStatusQueued })
+	require.NoError(t, repo.CreateJob(job))
+	assert.True(t, q.stillWaiting(job))
+
+	require.NoError(t, q.CancelJob(job.ID))
+	// The buffered copy still says queued, but the job was cancelled meanwhile
+	assert.Equal(t, models.JobStatusQueued, job.Status)
+	assert.False(t, q.stillWaiting(job))
+}
+
 func TestCancelJob_QueuedJob(t *testing.T) {
 	repo := testutil.SetupTestDB(t)
 	cfg := &config.Config{}
@@ -405,6 +688,11 @@ func TestCancelJob_QueuedJob(t *testing.T) {
 	updatedJob, err := repo.GetJob(job.ID)
 	require.NoError(t, err)
 	assert.Equal(t, models.JobStatusCancelled, updatedJob.Status)
+
+	audit, err := q.GetJobAudit(job.ID)
+	require.NoError(t, err)
+	require.Len(t, audit, 1)
+	assert.Equal(t, models.AuditEventCancelled, audit[0].Event)
 }
 
 func TestCancelJob_NotFound(t *testing.T) {
@@ -434,10 +722,327 @@ func TestDeleteJob_Success(t *testing.T) {
 	err := q.DeleteJob(job.ID)
 	assert.NoError(t, err)
 
-	// Verify job is deleted from database
+	// Verify job is soft-deleted: kept, but hidden from lists
+	deleted, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+	assert.Equal(t, models.JobStatusCompleted, deleted.Status)
+
+	jobs, err := q.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestDeleteJob_CancelsUnfinishedJob(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), nil)
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusQueued
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	require.NoError(t, q.DeleteJob(job.ID))
+	require.NoError(t, q.RestoreJob(job.ID))
+
+	restored, err := q.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, models.JobStatusCancelled, restored.Status)
+
+	jobs, err := q.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
+func TestRestoreJob_NotDeleted(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), nil)
+
+	job := testutil.CreateTestJob()
+	require.NoError(t, repo.CreateJob(job))
+
+	assert.Error(t, q.RestoreJob(job.ID))
+	assert.Error(t, q.RestoreJob(99999))
+}
+
+func TestPerformMaintenance_VacuumsOncePerInterval(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Database: config.DatabaseConfig{VacuumInterval: 24 * time.Hour}}
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil).(*queue)
+
+	start := time.Now()
+	q.lastVacuum = start
+	q.now = func() time.Time { return start.Add(time.Hour) }
+
+	q.performMaintenance()
+	assert.Equal(t, start, q.lastVacuum, "vacuum should wait for the interval")
+
+	later := start.Add(25 * time.Hour)
+	q.now = func() time.Time { return later }
+	q.performMaintenance()
+	assert.Equal(t, later, q.lastVacuum)
+}
+
+func TestPerformMaintenance_Checkpoints(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{}
+	cfg.ApplyDefaults()
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil).(*queue)
+	q.lastVacuum = time.Now()
+
+	result := q.performMaintenance()
+	require.NotNil(t, result, "checkpoint should succeed")
+	assert.False(t, result.Vacuumed)
+}
+
+func TestPerformMaintenance_DefersVacuumWhileJobsActive(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Database: config.DatabaseConfig{VacuumInterval: time.Hour}}
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil).(*queue)
+
+	start := time.Now()
+	q.lastVacuum = start
+	q.now = func() time.Time { return start.Add(2 * time.Hour) }
+	q.activeJobs[1] = func() {}
+
+	result := q.performMaintenance()
+	require.NotNil(t, result)
+	assert.False(t, result.Vacuumed)
+	assert.Equal(t, start, q.lastVacuum, "vacuum should wait until no jobs are active")
+
+	delete(q.activeJobs, 1)
+	result = q.performMaintenance()
+	require.NotNil(t, result)
+	assert.True(t, result.Vacuumed)
+}
+
+func TestVacuum(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), nil).(*queue)
+
+	q.activeJobs[1] = func() {}
+	_, err := q.Vacuum()
+	assert.ErrorIs(t, err, interfaces.ErrJobsActive)
+
+	delete(q.activeJobs, 1)
+	now := time.Now().Add(time.Minute)
+	q.now = func() time.Time { return now }
+	result, err := q.Vacuum()
+	require.NoError(t, err)
+	assert.True(t, result.Vacuumed)
+	assert.Equal(t, now, q.lastVacuum)
+}
+
+// createCompletedJob stores a completed download of remotePath that finished age ago.
+func createCompletedJob(t *testing.T, repo *repository.Repository, remotePath string, age time.Duration) *models.Job {
+	t.Helper()
+	completedAt := time.Now().Add(-age)
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.RemotePath = remotePath
+		j.LocalPath = "/cache/downloads"
+	})
+	require.NoError(t, repo.CreateJob(job))
+	job.Status = models.JobStatusCompleted
+	job.CompletedAt = &completedAt
+	require.NoError(t, repo.UpdateJob(job))
+	return job
+}
+
+func TestEvictCache_OldestFirstUntilBelowThreshold(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{
+		MaxUsagePercent: 90,
+		Eviction:        config.CacheEvictionConfig{Enabled: true, ThresholdPercent: 85, MinAge: time.Hour},
+	}}}
+	gk := mocks.NewMockGatekeeper(t)
+	q := New(repo, cfg, gk, nil).(*queue)
+
+	middle := createCompletedJob(t, repo, "/remote/middle.mkv", 48*time.Hour)
+	oldest := createCompletedJob(t, repo, "/remote/oldest", 72*time.Hour)
+	newest := createCompletedJob(t, repo, "/remote/newest.mkv", 24*time.Hour)
+
+	var removed []string
+	q.removeAll = func(path string) error {
+		removed = append(removed, path)
+		return nil
+	}
+
+	gk.EXPECT().GetResourceStatus().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 95}).Once()
+	gk.EXPECT().RefreshNow().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 88}).Once()
+	gk.EXPECT().RefreshNow().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 80}).Once()
+
+	assert.Equal(t, 2, q.evictCache())
+	assert.Equal(t, []string{"/cache/downloads/oldest", "/cache/downloads/middle.mkv"}, removed)
+
+	for _, id := range []int64{oldest.ID, middle.ID} {
+		job, err := repo.GetJob(id)
+		require.NoError(t, err)
+		assert.NotNil(t, job.EvictedAt)
+	}
+	job, err := repo.GetJob(newest.ID)
+	require.NoError(t, err)
+	assert.Nil(t, job.EvictedAt, "eviction should stop once usage is below the threshold")
+
+	audit, err := repo.GetAudit(oldest.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, audit)
+	assert.Equal(t, models.AuditEventEvicted, audit[len(audit)-1].Event)
+}
+
+func TestEvictCache_NeverEvictsRecentDownloads(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{
+		MaxUsagePercent: 80,
+		Eviction:        config.CacheEvictionConfig{Enabled: true, MinAge: 6 * time.Hour},
+	}}}
+	gk := mocks.NewMockGatekeeper(t)
+	q := New(repo, cfg, gk, nil).(*queue)
+
+	createCompletedJob(t, repo, "/remote/recent.mkv", time.Hour)
+	q.removeAll = func(path string) error {
+		t.Fatalf("unexpected removal of %s", path)
+		return nil
+	}
+
+	gk.EXPECT().GetResourceStatus().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 99}).Once()
+
+	assert.Equal(t, 0, q.evictCache())
+}
+
+func TestEvictCache_SkipsFilesDownloadedAgain(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{
+		MaxUsagePercent: 80,
+		Eviction:        config.CacheEvictionConfig{Enabled: true, MinAge: 6 * time.Hour},
+	}}}
+	gk := mocks.NewMockGatekeeper(t)
+	q := New(repo, cfg, gk, nil).(*queue)
+
+	// An old job whose file a recent job fetched again, and one that
+	// skip_existing completed without downloading anything
+	createCompletedJob(t, repo, "/remote/movie.mkv", 72*time.Hour)
+	createCompletedJob(t, repo, "/other/movie.mkv", time.Hour)
+	skipped := createCompletedJob(t, repo, "/remote/present.mkv", 72*time.Hour)
+	skipped.Metadata.ExtraFields = map[string]interface{}{"skip_reason": "already present locally"}
+	require.NoError(t, repo.UpdateJob(skipped))
+	old := createCompletedJob(t, repo, "/remote/old.mkv", 48*time.Hour)
+
+	var removed []string
+	q.removeAll = func(path string) error {
+		removed = append(removed, path)
+		return nil
+	}
+
+	gk.EXPECT().GetResourceStatus().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 95}).Once()
+	gk.EXPECT().RefreshNow().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 90}).Once()
+
+	assert.Equal(t, 1, q.evictCache())
+	assert.Equal(t, []string{"/cache/downloads/old.mkv"}, removed)
+
+	job, err := repo.GetJob(old.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, job.EvictedAt)
+}
+
+func TestEvictCache_BelowThreshold(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{
+		MaxUsagePercent: 80,
+		Eviction:        config.CacheEvictionConfig{Enabled: true},
+	}}}
+	gk := mocks.NewMockGatekeeper(t)
+	q := New(repo, cfg, gk, nil).(*queue)
+
+	createCompletedJob(t, repo, "/remote/old.mkv", 30*24*time.Hour)
+	gk.EXPECT().GetResourceStatus().Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 79.9}).Once()
+
+	assert.Equal(t, 0, q.evictCache())
+}
+
+func TestEvictionRoutine_FollowsConfigChanges(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Gatekeeper: config.GatekeeperConfig{CacheDisk: config.CacheDiskConfig{
+		MaxUsagePercent: 80,
+		CheckInterval:   10 * time.Millisecond,
+	}}}
+	cfg.ApplyDefaults()
+	gk := mocks.NewMockGatekeeper(t)
+	q := New(repo, cfg, gk, nil).(*queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.schedulerCtx = ctx
+	go q.evictionRoutine(cfg.WatchForChanges())
+
+	// Disabled at startup: ticks must not check the cache
+	time.Sleep(50 * time.Millisecond)
+
+	checked := make(chan struct{}, 1)
+	gk.EXPECT().GetResourceStatus().Run(func() {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+	}).Return(interfaces.GatekeeperResourceStatus{CacheUsagePercent: 10}).Maybe()
+
+	gatekeeper := cfg.GetGatekeeper()
+	gatekeeper.CacheDisk.Eviction.Enabled = true
+	_, err := cfg.OverrideGatekeeper(gatekeeper)
+	require.NoError(t, err)
+
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		t.Fatal("eviction did not start after it was enabled at runtime")
+	}
+}
+
+func TestEvictionPath(t *testing.T) {
+	tests := []struct {
+		remote, local string
+		want          string
+		ok            bool
+	}{
+		{"/remote/Show.S01E01.mkv", "/cache/tv", "/cache/tv/Show.S01E01.mkv", true},
+		{"/remote/Show.S01", "/cache/tv", "/cache/tv/Show.S01", true},
+		{"/remote/Show.S01/", "/cache/tv", "", false}, // contents were copied into /cache/tv
+		{"/remote/file.mkv", "relative/dir", "", false},
+		{"/", "/cache/tv", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := evictionPath(&models.Job{RemotePath: tt.remote, LocalPath: tt.local})
+		assert.Equal(t, tt.ok, ok, tt.remote)
+		assert.Equal(t, tt.want, got, tt.remote)
+	}
+}
+
+func TestEvictionPath_CopyURL(t *testing.T) {
+	got, ok := evictionPath(&models.Job{SourceURL: "https://example.com/files/movie.mkv?sig=1", LocalPath: "/cache/movies"})
+	assert.True(t, ok)
+	assert.Equal(t, "/cache/movies/movie.mkv", got)
+}
+
+func TestPerformCleanup_PurgesExpiredDeletedJobs(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{DeletedJobRetention: time.Hour}}
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+	queue := q.(*queue)
+
+	job := testutil.CreateTestJob()
+	require.NoError(t, repo.CreateJob(job))
+	require.NoError(t, q.DeleteJob(job.ID))
+
+	// Still within the retention window
+	queue.performCleanup()
+	_, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+
+	cfg.Jobs.DeletedJobRetention = time.Nanosecond
+	queue.performCleanup()
 	_, err = repo.GetJob(job.ID)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "not found")
 }
 
 func TestDeleteJob_NotFound(t *testing.T) {
@@ -494,6 +1099,173 @@ func TestCanScheduleNewJob_AtLimit(t *testing.T) {
 	assert.False(t, queue.canScheduleNewJob())
 }
 
+func TestLoadExistingJobs_RecoveryModes(t *testing.T) {
+	tests := []struct {
+		mode         string
+		wantStatus   models.JobStatus
+		wantError    string
+		wantRequeued bool
+	}{
+		{"", models.JobStatusQueued, "", true},
+		{config.RecoveryModeRequeue, models.JobStatusQueued, "", true},
+		{config.RecoveryModeFail, models.JobStatusFailed, interruptedJobMessage, false},
+		{config.RecoveryModeLeave, models.JobStatusRunning, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode "+tt.mode, func(t *testing.T) {
+			repo := testutil.SetupTestDB(t)
+			cfg := &config.Config{Jobs: config.JobsConfig{RecoveryMode: tt.mode}}
+
+			q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+			queue := q.(*queue)
+
+			running := testutil.CreateTestJob(func(j *models.Job) {
+				j.Name = "running"
+				j.Status = models.JobStatusRunning
+			})
+			require.NoError(t, repo.CreateJob(running))
+			pending := testutil.CreateTestJob(func(j *models.Job) {
+				j.Name = "pending"
+				j.Status = models.JobStatusPending
+			})
+			require.NoError(t, repo.CreateJob(pending))
+
+			require.NoError(t, queue.loadExistingJobs())
+
+			got, err := repo.GetJob(running.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantError, got.ErrorMessage)
+
+			// Pending jobs never started, so they're requeued in every mode
+			got, err = repo.GetJob(pending.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.JobStatusQueued, got.Status)
+
+			queued := map[int64]bool{}
+			for len(queue.jobQueue) > 0 {
+				queued[(<-queue.jobQueue).ID] = true
+			}
+			assert.True(t, queued[pending.ID])
+			assert.Equal(t, tt.wantRequeued, queued[running.ID])
+		})
+	}
+}
+
+func TestAlertLongRunningJobs_AlertsOncePerJob(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{Jobs: config.JobsConfig{NotifyIfRunningLongerThan: time.Hour}}
+	mockNotifier := mocks.NewMockNotifier(t)
+	mockNotifier.EXPECT().IsEnabled().Return(true)
+	mockNotifier.EXPECT().
+		NotifySystemAlert("Long-Running Job", mock.MatchedBy(func(msg string) bool {
+			return strings.Contains(msg, "slow.mkv")
+		}), 0).
+		Return(nil).
+		Once()
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), mockNotifier).(*queue)
+
+	now := time.Now()
+	q.now = func() time.Time { return now }
+	startRunning := func(name string, startedAt time.Time) *models.Job {
+		job := testutil.CreateTestJob(func(j *models.Job) { j.Name = name })
+		require.NoError(t, repo.CreateJob(job))
+		job.Status = models.JobStatusRunning
+		job.StartedAt = &startedAt
+		require.NoError(t, repo.UpdateJob(job))
+		return job
+	}
+	slow := startRunning("slow.mkv", now.Add(-2*time.Hour))
+	startRunning("fresh.mkv", now.Add(-10*time.Minute))
+
+	assert.Equal(t, 1, q.alertLongRunningJobs())
+	// Later checks don't repeat the alert while the job keeps running
+	assert.Equal(t, 0, q.alertLongRunningJobs())
+	assert.Equal(t, 0, q.alertLongRunningJobs())
+
+	// A job that stops running is forgotten
+	slow.Status = models.JobStatusCompleted
+	require.NoError(t, repo.UpdateJob(slow))
+	assert.Equal(t, 0, q.alertLongRunningJobs())
+	assert.Empty(t, q.longRunningAlerted)
+}
+
+func TestAlertLongRunningJobs_Disabled(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), mocks.NewMockNotifier(t)).(*queue)
+
+	assert.Equal(t, 0, q.alertLongRunningJobs())
+}
+
+func TestRecoverStuckPendingJobs_RequeuesDroppedJob(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{}
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+	queue := q.(*queue)
+
+	// Pending job exists in the database but was dropped from the in-memory queue
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusPending
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	recovered := queue.recoverStuckPendingJobs()
+
+	assert.Equal(t, 1, recovered)
+	require.Len(t, queue.jobQueue, 1)
+	assert.Equal(t, job.ID, (<-queue.jobQueue).ID)
+}
+
+func TestRecoverStuckPendingJobs_SkipsActiveAndBufferedJobs(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{}
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+	queue := q.(*queue)
+
+	active := testutil.CreateTestJob(func(j *models.Job) {
+		j.Name = "active"
+		j.Status = models.JobStatusPending
+	})
+	require.NoError(t, repo.CreateJob(active))
+	queue.activeJobs[active.ID] = func() {}
+
+	buffered := testutil.CreateTestJob(func(j *models.Job) {
+		j.Name = "buffered"
+		j.Status = models.JobStatusPending
+	})
+	require.NoError(t, repo.CreateJob(buffered))
+	require.True(t, queue.pushJob(buffered))
+
+	recovered := queue.recoverStuckPendingJobs()
+
+	assert.Equal(t, 0, recovered)
+	assert.Len(t, queue.jobQueue, 1)
+}
+
+func TestPushJob_DeduplicatesUntilDequeued(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	q := New(repo, &config.Config{}, mocks.NewMockGatekeeper(t), nil)
+	queue := q.(*queue)
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.ID = 42
+	})
+
+	assert.True(t, queue.pushJob(job))
+	assert.True(t, queue.pushJob(job))
+	assert.Len(t, queue.jobQueue, 1)
+
+	<-queue.jobQueue
+	queue.markDequeued(job.ID)
+
+	assert.True(t, queue.pushJob(job))
+	assert.Len(t, queue.jobQueue, 1)
+}
+
 // ========================================
 // 7. Execution Tests
 // ========================================
@@ -534,6 +1306,118 @@ func TestExecuteJob_Success(t *testing.T) {
 	assert.Equal(t, models.JobStatusCompleted, updatedJob.Status)
 	assert.NotNil(t, updatedJob.StartedAt)
 	assert.NotNil(t, updatedJob.CompletedAt)
+
+	// Verify the completion was added to lifetime totals
+	lifetime, err := repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), lifetime.CompletedJobs)
+}
+
+func TestExecuteJob_NotifiesCompletion(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent: 2,
+		},
+	}
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockNotifier := mocks.NewMockNotifier(t)
+
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).Return(nil).Once()
+	mockNotifier.EXPECT().IsEnabled().Return(true).Once()
+	mockNotifier.EXPECT().
+		NotifyJobCompleted(mock.MatchedBy(func(j *models.Job) bool {
+			return j.Status == models.JobStatusCompleted
+		})).
+		Return(nil).
+		Once()
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), mockNotifier)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+
+	ctx := context.Background()
+	queue.schedulerCtx = ctx
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusQueued
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.executeJob(ctx, job)
+
+	mockNotifier.AssertNotCalled(t, "NotifyJobFailed", mock.Anything)
+}
+
+func TestExecuteJob_NotifierDisabled(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent: 2,
+		},
+	}
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockNotifier := mocks.NewMockNotifier(t)
+
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).Return(nil).Once()
+	mockNotifier.EXPECT().IsEnabled().Return(false).Once()
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), mockNotifier)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+
+	ctx := context.Background()
+	queue.schedulerCtx = ctx
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusQueued
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.executeJob(ctx, job)
+
+	mockNotifier.AssertNotCalled(t, "NotifyJobCompleted", mock.Anything)
+}
+
+func TestExecuteJob_RunsCompletionHooks(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	marker := filepath.Join(t.TempDir(), "hook-ran")
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent: 2,
+		},
+		Hooks: config.HooksConfig{
+			OnJobCompleted: []string{`printf '%s' "$GRABARR_JOB_NAME" > ` + marker},
+		},
+	}
+	cfg.ApplyDefaults()
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).Return(nil).Once()
+
+	q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+
+	ctx := context.Background()
+	queue.schedulerCtx = ctx
+	queue.jobsCtx = ctx
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusQueued
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.executeJob(ctx, job)
+
+	data, err := os.ReadFile(marker)
+	require.NoError(t, err)
+	assert.Equal(t, job.Name, string(data))
+
+	attempts, err := repo.GetJobAttempts(job.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Contains(t, attempts[0].LogData, "result: completed")
+	assert.Contains(t, attempts[0].LogData, ": ok")
 }
 
 func TestExecuteJob_Failure(t *testing.T) {
@@ -570,6 +1454,116 @@ func TestExecuteJob_Failure(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, models.JobStatusFailed, updatedJob.Status)
 	assert.Contains(t, updatedJob.ErrorMessage, "execution failed")
+
+	// The attempt records a log of how it ended
+	attempts, err := repo.GetJobAttempts(job.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.NotEmpty(t, attempts[0].LogData)
+	assert.Contains(t, attempts[0].LogData, "result: failed (permanent)")
+	assert.Contains(t, attempts[0].LogData, "bad path")
+}
+
+func TestAttemptLog_IncludesTransferStderr(t *testing.T) {
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.FileSize = 1000
+		j.Progress.TransferredBytes = 250
+		j.Progress.Percentage = 25
+	})
+	err := &rsync.TransferError{
+		Err:    errors.New("exit status 23"),
+		Stderr: "rsync: read errors mapping \"/remote/file\": Input/output error (5)\n",
+	}
+
+	log := attemptLog(job, err)
+
+	assert.Contains(t, log, "transferred: 250/1000 bytes (25.0%)")
+	assert.Contains(t, log, "result: failed (retryable)")
+	assert.Contains(t, log, "Input/output error")
+}
+
+// waitForNoActiveJobs waits for scheduled jobs to finish without touching the
+// database, since the in-memory test DB can't be shared across connections.
+func waitForNoActiveJobs(t *testing.T, q *queue) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		q.mu.RLock()
+		defer q.mu.RUnlock()
+		return len(q.activeJobs) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// blockingExecute simulates a stuck transfer that only returns once its context ends.
+func blockingExecute(ctx context.Context, job *models.Job) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestScheduleJob_MaxDurationFailsJob(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent:  2,
+			MaxJobDuration: 50 * time.Millisecond,
+		},
+	}
+	mockChecker := mocks.NewMockGatekeeper(t)
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).RunAndReturn(blockingExecute).Once()
+
+	q := New(repo, cfg, mockChecker, nil)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+	queue.jobsCtx = context.Background()
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.MaxRetries = 0
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.scheduleJob(job)
+	waitForNoActiveJobs(t, queue)
+
+	updated, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusFailed, updated.Status)
+	assert.Contains(t, updated.ErrorMessage, "exceeded max duration of 50ms")
+}
+
+func TestScheduleJob_MaxDurationRetriesWithinLimit(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent:  2,
+			MaxJobDuration: 50 * time.Millisecond,
+		},
+	}
+	mockChecker := mocks.NewMockGatekeeper(t)
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).RunAndReturn(blockingExecute).Once()
+
+	q := New(repo, cfg, mockChecker, nil)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+	queue.jobsCtx = context.Background()
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.MaxRetries = 3
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.scheduleJob(job)
+	waitForNoActiveJobs(t, queue)
+
+	updated, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, updated.Status)
+	assert.Equal(t, 1, updated.Retries)
+
+	attempts, err := repo.GetJobAttempts(job.ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Contains(t, attempts[0].ErrorMessage, "exceeded max duration")
 }
 
 func TestExecuteJob_PermanentError(t *testing.T) {
@@ -647,6 +1641,97 @@ func TestExecuteJob_RetryableError(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, models.JobStatusQueued, updatedJob.Status)
 	assert.Equal(t, 1, updatedJob.Retries)
+
+	// Retries aren't terminal, so nothing is notified
+	mockNotifier.AssertNotCalled(t, "NotifyJobFailed", mock.Anything)
+	mockNotifier.AssertNotCalled(t, "NotifyJobCompleted", mock.Anything)
+}
+
+func TestExecuteJob_NonRetryableCategoryFailsImmediately(t *testing.T) {
+	repo := testutil.SetupTestDB(t)
+	cfg := &config.Config{
+		Jobs: config.JobsConfig{
+			MaxConcurrent: 2,
+			MaxRetries:    5,
+		},
+	}
+	mockChecker := mocks.NewMockGatekeeper(t)
+	mockExecutor := mocks.NewMockJobExecutor(t)
+	mockNotifier := mocks.NewMockNotifier(t)
+
+	mockExecutor.EXPECT().
+		Execute(mock.Anything, mock.Anything).
+		Return(errors.New(`rsync: change_dir "/remote/gone" failed: No such file or directory (2)`)).
+		Once()
+
+	mockNotifier.EXPECT().IsEnabled().Return(false).Once()
+
+	q := New(repo, cfg, mockChecker, mockNotifier)
+	q.SetJobExecutor(mockExecutor)
+	queue := q.(*queue)
+
+	ctx := context.Background()
+	queue.schedulerCtx = ctx
+
+	job := testutil.CreateTestJob(func(j *models.Job) {
+		j.Status = models.JobStatusQueued
+	})
+	require.NoError(t, repo.CreateJob(job))
+
+	queue.executeJob(ctx, job)
+
+	updatedJob, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusFailed, updatedJob.Status)
+	assert.Equal(t, 0, updatedJob.Retries)
+	assert.Equal(t, models.FailureCategoryNotFound, updatedJob.FailureCategory)
+}
+
+func TestExecuteJob_RetryableFailuresConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryable  []models.FailureCategory
+		err        error
+		wantStatus models.JobStatus
+		wantRetry  int
+	}{
+		{"network retried by default", nil, errors.New("connection reset by peer"), models.JobStatusQueued, 1},
+		{"network not configured", []models.FailureCategory{models.FailureCategoryDisk}, errors.New("connection reset by peer"), models.JobStatusFailed, 0},
+		{"notfound configured", []models.FailureCategory{models.FailureCategoryNotFound}, errors.New("No such file or directory"), models.JobStatusQueued, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := testutil.SetupTestDB(t)
+			cfg := &config.Config{
+				Jobs: config.JobsConfig{
+					MaxConcurrent:     2,
+					RetryableFailures: tt.retryable,
+				},
+			}
+			mockExecutor := mocks.NewMockJobExecutor(t)
+			mockExecutor.EXPECT().Execute(mock.Anything, mock.Anything).Return(tt.err).Once()
+
+			q := New(repo, cfg, mocks.NewMockGatekeeper(t), nil)
+			q.SetJobExecutor(mockExecutor)
+			queue := q.(*queue)
+
+			ctx := context.Background()
+			queue.schedulerCtx = ctx
+
+			job := testutil.CreateTestJob(func(j *models.Job) {
+				j.Status = models.JobStatusQueued
+			})
+			require.NoError(t, repo.CreateJob(job))
+
+			queue.executeJob(ctx, job)
+
+			updatedJob, err := repo.GetJob(job.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, updatedJob.Status)
+			assert.Equal(t, tt.wantRetry, updatedJob.Retries)
+		})
+	}
 }
 
 // ========================================
@@ -683,6 +1768,7 @@ func TestQueueIntegration_SimpleExecution(t *testing.T) {
 		Return(nil).
 		Maybe()
 
+	cfg.ApplyDefaults()
 	q := New(repo, cfg, mockChecker, nil)
 	q.SetJobExecutor(mockExecutor)
 
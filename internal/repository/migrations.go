@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+)
+
+// migration is a versioned schema change. Migrations run in version order and
+// are recorded in schema_migrations so each is applied at most once. Each must
+// also be idempotent, since databases that predate schema_migrations may
+// already contain the change.
+type migration struct {
+	version     int
+	description string
+	apply       func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change in order. Append new migrations with the
+// next version number; never renumber or edit one that has shipped.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "add download_config column to jobs",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "jobs", "download_config", "TEXT")
+		},
+	},
+	{
+		version:     2,
+		description: "add group_id column to jobs",
+		apply: func(tx *sql.Tx) error {
+			if err := addColumnIfMissing(tx, "jobs", "group_id", "TEXT"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_jobs_group_id ON jobs(group_id)"); err != nil {
+				return fmt.Errorf("failed to create group_id index: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     3,
+		description: "add failure_category column to jobs",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "jobs", "failure_category", "TEXT")
+		},
+	},
+	{
+		version:     4,
+		description: "add deleted_at column to jobs",
+		apply: func(tx *sql.Tx) error {
+			if err := addColumnIfMissing(tx, "jobs", "deleted_at", "DATETIME"); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_jobs_deleted_at ON jobs(deleted_at)"); err != nil {
+				return fmt.Errorf("failed to create deleted_at index: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     5,
+		description: "add audit_log table",
+		apply: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS audit_log (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				job_id INTEGER NOT NULL,
+				event TEXT NOT NULL,
+				message TEXT,
+				created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+			)`); err != nil {
+				return fmt.Errorf("failed to create audit_log table: %w", err)
+			}
+			if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_audit_log_job_id ON audit_log(job_id)"); err != nil {
+				return fmt.Errorf("failed to create audit_log index: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		version:     6,
+		description: "add evicted_at column to jobs",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "jobs", "evicted_at", "DATETIME")
+		},
+	},
+	{
+		version:     7,
+		description: "add source_url column to jobs",
+		apply: func(tx *sql.Tx) error {
+			return addColumnIfMissing(tx, "jobs", "source_url", "TEXT NOT NULL DEFAULT ''")
+		},
+	},
+}
+
+// runMigrations applies any migrations not yet recorded in schema_migrations.
+func (r *Repository) runMigrations() error {
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+
+		slog.Info("migrating database", "version", m.version, "migration", m.description)
+		if err := r.applyMigration(m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.description, err)
+		}
+		slog.Info("migration complete", "version", m.version)
+	}
+
+	return nil
+}
+
+func (r *Repository) appliedMigrations() (map[int]bool, error) {
+	rows, err := r.db.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// applyMigration runs a migration and records it in a single transaction.
+func (r *Repository) applyMigration(m migration) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := m.apply(tx); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", m.version); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func addColumnIfMissing(tx *sql.Tx, table, column, columnType string) error {
+	var exists bool
+	row := tx.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column)
+	if err := row.Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check for %s column in %s: %w", column, table, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnType)); err != nil {
+		return fmt.Errorf("failed to add %s column to %s: %w", column, table, err)
+	}
+	return nil
+}
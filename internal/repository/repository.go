@@ -1,13 +1,18 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"grabarr/internal/config"
 	"grabarr/internal/models"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -20,15 +25,47 @@ type Repository struct {
 	db *sql.DB
 }
 
+// Connection defaults used when the database config leaves a setting unset.
+const (
+	defaultBusyTimeoutMs   = 5000
+	defaultMaxOpenConns    = 10
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = time.Hour
+)
+
+// New opens the database at dbPath with the default connection settings.
 func New(dbPath string) (*Repository, error) {
-	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_journal_mode=WAL&_timeout=5000&_cache_size=2000", dbPath))
+	return NewWithConfig(config.DatabaseConfig{Path: dbPath})
+}
+
+// NewWithConfig opens the database described by cfg, falling back to the
+// defaults for unset busy timeout and pool settings.
+func NewWithConfig(cfg config.DatabaseConfig) (*Repository, error) {
+	busyTimeout := cfg.BusyTimeoutMs
+	if busyTimeout == 0 {
+		busyTimeout = defaultBusyTimeoutMs
+	}
+	maxOpen := cfg.MaxOpenConns
+	if maxOpen == 0 {
+		maxOpen = defaultMaxOpenConns
+	}
+	maxIdle := cfg.MaxIdleConns
+	if maxIdle == 0 {
+		maxIdle = defaultMaxIdleConns
+	}
+	lifetime := cfg.ConnMaxLifetime
+	if lifetime == 0 {
+		lifetime = defaultConnMaxLifetime
+	}
+
+	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_journal_mode=WAL&_timeout=%d&_cache_size=2000", cfg.Path, busyTimeout))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(time.Hour)
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
 
 	repo := &Repository{db: db}
 
@@ -44,6 +81,57 @@ func (r *Repository) Close() error {
 	return r.db.Close()
 }
 
+// Ping checks that the database answers a trivial query.
+func (r *Repository) Ping(ctx context.Context) error {
+	var one int
+	return r.db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
+// Maintain checkpoints the WAL into the database and truncates it, then runs
+// VACUUM when vacuum is set. Databases not in WAL mode (e.g. in-memory ones)
+// skip the checkpoint.
+func (r *Repository) Maintain(vacuum bool) (*models.MaintenanceResult, error) {
+	var result models.MaintenanceResult
+
+	before, err := r.databaseSize()
+	if err != nil {
+		return nil, err
+	}
+	result.SizeBefore = before
+
+	var busy, walFrames int
+	if err := r.db.QueryRow("PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &walFrames, &result.CheckpointedFrames); err != nil {
+		return nil, fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+	if busy != 0 {
+		slog.Warn("WAL checkpoint could not complete, database busy", "wal_frames", walFrames, "checkpointed", result.CheckpointedFrames)
+	}
+
+	if vacuum {
+		if _, err := r.db.Exec("VACUUM"); err != nil {
+			return nil, fmt.Errorf("failed to vacuum database: %w", err)
+		}
+		result.Vacuumed = true
+	}
+
+	after, err := r.databaseSize()
+	if err != nil {
+		return nil, err
+	}
+	result.SizeAfter = after
+	result.ReclaimedBytes = before - after
+
+	return &result, nil
+}
+
+func (r *Repository) databaseSize() (int64, error) {
+	var size int64
+	if err := r.db.QueryRow("SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()").Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to read database size: %w", err)
+	}
+	return size, nil
+}
+
 func (r *Repository) initSchema() error {
 	schemaSQL, err := schemaFS.ReadFile("schema.sql")
 	if err != nil {
@@ -63,39 +151,23 @@ func (r *Repository) initSchema() error {
 	return nil
 }
 
-// runMigrations applies database migrations for schema changes
-func (r *Repository) runMigrations() error {
-	// Migration 1: Add download_config column to jobs table
-	var hasJobsDownloadConfig bool
-	row := r.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('jobs') WHERE name='download_config'")
-	if err := row.Scan(&hasJobsDownloadConfig); err != nil {
-		return fmt.Errorf("failed to check for download_config column in jobs: %w", err)
-	}
-
-	if !hasJobsDownloadConfig {
-		slog.Info("migrating database: adding download_config column to jobs table")
-		_, err := r.db.Exec("ALTER TABLE jobs ADD COLUMN download_config TEXT")
-		if err != nil {
-			return fmt.Errorf("failed to add download_config column to jobs: %w", err)
-		}
-		slog.Info("migration complete: download_config column added to jobs table")
-	}
-
-	return nil
-}
-
 // Job operations
 func (r *Repository) CreateJob(job *models.Job) error {
 	query := `
 		INSERT INTO jobs (
 			name, remote_path, local_path, status, priority, max_retries,
-			progress, metadata, download_config, file_size
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			progress, metadata, download_config, file_size, group_id, source_url
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
+	var groupID sql.NullString
+	if job.GroupID != "" {
+		groupID = sql.NullString{String: job.GroupID, Valid: true}
+	}
+
 	result, err := r.db.Exec(query,
 		job.Name, job.RemotePath, job.LocalPath, job.Status, job.Priority,
-		job.MaxRetries, job.Progress, job.Metadata, job.DownloadConfig, job.FileSize)
+		job.MaxRetries, job.Progress, job.Metadata, job.DownloadConfig, job.FileSize, groupID, job.SourceURL)
 	if err != nil {
 		return fmt.Errorf("failed to create job: %w", err)
 	}
@@ -113,39 +185,69 @@ func (r *Repository) CreateJob(job *models.Job) error {
 }
 
 func (r *Repository) GetJob(id int64) (*models.Job, error) {
-	query := `
-		SELECT id, name, remote_path, local_path, status, priority, retries, max_retries,
-			   error_message, progress, metadata, download_config, created_at, updated_at, started_at,
-			   completed_at, file_size, transferred_bytes, transfer_speed
-		FROM jobs WHERE id = ?
-	`
+	return r.GetJobContext(context.Background(), id)
+}
+
+// GetJobContext is GetJob with a context; the query is aborted when ctx is done.
+func (r *Repository) GetJobContext(ctx context.Context, id int64) (*models.Job, error) {
+	query := "SELECT " + jobColumns + " FROM jobs WHERE id = ?"
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("job %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
 
+// jobColumns is the column list scanJob expects, in order.
+const jobColumns = `id, name, remote_path, local_path, status, priority, retries, max_retries,
+	error_message, progress, metadata, download_config, created_at, updated_at, started_at,
+	completed_at, file_size, transferred_bytes, transfer_speed, group_id, failure_category,
+	deleted_at, evicted_at, source_url`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanJob reads a row selected with jobColumns, followed by any extra columns,
+// which are scanned into extra.
+func scanJob(row rowScanner, extra ...interface{}) (*models.Job, error) {
 	var job models.Job
 	var errorMessage sql.NullString
-	var startedAt, completedAt sql.NullTime
-	var downloadConfig sql.NullString
+	var startedAt, completedAt, deletedAt, evictedAt sql.NullTime
+	var downloadConfig, groupID, failureCategory sql.NullString
 
-	err := r.db.QueryRow(query, id).Scan(
+	dest := []interface{}{
 		&job.ID, &job.Name, &job.RemotePath, &job.LocalPath, &job.Status,
 		&job.Priority, &job.Retries, &job.MaxRetries, &errorMessage,
 		&job.Progress, &job.Metadata, &downloadConfig, &job.CreatedAt, &job.UpdatedAt,
 		&startedAt, &completedAt, &job.FileSize, &job.TransferredBytes,
-		&job.TransferSpeed)
+		&job.TransferSpeed, &groupID, &failureCategory, &deletedAt, &evictedAt, &job.SourceURL,
+	}
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("job %d not found", id)
-		}
-		return nil, fmt.Errorf("failed to get job: %w", err)
+		return nil, err
 	}
 
 	if errorMessage.Valid {
 		job.ErrorMessage = errorMessage.String
 	}
+	if groupID.Valid {
+		job.GroupID = groupID.String
+	}
+	if failureCategory.Valid {
+		job.FailureCategory = models.FailureCategory(failureCategory.String)
+	}
 	if downloadConfig.Valid && downloadConfig.String != "" {
 		// Download config is stored as JSON, use the Scan method
 		job.DownloadConfig = &models.DownloadConfig{}
 		if err := job.DownloadConfig.Scan(downloadConfig.String); err != nil {
-			slog.Warn("failed to parse download_config, ignoring", "job_id", id, "error", err)
+			slog.Warn("failed to parse download_config, ignoring", "job_id", job.ID, "error", err)
 			job.DownloadConfig = nil
 		}
 	}
@@ -155,43 +257,78 @@ func (r *Repository) GetJob(id int64) (*models.Job, error) {
 	if completedAt.Valid {
 		job.CompletedAt = &completedAt.Time
 	}
+	if deletedAt.Valid {
+		job.DeletedAt = &deletedAt.Time
+	}
+	if evictedAt.Valid {
+		job.EvictedAt = &evictedAt.Time
+	}
 
 	return &job, nil
 }
 
-func (r *Repository) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
-	query := `
-		SELECT id, name, remote_path, local_path, status, priority, retries, max_retries,
-			   error_message, progress, metadata, download_config, created_at, updated_at, started_at,
-			   completed_at, file_size, transferred_bytes, transfer_speed
-		FROM jobs
-	`
-
-	var conditions []string
-	var args []interface{}
+// jobSortColumns maps each models.JobSortFields value to its ORDER BY expression.
+// Nullable columns are coalesced so keyset comparisons never see NULL; the
+// replacements sort where NULL would.
+var jobSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"started_at":   "COALESCE(started_at, '')",
+	"completed_at": "COALESCE(completed_at, '')",
+	"priority":     "priority",
+	"progress":     "COALESCE(JSON_EXTRACT(progress, '$.percentage'), 0)",
+	"name":         "name",
+	"status":       "status",
+	"file_size":    "file_size",
+}
 
-	if len(filter.Status) > 0 {
-		placeholders := strings.Repeat("?,", len(filter.Status))
-		placeholders = placeholders[:len(placeholders)-1] // Remove trailing comma
-		conditions = append(conditions, fmt.Sprintf("status IN (%s)", placeholders))
-		for _, status := range filter.Status {
-			args = append(args, status)
+// jobOrderBy returns the ORDER BY column and direction for a filter. Unknown
+// values fall back to created_at DESC so caller input never reaches the query.
+func jobOrderBy(filter models.JobFilter) (string, string) {
+	column := "created_at"
+	if filter.SortBy != "" {
+		if c, ok := jobSortColumns[filter.SortBy]; ok {
+			column = c
+		} else {
+			slog.Warn("ignoring invalid job sort field", "sort_by", filter.SortBy)
 		}
 	}
 
-	if filter.Category != "" {
-		conditions = append(conditions, "JSON_EXTRACT(metadata, '$.category') = ?")
-		args = append(args, filter.Category)
+	order := "DESC"
+	if filter.SortOrder != "" {
+		if models.IsValidSortOrder(filter.SortOrder) {
+			order = strings.ToUpper(filter.SortOrder)
+		} else {
+			slog.Warn("ignoring invalid job sort order", "sort_order", filter.SortOrder)
+		}
 	}
 
-	if filter.MinPriority != nil {
-		conditions = append(conditions, "priority >= ?")
-		args = append(args, *filter.MinPriority)
-	}
+	return column, order
+}
 
-	if filter.MaxPriority != nil {
-		conditions = append(conditions, "priority <= ?")
-		args = append(args, *filter.MaxPriority)
+func (r *Repository) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
+	return r.GetJobsContext(context.Background(), filter)
+}
+
+// GetJobsContext is GetJobs with a context; the query is aborted when ctx is done.
+func (r *Repository) GetJobsContext(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	sortColumn, sortOrder := jobOrderBy(filter)
+
+	// The sort key is selected as an expression (unary + is a no-op) so the
+	// driver returns it exactly as stored instead of parsing DATETIME columns;
+	// cursors built from it then compare equal to the row it came from.
+	query := "SELECT " + jobColumns + ", +(" + sortColumn + ") FROM jobs"
+	conditions, args := jobFilterConditions(filter)
+
+	// Keyset pagination: continue after the cursor's position in the sort, so
+	// rows added, removed or updated earlier in the list don't shift later pages.
+	if filter.After != nil {
+		op := "<"
+		if sortOrder == "ASC" {
+			op = ">"
+		}
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id > ?))", sortColumn, op))
+		args = append(args, filter.After.SortKey, filter.After.SortKey, filter.After.ID)
 	}
 
 	if len(conditions) > 0 {
@@ -199,27 +336,19 @@ func (r *Repository) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
 	}
 
 	// Sorting
-	sortBy := "created_at"
-	if filter.SortBy != "" {
-		sortBy = filter.SortBy
-	}
-	sortOrder := "DESC"
-	if filter.SortOrder != "" {
-		sortOrder = filter.SortOrder
-	}
-	query += fmt.Sprintf(" ORDER BY %s %s, id ASC", sortBy, sortOrder)
+	query += fmt.Sprintf(" ORDER BY %s %s, id ASC", sortColumn, sortOrder)
 
 	// Pagination
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
 		args = append(args, filter.Limit)
 	}
-	if filter.Offset > 0 {
+	if filter.Offset > 0 && filter.After == nil {
 		query += " OFFSET ?"
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query jobs: %w", err)
 	}
@@ -227,39 +356,13 @@ func (r *Repository) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
 
 	var jobs []*models.Job
 	for rows.Next() {
-		var job models.Job
-		var errorMessage sql.NullString
-		var startedAt, completedAt sql.NullTime
-		var downloadConfig sql.NullString
-
-		err := rows.Scan(
-			&job.ID, &job.Name, &job.RemotePath, &job.LocalPath, &job.Status,
-			&job.Priority, &job.Retries, &job.MaxRetries, &errorMessage,
-			&job.Progress, &job.Metadata, &downloadConfig, &job.CreatedAt, &job.UpdatedAt,
-			&startedAt, &completedAt, &job.FileSize, &job.TransferredBytes,
-			&job.TransferSpeed)
+		var sortKey interface{}
+		job, err := scanJob(rows, &sortKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan job: %w", err)
 		}
-
-		if errorMessage.Valid {
-			job.ErrorMessage = errorMessage.String
-		}
-		if downloadConfig.Valid && downloadConfig.String != "" {
-			job.DownloadConfig = &models.DownloadConfig{}
-			if err := job.DownloadConfig.Scan(downloadConfig.String); err != nil {
-				slog.Warn("failed to parse download_config, ignoring", "job_id", job.ID, "error", err)
-				job.DownloadConfig = nil
-			}
-		}
-		if startedAt.Valid {
-			job.StartedAt = &startedAt.Time
-		}
-		if completedAt.Valid {
-			job.CompletedAt = &completedAt.Time
-		}
-
-		jobs = append(jobs, &job)
+		job.SortKey = sortKey
+		jobs = append(jobs, job)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -270,8 +373,30 @@ func (r *Repository) GetJobs(filter models.JobFilter) ([]*models.Job, error) {
 }
 
 func (r *Repository) CountJobs(filter models.JobFilter) (int, error) {
+	return r.CountJobsContext(context.Background(), filter)
+}
+
+// CountJobsContext is CountJobs with a context; the query is aborted when ctx is done.
+func (r *Repository) CountJobsContext(ctx context.Context, filter models.JobFilter) (int, error) {
 	query := "SELECT COUNT(*) FROM jobs"
+	conditions, args := jobFilterConditions(filter)
 
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	return count, nil
+}
+
+// jobFilterConditions builds the WHERE conditions and arguments shared by
+// GetJobsContext and CountJobsContext. Pagination is left to the caller.
+func jobFilterConditions(filter models.JobFilter) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
 
@@ -284,9 +409,32 @@ func (r *Repository) CountJobs(filter models.JobFilter) (int, error) {
 		}
 	}
 
-	if filter.Category != "" {
-		conditions = append(conditions, "JSON_EXTRACT(metadata, '$.category') = ?")
-		args = append(args, filter.Category)
+	if len(filter.IDs) > 0 {
+		placeholders := strings.Repeat("?,", len(filter.IDs))
+		placeholders = placeholders[:len(placeholders)-1]
+		conditions = append(conditions, fmt.Sprintf("id IN (%s)", placeholders))
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(filter.Categories) > 0 {
+		placeholders := strings.Repeat("?,", len(filter.Categories))
+		placeholders = placeholders[:len(placeholders)-1]
+		conditions = append(conditions, fmt.Sprintf("JSON_EXTRACT(metadata, '$.category') IN (%s)", placeholders))
+		for _, category := range filter.Categories {
+			args = append(args, category)
+		}
+	}
+
+	if filter.GroupID != "" {
+		conditions = append(conditions, "group_id = ?")
+		args = append(args, filter.GroupID)
+	}
+
+	if filter.Hash != "" {
+		conditions = append(conditions, "JSON_EXTRACT(metadata, '$.qbittorrent_hash') = ? COLLATE NOCASE")
+		args = append(args, filter.Hash)
 	}
 
 	if filter.MinPriority != nil {
@@ -299,29 +447,71 @@ func (r *Repository) CountJobs(filter models.JobFilter) (int, error) {
 		args = append(args, *filter.MaxPriority)
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
 	}
 
-	var count int
-	err := r.db.QueryRow(query, args...).Scan(&count)
+	return conditions, args
+}
+
+// GetJobByHash returns the most recently created job for a qBittorrent torrent
+// hash. Hashes are matched case-insensitively.
+func (r *Repository) GetJobByHash(hash string) (*models.Job, error) {
+	var id int64
+	err := r.db.QueryRow(`
+		SELECT id FROM jobs
+		WHERE JSON_EXTRACT(metadata, '$.qbittorrent_hash') = ? COLLATE NOCASE
+		  AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, hash).Scan(&id)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count jobs: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("job with hash %s not found", hash)
+		}
+		return nil, fmt.Errorf("failed to get job by hash: %w", err)
 	}
 
-	return count, nil
+	return r.GetJob(id)
+}
+
+// GetActiveJobByRemotePath returns the most recently created unfinished
+// (queued, pending or running) job for remotePath, i.e. the one a cancel
+// would act on.
+func (r *Repository) GetActiveJobByRemotePath(remotePath string) (*models.Job, error) {
+	var id int64
+	err := r.db.QueryRow(`
+		SELECT id FROM jobs
+		WHERE remote_path = ?
+		  AND status IN ('queued', 'pending', 'running')
+		  AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, remotePath).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no active job for remote path %s", remotePath)
+		}
+		return nil, fmt.Errorf("failed to get job by remote path: %w", err)
+	}
+
+	return r.GetJob(id)
+}
+
+// GetJobsByIDs returns the jobs with the given IDs, including soft-deleted
+// ones. IDs with no job are left out.
+func (r *Repository) GetJobsByIDs(ids []int64) ([]*models.Job, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.GetJobs(models.JobFilter{IDs: ids, IncludeDeleted: true})
 }
 
 // GetJobsByArchiveGroup returns all jobs that belong to the given archive group.
 func (r *Repository) GetJobsByArchiveGroup(group string) ([]*models.Job, error) {
-	query := `
-		SELECT id, name, remote_path, local_path, status, priority, retries, max_retries,
-			   error_message, progress, metadata, download_config, created_at, updated_at, started_at,
-			   completed_at, file_size, transferred_bytes, transfer_speed
-		FROM jobs
-		WHERE JSON_EXTRACT(metadata, '$.extra_fields.archive_group') = ?
-		ORDER BY name ASC
-	`
+	query := "SELECT " + jobColumns + ` FROM jobs
+		WHERE JSON_EXTRACT(metadata, '$.extra_fields.archive_group') = ? AND deleted_at IS NULL
+		ORDER BY name ASC`
 
 	rows, err := r.db.Query(query, group)
 	if err != nil {
@@ -331,39 +521,11 @@ func (r *Repository) GetJobsByArchiveGroup(group string) ([]*models.Job, error)
 
 	var jobs []*models.Job
 	for rows.Next() {
-		var job models.Job
-		var errorMessage sql.NullString
-		var startedAt, completedAt sql.NullTime
-		var downloadConfig sql.NullString
-
-		err := rows.Scan(
-			&job.ID, &job.Name, &job.RemotePath, &job.LocalPath, &job.Status,
-			&job.Priority, &job.Retries, &job.MaxRetries, &errorMessage,
-			&job.Progress, &job.Metadata, &downloadConfig, &job.CreatedAt, &job.UpdatedAt,
-			&startedAt, &completedAt, &job.FileSize, &job.TransferredBytes,
-			&job.TransferSpeed)
+		job, err := scanJob(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan job: %w", err)
 		}
-
-		if errorMessage.Valid {
-			job.ErrorMessage = errorMessage.String
-		}
-		if downloadConfig.Valid && downloadConfig.String != "" {
-			job.DownloadConfig = &models.DownloadConfig{}
-			if err := job.DownloadConfig.Scan(downloadConfig.String); err != nil {
-				slog.Warn("failed to parse download_config, ignoring", "job_id", job.ID, "error", err)
-				job.DownloadConfig = nil
-			}
-		}
-		if startedAt.Valid {
-			job.StartedAt = &startedAt.Time
-		}
-		if completedAt.Valid {
-			job.CompletedAt = &completedAt.Time
-		}
-
-		jobs = append(jobs, &job)
+		jobs = append(jobs, job)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -378,14 +540,21 @@ func (r *Repository) UpdateJob(job *models.Job) error {
 		UPDATE jobs SET
 			status = ?, priority = ?, retries = ?, error_message = ?,
 			progress = ?, started_at = ?, completed_at = ?,
-			transferred_bytes = ?, transfer_speed = ?
+			transferred_bytes = ?, transfer_speed = ?, failure_category = ?,
+			local_path = ?, metadata = ?
 		WHERE id = ?
 	`
 
+	var failureCategory sql.NullString
+	if job.FailureCategory != "" {
+		failureCategory = sql.NullString{String: string(job.FailureCategory), Valid: true}
+	}
+
 	_, err := r.db.Exec(query,
 		job.Status, job.Priority, job.Retries, job.ErrorMessage,
 		job.Progress, job.StartedAt, job.CompletedAt,
-		job.TransferredBytes, job.TransferSpeed, job.ID)
+		job.TransferredBytes, job.TransferSpeed, failureCategory,
+		job.LocalPath, job.Metadata, job.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update job: %w", err)
 	}
@@ -393,8 +562,11 @@ func (r *Repository) UpdateJob(job *models.Job) error {
 	return nil
 }
 
+// DeleteJob soft-deletes a job: it is hidden from lists and summaries but kept,
+// with its attempts, until RestoreJob or PurgeDeletedJobs. Deleting a missing
+// or already deleted job is a no-op.
 func (r *Repository) DeleteJob(id int64) error {
-	_, err := r.db.Exec("DELETE FROM jobs WHERE id = ?", id)
+	_, err := r.db.Exec("UPDATE jobs SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete job: %w", err)
 	}
@@ -402,6 +574,143 @@ func (r *Repository) DeleteJob(id int64) error {
 	return nil
 }
 
+// RestoreJob undoes DeleteJob.
+func (r *Repository) RestoreJob(id int64) error {
+	result, err := r.db.Exec("UPDATE jobs SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("failed to restore job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted job %d not found", id)
+	}
+
+	return nil
+}
+
+// PurgeDeletedJobs permanently removes jobs soft-deleted before the given time
+// and returns how many were removed.
+func (r *Repository) PurgeDeletedJobs(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM jobs WHERE deleted_at IS NOT NULL AND deleted_at < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted jobs: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetEvictionCandidates returns the IDs of completed download jobs that finished
+// before the given time and whose local files have not been evicted yet, oldest
+// first. Extraction jobs are skipped, since their files belong to the downloads,
+// and so are jobs completed by skip_existing, which never downloaded anything.
+func (r *Repository) GetEvictionCandidates(completedBefore time.Time) ([]int64, error) {
+	rows, err := r.db.Query(`
+		SELECT id FROM jobs
+		WHERE status = 'completed'
+		  AND completed_at < ?
+		  AND evicted_at IS NULL
+		  AND deleted_at IS NULL
+		  AND COALESCE(JSON_EXTRACT(metadata, '$.extra_fields.job_type'), '') != 'extraction'
+		  AND JSON_EXTRACT(metadata, '$.extra_fields.skip_reason') IS NULL
+		ORDER BY completed_at ASC, id ASC
+	`, completedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query eviction candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan eviction candidate: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// GetNewerCompletedJobs returns the completed, non-evicted jobs in localPath
+// that finished after the given job. Cache eviction uses them to leave alone a
+// file that a later job downloaded again.
+func (r *Repository) GetNewerCompletedJobs(localPath string, completedAt time.Time, id int64) ([]*models.Job, error) {
+	query := "SELECT " + jobColumns + ` FROM jobs
+		WHERE status = 'completed'
+		  AND local_path = ?
+		  AND (completed_at > ? OR (completed_at = ? AND id > ?))
+		  AND evicted_at IS NULL
+		  AND deleted_at IS NULL
+		ORDER BY completed_at ASC, id ASC`
+
+	rows, err := r.db.Query(query, localPath, completedAt, completedAt, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query newer completed jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// MarkJobEvicted records that a job's local files were removed by cache eviction.
+func (r *Repository) MarkJobEvicted(id int64) error {
+	_, err := r.db.Exec("UPDATE jobs SET evicted_at = ?, updated_at = ? WHERE id = ?", time.Now(), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark job evicted: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeJobs deletes jobs in any of statuses that finished before the given time,
+// using completed_at when set and updated_at otherwise. It returns the number of
+// jobs removed.
+func (r *Repository) PurgeJobs(statuses []models.JobStatus, before time.Time) (int, error) {
+	if len(statuses) == 0 {
+		return 0, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(statuses))
+	placeholders = placeholders[:len(placeholders)-1]
+	query := fmt.Sprintf("DELETE FROM jobs WHERE status IN (%s) AND COALESCE(completed_at, updated_at) < ?", placeholders)
+
+	args := make([]interface{}, 0, len(statuses)+1)
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+	args = append(args, before)
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge jobs: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
 func (r *Repository) GetJobSummary() (*models.JobSummary, error) {
 	query := `
 		SELECT
@@ -413,6 +722,7 @@ func (r *Repository) GetJobSummary() (*models.JobSummary, error) {
 			SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END) as failed,
 			SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END) as cancelled
 		FROM jobs
+		WHERE deleted_at IS NULL
 	`
 
 	var summary models.JobSummary
@@ -424,9 +734,103 @@ func (r *Repository) GetJobSummary() (*models.JobSummary, error) {
 		return nil, fmt.Errorf("failed to get job summary: %w", err)
 	}
 
+	err = r.db.QueryRow(`
+		SELECT COALESCE(SUM(transfer_speed), 0)
+		FROM jobs
+		WHERE status = 'running' AND deleted_at IS NULL
+	`).Scan(&summary.TotalActiveSpeedBytesPerSec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active transfer speed: %w", err)
+	}
+
 	return &summary, nil
 }
 
+// GetJobStatsByCategory returns job counts by status and transferred bytes for
+// each metadata.category, ordered by category. Jobs without a category are
+// grouped under "".
+func (r *Repository) GetJobStatsByCategory() ([]*models.CategoryStats, error) {
+	query := `
+		SELECT
+			COALESCE(JSON_EXTRACT(metadata, '$.category'), '') as category,
+			COUNT(*) as total,
+			SUM(CASE WHEN status = 'queued' THEN 1 ELSE 0 END) as queued,
+			SUM(CASE WHEN status = 'pending' THEN 1 ELSE 0 END) as pending,
+			SUM(CASE WHEN status = 'running' THEN 1 ELSE 0 END) as running,
+			SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) as completed,
+			SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END) as failed,
+			SUM(CASE WHEN status = 'cancelled' THEN 1 ELSE 0 END) as cancelled,
+			COALESCE(SUM(transferred_bytes), 0) as transferred_bytes
+		FROM jobs
+		WHERE deleted_at IS NULL
+		GROUP BY category
+		ORDER BY category
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job stats by category: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []*models.CategoryStats{}
+	for rows.Next() {
+		var s models.CategoryStats
+		if err := rows.Scan(&s.Category, &s.TotalJobs, &s.QueuedJobs, &s.PendingJobs,
+			&s.RunningJobs, &s.CompletedJobs, &s.FailedJobs, &s.CancelledJobs,
+			&s.TransferredBytes); err != nil {
+			return nil, fmt.Errorf("failed to scan category stats: %w", err)
+		}
+		stats = append(stats, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get job stats by category: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTransferStats aggregates transfer totals for jobs completed at or after since.
+// A zero since includes all completed jobs.
+func (r *Repository) GetTransferStats(since time.Time) (*models.TransferStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(transferred_bytes), 0),
+			COALESCE(SUM(
+				CASE WHEN started_at IS NOT NULL AND completed_at IS NOT NULL
+				THEN (julianday(completed_at) - julianday(started_at)) * 86400.0
+				ELSE 0 END
+			), 0)
+		FROM jobs
+		WHERE status = 'completed'
+	`
+	args := []interface{}{}
+	if !since.IsZero() {
+		query += " AND completed_at >= ?"
+		args = append(args, since)
+	}
+
+	var stats models.TransferStats
+	err := r.db.QueryRow(query, args...).Scan(&stats.CompletedJobs, &stats.TotalBytes, &stats.TotalDurationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer stats: %w", err)
+	}
+
+	// julianday arithmetic is only precise to about a millisecond
+	stats.TotalDurationSeconds = math.Round(stats.TotalDurationSeconds*1000) / 1000
+
+	if stats.CompletedJobs > 0 {
+		stats.AverageJobSize = stats.TotalBytes / int64(stats.CompletedJobs)
+		stats.AverageDurationSeconds = stats.TotalDurationSeconds / float64(stats.CompletedJobs)
+	}
+	if stats.TotalDurationSeconds > 0 {
+		stats.AverageSpeed = int64(float64(stats.TotalBytes) / stats.TotalDurationSeconds)
+	}
+
+	return &stats, nil
+}
+
 // Job attempt operations
 func (r *Repository) CreateJobAttempt(attempt *models.JobAttempt) error {
 	query := `
@@ -510,13 +914,70 @@ func (r *Repository) GetJobAttempts(jobID int64) ([]*models.JobAttempt, error) {
 	return attempts, nil
 }
 
+// Audit log operations
+
+// AppendAudit records a job state transition. Entries are never updated or
+// deleted, including when their job is purged.
+func (r *Repository) AppendAudit(entry *models.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	result, err := r.db.Exec(
+		"INSERT INTO audit_log (job_id, event, message, created_at) VALUES (?, ?, ?, ?)",
+		entry.JobID, entry.Event, entry.Message, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get audit entry ID: %w", err)
+	}
+	entry.ID = id
+
+	return nil
+}
+
+// GetAudit returns a job's audit entries in the order they were recorded.
+func (r *Repository) GetAudit(jobID int64) ([]*models.AuditEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT id, job_id, event, message, created_at
+		FROM audit_log
+		WHERE job_id = ?
+		ORDER BY id ASC
+	`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		var message sql.NullString
+
+		if err := rows.Scan(&entry.ID, &entry.JobID, &entry.Event, &message, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entry.Message = message.String
+
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// ErrConfigNotFound is returned by GetConfig for a key that isn't set.
+var ErrConfigNotFound = errors.New("config key not found")
+
 // System configuration operations
 func (r *Repository) GetConfig(key string) (string, error) {
 	var value string
 	err := r.db.QueryRow("SELECT value FROM system_config WHERE key = ?", key).Scan(&value)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("config key %s not found", key)
+			return "", fmt.Errorf("%w: %s", ErrConfigNotFound, key)
 		}
 		return "", fmt.Errorf("failed to get config: %w", err)
 	}
@@ -537,15 +998,119 @@ func (r *Repository) SetConfig(key, value string) error {
 	return nil
 }
 
+// DeleteConfig removes a key. Deleting a missing key is a no-op.
+func (r *Repository) DeleteConfig(key string) error {
+	if _, err := r.db.Exec("DELETE FROM system_config WHERE key = ?", key); err != nil {
+		return fmt.Errorf("failed to delete config: %w", err)
+	}
+	return nil
+}
+
+// Lifetime stats are kept as running totals in system_config so they survive job
+// cleanup and don't need a full table scan.
+const (
+	lifetimeBytesKey = "lifetime_bytes_transferred"
+	lifetimeJobsKey  = "lifetime_jobs_completed"
+)
+
+// RecordJobCompletion adds a completed job to the lifetime running totals.
+// Call it after the job has been marked completed.
+func (r *Repository) RecordJobCompletion(bytesTransferred int64) error {
+	query := `
+		UPDATE system_config
+		SET value = CAST(CAST(value AS INTEGER) + CASE key WHEN ? THEN ? ELSE 1 END AS TEXT)
+		WHERE key IN (?, ?)
+	`
+
+	result, err := r.db.Exec(query, lifetimeBytesKey, bytesTransferred, lifetimeBytesKey, lifetimeJobsKey)
+	if err != nil {
+		return fmt.Errorf("failed to update lifetime stats: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected != 2 {
+		// Totals were never seeded; rebuild from the jobs table, which already
+		// includes this job.
+		_, err := r.RebuildLifetimeStats()
+		return err
+	}
+
+	return nil
+}
+
+// GetLifetimeStats returns the lifetime running totals, rebuilding them from the
+// jobs table if they are missing or corrupt.
+func (r *Repository) GetLifetimeStats() (*models.LifetimeStats, error) {
+	var stats models.LifetimeStats
+
+	bytesValue, bytesErr := r.GetConfig(lifetimeBytesKey)
+	jobsValue, jobsErr := r.GetConfig(lifetimeJobsKey)
+	if bytesErr != nil || jobsErr != nil {
+		return r.RebuildLifetimeStats()
+	}
+
+	var err error
+	if stats.BytesTransferred, err = strconv.ParseInt(bytesValue, 10, 64); err != nil {
+		slog.Warn("invalid lifetime bytes total, rebuilding", "value", bytesValue)
+		return r.RebuildLifetimeStats()
+	}
+	if stats.CompletedJobs, err = strconv.ParseInt(jobsValue, 10, 64); err != nil {
+		slog.Warn("invalid lifetime jobs total, rebuilding", "value", jobsValue)
+		return r.RebuildLifetimeStats()
+	}
+
+	return &stats, nil
+}
+
+// RebuildLifetimeStats recomputes the lifetime totals from completed jobs still in
+// the database and stores them. Jobs already removed by cleanup are not counted.
+func (r *Repository) RebuildLifetimeStats() (*models.LifetimeStats, error) {
+	var stats models.LifetimeStats
+	err := r.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(transferred_bytes), 0)
+		FROM jobs WHERE status = 'completed'
+	`).Scan(&stats.CompletedJobs, &stats.BytesTransferred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute lifetime stats: %w", err)
+	}
+
+	if err := r.SetConfig(lifetimeBytesKey, strconv.FormatInt(stats.BytesTransferred, 10)); err != nil {
+		return nil, err
+	}
+	if err := r.SetConfig(lifetimeJobsKey, strconv.FormatInt(stats.CompletedJobs, 10)); err != nil {
+		return nil, err
+	}
+
+	slog.Info("rebuilt lifetime stats", "bytes_transferred", stats.BytesTransferred, "completed_jobs", stats.CompletedJobs)
+	return &stats, nil
+}
+
 // Cleanup operations
-func (r *Repository) CleanupOldJobs(completedBefore, failedBefore time.Time) (int, error) {
+// CleanupOldJobs deletes completed and failed jobs older than the given cutoffs.
+// Jobs whose metadata category is in excludeCategories are always kept.
+func (r *Repository) CleanupOldJobs(completedBefore, failedBefore time.Time, excludeCategories []string) (int, error) {
 	query := `
 		DELETE FROM jobs
-		WHERE (status = 'completed' AND completed_at < ?)
-		   OR (status = 'failed' AND updated_at < ?)
+		WHERE ((status = 'completed' AND completed_at < ?)
+		   OR (status = 'failed' AND updated_at < ?))
 	`
+	args := []interface{}{completedBefore, failedBefore}
+
+	if len(excludeCategories) > 0 {
+		placeholders := strings.Repeat("?,", len(excludeCategories))
+		placeholders = placeholders[:len(placeholders)-1]
+		// Jobs without a category have a NULL category, which NOT IN would never match
+		query += fmt.Sprintf(" AND (JSON_EXTRACT(metadata, '$.category') IS NULL OR JSON_EXTRACT(metadata, '$.category') NOT IN (%s))", placeholders)
+		for _, category := range excludeCategories {
+			args = append(args, category)
+		}
+	}
 
-	result, err := r.db.Exec(query, completedBefore, failedBefore)
+	result, err := r.db.Exec(query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup old jobs: %w", err)
 	}
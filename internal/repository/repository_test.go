@@ -1,7 +1,13 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"grabarr/internal/config"
 	"grabarr/internal/models"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +32,42 @@ func TestNew(t *testing.T) {
 	defer repo.Close()
 }
 
+func TestNewWithConfig_CustomSettings(t *testing.T) {
+	repo, err := NewWithConfig(config.DatabaseConfig{
+		Path:            filepath.Join(t.TempDir(), "grabarr.db"),
+		BusyTimeoutMs:   1234,
+		MaxOpenConns:    3,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 10 * time.Minute,
+	})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	assert.Equal(t, 3, repo.db.Stats().MaxOpenConnections)
+
+	var busyTimeout int
+	require.NoError(t, repo.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 1234, busyTimeout)
+
+	job := &models.Job{Name: "test-job", RemotePath: "/remote/path", LocalPath: "/local/path", Status: models.JobStatusQueued}
+	require.NoError(t, repo.CreateJob(job))
+	retrieved, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "test-job", retrieved.Name)
+}
+
+func TestNewWithConfig_Defaults(t *testing.T) {
+	repo, err := NewWithConfig(config.DatabaseConfig{Path: filepath.Join(t.TempDir(), "grabarr.db")})
+	require.NoError(t, err)
+	defer repo.Close()
+
+	assert.Equal(t, defaultMaxOpenConns, repo.db.Stats().MaxOpenConnections)
+
+	var busyTimeout int
+	require.NoError(t, repo.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, defaultBusyTimeoutMs, busyTimeout)
+}
+
 func TestRepository_CreateJob(t *testing.T) {
 	repo := setupTestRepo(t)
 
@@ -114,6 +156,63 @@ func TestRepository_UpdateJob(t *testing.T) {
 	assert.NotNil(t, retrieved.StartedAt)
 }
 
+func TestRepository_UpdateJob_Destination(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{
+		Name:       "test-job",
+		RemotePath: "/remote/path",
+		LocalPath:  "/local/path",
+		Status:     models.JobStatusQueued,
+		Metadata:   models.JobMetadata{Category: "movies"},
+	}
+	require.NoError(t, repo.CreateJob(job))
+
+	// The executor rewrites the destination when a dest_template applies
+	job.LocalPath = "/local/path/movies/2024"
+	job.Metadata.ExtraFields = map[string]interface{}{"dest_subpath": "movies/2024"}
+	require.NoError(t, repo.UpdateJob(job))
+
+	retrieved, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/local/path/movies/2024", retrieved.LocalPath)
+	assert.Equal(t, "movies/2024", retrieved.Metadata.ExtraFields["dest_subpath"])
+	assert.Equal(t, "movies", retrieved.Metadata.Category)
+}
+
+func TestRepository_UpdateJob_FailureCategory(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{
+		Name:       "test-job",
+		RemotePath: "/remote/path",
+		LocalPath:  "/local/path",
+		Status:     models.JobStatusQueued,
+		MaxRetries: 3,
+	}
+	require.NoError(t, repo.CreateJob(job))
+
+	job.MarkFailed("Permission denied (publickey)")
+	job.FailureCategory = models.FailureCategoryAuth
+	require.NoError(t, repo.UpdateJob(job))
+
+	retrieved, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.FailureCategoryAuth, retrieved.FailureCategory)
+
+	jobs, err := repo.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, models.FailureCategoryAuth, jobs[0].FailureCategory)
+
+	job.MarkCompleted()
+	require.NoError(t, repo.UpdateJob(job))
+
+	retrieved, err = repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Empty(t, retrieved.FailureCategory)
+}
+
 func TestRepository_GetJobs_WithFilters(t *testing.T) {
 	repo := setupTestRepo(t)
 
@@ -166,7 +265,7 @@ func TestRepository_GetJobs_WithFilters(t *testing.T) {
 
 	// Test category filter
 	filter = models.JobFilter{
-		Category: "movies",
+		Categories: []string{"movies"},
 	}
 	results, err = repo.GetJobs(filter)
 	require.NoError(t, err)
@@ -194,6 +293,42 @@ func TestRepository_GetJobs_WithFilters(t *testing.T) {
 	assert.Len(t, results, 2)
 }
 
+func TestRepository_GetJobs_MultipleCategories(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i, category := range []string{"movies", "tv", "music", "movies", ""} {
+		job := &models.Job{
+			Name:       fmt.Sprintf("job%d", i),
+			RemotePath: fmt.Sprintf("/path%d", i),
+			LocalPath:  "/local",
+			Status:     models.JobStatusQueued,
+			Metadata:   models.JobMetadata{Category: category},
+		}
+		require.NoError(t, repo.CreateJob(job))
+	}
+
+	filter := models.JobFilter{Categories: []string{"movies", "tv"}}
+	results, err := repo.GetJobs(filter)
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	for _, job := range results {
+		assert.Contains(t, []string{"movies", "tv"}, job.Metadata.Category)
+	}
+
+	count, err := repo.CountJobs(filter)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Categories combine with the other filters
+	results, err = repo.GetJobs(models.JobFilter{Categories: []string{"music", "tv"}, Status: []models.JobStatus{models.JobStatusQueued}})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = repo.GetJobs(models.JobFilter{Categories: []string{"anime"}})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
 func TestRepository_GetJobs_Sorting(t *testing.T) {
 	repo := setupTestRepo(t)
 
@@ -233,6 +368,277 @@ func TestRepository_GetJobs_Sorting(t *testing.T) {
 	}
 }
 
+func TestJobOrderBy_InvalidInputFallsBack(t *testing.T) {
+	column, order := jobOrderBy(models.JobFilter{
+		SortBy:    "priority; DROP TABLE jobs; --",
+		SortOrder: "ASC; DELETE FROM jobs",
+	})
+	assert.Equal(t, "created_at", column)
+	assert.Equal(t, "DESC", order)
+
+	column, order = jobOrderBy(models.JobFilter{SortBy: "priority", SortOrder: "asc"})
+	assert.Equal(t, "priority", column)
+	assert.Equal(t, "ASC", order)
+}
+
+func TestJobSortColumns_CoverSortFields(t *testing.T) {
+	for _, field := range models.JobSortFields {
+		assert.Contains(t, jobSortColumns, field)
+	}
+	assert.Len(t, jobSortColumns, len(models.JobSortFields))
+}
+
+func TestRepository_GetJobs_InvalidSortUsesDefault(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i := 0; i < 3; i++ {
+		job := &models.Job{
+			Name:       fmt.Sprintf("job-%d", i),
+			RemotePath: "/path",
+			LocalPath:  "/local",
+			Status:     models.JobStatusQueued,
+			MaxRetries: 3,
+		}
+		require.NoError(t, repo.CreateJob(job))
+	}
+
+	results, err := repo.GetJobs(models.JobFilter{
+		SortBy:    "id; DROP TABLE jobs; --",
+		SortOrder: "sideways",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// Default order is created_at DESC with id ASC as the tiebreaker
+	for i := 0; i < len(results)-1; i++ {
+		assert.False(t, results[i].CreatedAt.Before(results[i+1].CreatedAt))
+	}
+
+	// The table is intact
+	count, err := repo.CountJobs(models.JobFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+// collectJobPages walks every page of filter using keyset cursors.
+func collectJobPages(t *testing.T, repo *Repository, filter models.JobFilter, between func(page []*models.Job)) []int64 {
+	t.Helper()
+	var ids []int64
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 100, "pagination did not terminate")
+		page, err := repo.GetJobs(filter)
+		require.NoError(t, err)
+		for _, job := range page {
+			ids = append(ids, job.ID)
+		}
+		if len(page) < filter.Limit {
+			return ids
+		}
+		filter.After = page[len(page)-1].Cursor()
+		if between != nil {
+			between(page)
+		}
+	}
+}
+
+func TestRepository_GetJobs_CursorIteratesStably(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// Duplicate priorities exercise the id tie-breaker
+	for _, priority := range []int{1, 3, 3, 2, 3, 1, 2} {
+		job := &models.Job{Name: "job", RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued, Priority: priority}
+		require.NoError(t, repo.CreateJob(job))
+	}
+
+	for _, order := range []string{"asc", "desc"} {
+		filter := models.JobFilter{SortBy: "priority", SortOrder: order}
+		all, err := repo.GetJobs(filter)
+		require.NoError(t, err)
+		var want []int64
+		for _, job := range all {
+			want = append(want, job.ID)
+		}
+
+		filter.Limit = 2
+		assert.Equal(t, want, collectJobPages(t, repo, filter, nil), "order %s", order)
+	}
+}
+
+func TestRepository_GetJobs_CursorUnaffectedByDeletes(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i := 0; i < 6; i++ {
+		job := &models.Job{Name: fmt.Sprintf("job-%d", i), RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued}
+		require.NoError(t, repo.CreateJob(job))
+	}
+	all, err := repo.GetJobs(models.JobFilter{SortBy: "name", SortOrder: "asc"})
+	require.NoError(t, err)
+
+	// Deleting already-seen rows would shift an offset-based page; the cursor
+	// still resumes after the last job returned.
+	ids := collectJobPages(t, repo, models.JobFilter{SortBy: "name", SortOrder: "asc", Limit: 2}, func(page []*models.Job) {
+		require.NoError(t, repo.DeleteJob(page[0].ID))
+	})
+
+	var want []int64
+	for _, job := range all {
+		want = append(want, job.ID)
+	}
+	assert.Equal(t, want, ids)
+}
+
+func TestRepository_GetJobs_CursorWithNullSortColumn(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// Queued jobs have neither started_at nor completed_at, so cursors land on
+	// NULL sort keys in both directions
+	now := time.Now()
+	for i := 0; i < 7; i++ {
+		job := &models.Job{Name: "job", RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued}
+		require.NoError(t, repo.CreateJob(job))
+		if i%3 != 1 {
+			at := now.Add(time.Duration(i) * time.Minute)
+			job.StartedAt = &at
+			if i%2 == 0 {
+				job.CompletedAt = &at
+			}
+			require.NoError(t, repo.UpdateJob(job))
+		}
+	}
+
+	for _, sortBy := range []string{"started_at", "completed_at"} {
+		for _, order := range []string{"asc", "desc"} {
+			all, err := repo.GetJobs(models.JobFilter{SortBy: sortBy, SortOrder: order})
+			require.NoError(t, err)
+			require.Len(t, all, 7)
+			var want []int64
+			for _, job := range all {
+				want = append(want, job.ID)
+			}
+
+			for limit := 1; limit <= 3; limit++ {
+				ids := collectJobPages(t, repo, models.JobFilter{SortBy: sortBy, SortOrder: order, Limit: limit}, nil)
+				assert.Equal(t, want, ids, "%s %s limit %d", sortBy, order, limit)
+			}
+		}
+	}
+}
+
+func TestRepository_GetJobs_CursorSurvivesPurgedCursorRow(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i := 0; i < 6; i++ {
+		job := &models.Job{Name: fmt.Sprintf("job-%d", i), RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued}
+		require.NoError(t, repo.CreateJob(job))
+	}
+	all, err := repo.GetJobs(models.JobFilter{SortBy: "name", SortOrder: "asc"})
+	require.NoError(t, err)
+	var want []int64
+	for _, job := range all {
+		want = append(want, job.ID)
+	}
+
+	// Cleanup hard-deletes the job the cursor points at before the next page
+	ids := collectJobPages(t, repo, models.JobFilter{SortBy: "name", SortOrder: "asc", Limit: 2}, func(page []*models.Job) {
+		last := page[len(page)-1]
+		completedAt := time.Now().Add(-48 * time.Hour)
+		last.Status = models.JobStatusCompleted
+		last.CompletedAt = &completedAt
+		require.NoError(t, repo.UpdateJob(last))
+		purged, err := repo.PurgeJobs([]models.JobStatus{models.JobStatusCompleted}, time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 1, purged)
+	})
+
+	assert.Equal(t, want, ids)
+}
+
+func TestRepository_GetJobs_CursorSurvivesSortKeyChange(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// Distinct updated_at values; an update resets the column through the
+	// trigger, so they are set on insert
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		_, err := repo.db.Exec(`INSERT INTO jobs (name, remote_path, local_path, progress, metadata, updated_at)
+			VALUES (?, '/path', '/local', '{}', '{}', ?)`, fmt.Sprintf("job-%d", i), base.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+	}
+	all, err := repo.GetJobs(models.JobFilter{SortBy: "updated_at", SortOrder: "desc"})
+	require.NoError(t, err)
+	require.Len(t, all, 6)
+	var want []int64
+	for _, job := range all {
+		want = append(want, job.ID)
+	}
+
+	// A progress write on the cursor job moves it to the top of the sort; the
+	// next page still starts after the position it had when it was returned
+	ids := collectJobPages(t, repo, models.JobFilter{SortBy: "updated_at", SortOrder: "desc", Limit: 2}, func(page []*models.Job) {
+		last := page[len(page)-1]
+		last.Progress.ObservePercentage(50)
+		require.NoError(t, repo.UpdateJob(last))
+	})
+
+	assert.Equal(t, want, ids)
+}
+
+func TestRepository_GetJobs_SortKeyAsStored(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{Name: "job", RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued, Priority: 4}
+	require.NoError(t, repo.CreateJob(job))
+
+	var createdAt string
+	require.NoError(t, repo.db.QueryRow("SELECT CAST(created_at AS TEXT) FROM jobs WHERE id = ?", job.ID).Scan(&createdAt))
+
+	tests := []struct {
+		sortBy string
+		want   interface{}
+	}{
+		{"created_at", createdAt},
+		{"started_at", ""},
+		{"priority", int64(4)},
+		{"name", "job"},
+	}
+	for _, tt := range tests {
+		jobs, err := repo.GetJobs(models.JobFilter{SortBy: tt.sortBy})
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, tt.want, jobs[0].SortKey, tt.sortBy)
+	}
+}
+
+func TestRepository_CancelledContextAbortsQuery(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{
+		Name:       "test-job",
+		RemotePath: "/remote/path",
+		LocalPath:  "/local/path",
+		Status:     models.JobStatusQueued,
+		MaxRetries: 3,
+	}
+	require.NoError(t, repo.CreateJob(job))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.GetJobsContext(ctx, models.JobFilter{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.CountJobsContext(ctx, models.JobFilter{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = repo.GetJobContext(ctx, job.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The same queries succeed with a live context
+	jobs, err := repo.GetJobsContext(context.Background(), models.JobFilter{})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
 func TestRepository_GetJobSummary(t *testing.T) {
 	repo := setupTestRepo(t)
 
@@ -270,6 +676,156 @@ func TestRepository_GetJobSummary(t *testing.T) {
 	assert.Equal(t, 3, summary.CompletedJobs)
 	assert.Equal(t, 1, summary.FailedJobs)
 	assert.Equal(t, 1, summary.CancelledJobs)
+	assert.Zero(t, summary.TotalActiveSpeedBytesPerSec)
+}
+
+func TestRepository_GetJobStatsByCategory(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	create := func(category string, status models.JobStatus, transferred int64) *models.Job {
+		job := &models.Job{
+			Name:       "job",
+			RemotePath: "/path",
+			LocalPath:  "/local",
+			Status:     status,
+			MaxRetries: 3,
+			Metadata:   models.JobMetadata{Category: category},
+		}
+		require.NoError(t, repo.CreateJob(job))
+		job.TransferredBytes = transferred
+		require.NoError(t, repo.UpdateJob(job))
+		return job
+	}
+	create("movies", models.JobStatusCompleted, 4000)
+	create("movies", models.JobStatusCompleted, 2000)
+	create("movies", models.JobStatusRunning, 500)
+	create("movies", models.JobStatusFailed, 100)
+	create("tv", models.JobStatusQueued, 0)
+	create("tv", models.JobStatusCancelled, 300)
+	create("", models.JobStatusPending, 0)
+	// Deleted jobs aren't counted
+	deleted := create("tv", models.JobStatusCompleted, 9000)
+	require.NoError(t, repo.DeleteJob(deleted.ID))
+
+	stats, err := repo.GetJobStatsByCategory()
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, &models.CategoryStats{Category: "", TotalJobs: 1, PendingJobs: 1}, stats[0])
+	assert.Equal(t, &models.CategoryStats{
+		Category:         "movies",
+		TotalJobs:        4,
+		RunningJobs:      1,
+		CompletedJobs:    2,
+		FailedJobs:       1,
+		TransferredBytes: 6600,
+	}, stats[1])
+	assert.Equal(t, &models.CategoryStats{
+		Category:         "tv",
+		TotalJobs:        2,
+		QueuedJobs:       1,
+		CancelledJobs:    1,
+		TransferredBytes: 300,
+	}, stats[2])
+}
+
+func TestRepository_GetJobStatsByCategory_Empty(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	stats, err := repo.GetJobStatsByCategory()
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+	assert.NotNil(t, stats)
+}
+
+func TestRepository_GetJobSummary_ActiveSpeed(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	create := func(status models.JobStatus, speed int64) {
+		job := &models.Job{Name: "job", RemotePath: "/path", LocalPath: "/local", Status: status, MaxRetries: 3}
+		require.NoError(t, repo.CreateJob(job))
+		job.TransferSpeed = speed
+		require.NoError(t, repo.UpdateJob(job))
+	}
+	create(models.JobStatusRunning, 5_000_000)
+	create(models.JobStatusRunning, 1_500_000)
+	// Speeds left over on finished jobs don't count
+	create(models.JobStatusCompleted, 9_000_000)
+	create(models.JobStatusQueued, 0)
+
+	summary, err := repo.GetJobSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.RunningJobs)
+	assert.Equal(t, int64(6_500_000), summary.TotalActiveSpeedBytesPerSec)
+}
+
+func TestRepository_GetTransferStats(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	now := time.Now().UTC()
+	createCompleted := func(bytes int64, completedAt time.Time, duration time.Duration) {
+		startedAt := completedAt.Add(-duration)
+		job := &models.Job{
+			Name:             "completed",
+			RemotePath:       "/path",
+			LocalPath:        "/local",
+			Status:           models.JobStatusCompleted,
+			MaxRetries:       3,
+			Progress:         models.JobProgress{},
+			Metadata:         models.JobMetadata{},
+			StartedAt:        &startedAt,
+			CompletedAt:      &completedAt,
+			TransferredBytes: bytes,
+		}
+		require.NoError(t, repo.CreateJob(job))
+		require.NoError(t, repo.UpdateJob(job))
+	}
+
+	createCompleted(1000, now.Add(-1*time.Hour), 10*time.Second)
+	createCompleted(3000, now.Add(-2*time.Hour), 30*time.Second)
+	createCompleted(6000, now.Add(-72*time.Hour), 20*time.Second)
+
+	// Non-completed jobs are excluded
+	failed := &models.Job{
+		Name:             "failed",
+		RemotePath:       "/path",
+		LocalPath:        "/local",
+		Status:           models.JobStatusFailed,
+		MaxRetries:       3,
+		TransferredBytes: 999999,
+	}
+	require.NoError(t, repo.CreateJob(failed))
+	require.NoError(t, repo.UpdateJob(failed))
+
+	t.Run("all time", func(t *testing.T) {
+		stats, err := repo.GetTransferStats(time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.CompletedJobs)
+		assert.Equal(t, int64(10000), stats.TotalBytes)
+		assert.InDelta(t, 60.0, stats.TotalDurationSeconds, 0.01)
+		assert.Equal(t, int64(166), stats.AverageSpeed)
+		assert.Equal(t, int64(3333), stats.AverageJobSize)
+		assert.InDelta(t, 20.0, stats.AverageDurationSeconds, 0.01)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		stats, err := repo.GetTransferStats(now.Add(-24 * time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.CompletedJobs)
+		assert.Equal(t, int64(4000), stats.TotalBytes)
+		assert.InDelta(t, 40.0, stats.TotalDurationSeconds, 0.01)
+		assert.Equal(t, int64(100), stats.AverageSpeed)
+		assert.Equal(t, int64(2000), stats.AverageJobSize)
+	})
+}
+
+func TestRepository_GetTransferStats_NoData(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	stats, err := repo.GetTransferStats(time.Time{})
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, models.TransferStats{}, *stats)
 }
 
 func TestRepository_CleanupOldJobs(t *testing.T) {
@@ -335,7 +891,7 @@ func TestRepository_CleanupOldJobs(t *testing.T) {
 	// Cleanup old jobs (older than 24 hours)
 	completedBefore := now.Add(-24 * time.Hour)
 	failedBefore := now.Add(-24 * time.Hour)
-	count, err := repo.CleanupOldJobs(completedBefore, failedBefore)
+	count, err := repo.CleanupOldJobs(completedBefore, failedBefore, nil)
 	require.NoError(t, err)
 
 	// The cleanup should remove at least the old completed job
@@ -356,26 +912,356 @@ func TestRepository_CleanupOldJobs(t *testing.T) {
 	assert.True(t, found, "expected recent job to remain")
 }
 
-func TestRepository_SetAndGetConfig(t *testing.T) {
+func TestRepository_Ping(t *testing.T) {
 	repo := setupTestRepo(t)
+	assert.NoError(t, repo.Ping(context.Background()))
 
-	err := repo.SetConfig("test_key", "test_value")
-	require.NoError(t, err)
+	repo.Close()
+	assert.Error(t, repo.Ping(context.Background()))
+}
 
-	value, err := repo.GetConfig("test_key")
-	require.NoError(t, err)
-	assert.Equal(t, "test_value", value)
+func TestRepository_Maintain(t *testing.T) {
+	t.Run("in-memory database", func(t *testing.T) {
+		repo := setupTestRepo(t)
 
-	// Test non-existent key
-	_, err = repo.GetConfig("non_existent")
-	require.Error(t, err)
+		result, err := repo.Maintain(true)
+		require.NoError(t, err)
+		// In-memory databases don't use a WAL, so there is nothing to checkpoint
+		assert.Equal(t, -1, result.CheckpointedFrames)
+		assert.True(t, result.Vacuumed)
+		assert.Positive(t, result.SizeAfter)
+	})
+
+	t.Run("file database reclaims deleted rows", func(t *testing.T) {
+		repo, err := New(filepath.Join(t.TempDir(), "grabarr.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+
+		padding := strings.Repeat("x", 4096)
+		for i := 0; i < 200; i++ {
+			require.NoError(t, repo.CreateJob(&models.Job{
+				Name:       padding,
+				RemotePath: "/path",
+				LocalPath:  "/local",
+				Status:     models.JobStatusCompleted,
+				MaxRetries: 3,
+			}))
+		}
+		_, err = repo.db.Exec("DELETE FROM jobs")
+		require.NoError(t, err)
+
+		result, err := repo.Maintain(false)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, result.CheckpointedFrames, 0)
+		assert.False(t, result.Vacuumed)
+
+		result, err = repo.Maintain(true)
+		require.NoError(t, err)
+		assert.True(t, result.Vacuumed)
+		assert.Positive(t, result.ReclaimedBytes)
+	})
 }
 
-func TestRepository_JobAttempts(t *testing.T) {
+func TestRepository_PurgeJobs(t *testing.T) {
 	repo := setupTestRepo(t)
 
-	// Create a job
-	job := &models.Job{
+	now := time.Now()
+	create := func(name string, status models.JobStatus, finishedAt time.Time) int64 {
+		job := &models.Job{
+			Name:       name,
+			RemotePath: "/path",
+			LocalPath:  "/local",
+			Status:     status,
+			MaxRetries: 3,
+		}
+		require.NoError(t, repo.CreateJob(job))
+		_, err := repo.db.Exec("UPDATE jobs SET completed_at = ? WHERE id = ?", finishedAt, job.ID)
+		require.NoError(t, err)
+		return job.ID
+	}
+
+	oldCompleted := create("old-completed", models.JobStatusCompleted, now.Add(-72*time.Hour))
+	newCompleted := create("new-completed", models.JobStatusCompleted, now.Add(-1*time.Hour))
+	oldFailed := create("old-failed", models.JobStatusFailed, now.Add(-72*time.Hour))
+	oldCancelled := create("old-cancelled", models.JobStatusCancelled, now.Add(-72*time.Hour))
+	oldQueued := create("old-queued", models.JobStatusQueued, now.Add(-72*time.Hour))
+
+	count, err := repo.PurgeJobs([]models.JobStatus{models.JobStatusCompleted, models.JobStatusFailed}, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	remaining, err := repo.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	var ids []int64
+	for _, job := range remaining {
+		ids = append(ids, job.ID)
+	}
+	assert.ElementsMatch(t, []int64{newCompleted, oldCancelled, oldQueued}, ids)
+	assert.NotContains(t, ids, oldCompleted)
+	assert.NotContains(t, ids, oldFailed)
+}
+
+func TestRepository_GetEvictionCandidates(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	now := time.Now()
+	create := func(name string, status models.JobStatus, completedAt time.Time, extra map[string]interface{}) int64 {
+		job := &models.Job{
+			Name:       name,
+			RemotePath: "/path/" + name,
+			LocalPath:  "/local",
+			Status:     status,
+			MaxRetries: 3,
+			Metadata:   models.JobMetadata{ExtraFields: extra},
+		}
+		require.NoError(t, repo.CreateJob(job))
+		_, err := repo.db.Exec("UPDATE jobs SET completed_at = ? WHERE id = ?", completedAt, job.ID)
+		require.NoError(t, err)
+		return job.ID
+	}
+
+	newer := create("newer", models.JobStatusCompleted, now.Add(-48*time.Hour), nil)
+	oldest := create("oldest", models.JobStatusCompleted, now.Add(-96*time.Hour), nil)
+	create("too-recent", models.JobStatusCompleted, now.Add(-time.Hour), nil)
+	create("failed", models.JobStatusFailed, now.Add(-96*time.Hour), nil)
+	create("extraction", models.JobStatusCompleted, now.Add(-96*time.Hour), map[string]interface{}{"job_type": "extraction"})
+	evicted := create("evicted", models.JobStatusCompleted, now.Add(-96*time.Hour), nil)
+	require.NoError(t, repo.MarkJobEvicted(evicted))
+	deleted := create("deleted", models.JobStatusCompleted, now.Add(-96*time.Hour), nil)
+	require.NoError(t, repo.DeleteJob(deleted))
+	create("skipped", models.JobStatusCompleted, now.Add(-96*time.Hour), map[string]interface{}{"skip_reason": "already present locally"})
+
+	ids, err := repo.GetEvictionCandidates(now.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{oldest, newer}, ids)
+
+	job, err := repo.GetJob(evicted)
+	require.NoError(t, err)
+	assert.NotNil(t, job.EvictedAt)
+}
+
+func TestRepository_GetNewerCompletedJobs(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	now := time.Now()
+	create := func(localPath string, status models.JobStatus, completedAt time.Time) *models.Job {
+		job := &models.Job{Name: "job", RemotePath: "/path/movie.mkv", LocalPath: localPath, Status: status, MaxRetries: 3}
+		require.NoError(t, repo.CreateJob(job))
+		job.CompletedAt = &completedAt
+		require.NoError(t, repo.UpdateJob(job))
+		return job
+	}
+
+	old := create("/cache/movies", models.JobStatusCompleted, now.Add(-96*time.Hour))
+	create("/cache/movies", models.JobStatusCompleted, now.Add(-120*time.Hour))
+	create("/cache/tv", models.JobStatusCompleted, now.Add(-time.Hour))
+	create("/cache/movies", models.JobStatusFailed, now.Add(-time.Hour))
+	evicted := create("/cache/movies", models.JobStatusCompleted, now.Add(-2*time.Hour))
+	require.NoError(t, repo.MarkJobEvicted(evicted.ID))
+	newer := create("/cache/movies", models.JobStatusCompleted, now.Add(-time.Hour))
+
+	jobs, err := repo.GetNewerCompletedJobs(old.LocalPath, *old.CompletedAt, old.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, newer.ID, jobs[0].ID)
+}
+
+func TestRepository_PurgeJobs_FallsBackToUpdatedAt(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// A failed job without completed_at is aged by updated_at, which is now
+	job := &models.Job{
+		Name:       "failed",
+		RemotePath: "/path",
+		LocalPath:  "/local",
+		Status:     models.JobStatusFailed,
+		MaxRetries: 3,
+	}
+	require.NoError(t, repo.CreateJob(job))
+
+	count, err := repo.PurgeJobs([]models.JobStatus{models.JobStatusFailed}, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = repo.PurgeJobs([]models.JobStatus{models.JobStatusFailed}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRepository_DeleteJob_SoftDeletes(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	kept := &models.Job{Name: "kept", RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued, MaxRetries: 3}
+	require.NoError(t, repo.CreateJob(kept))
+	deleted := &models.Job{Name: "deleted", RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued, MaxRetries: 3}
+	require.NoError(t, repo.CreateJob(deleted))
+
+	require.NoError(t, repo.DeleteJob(deleted.ID))
+	// Deleting again is a no-op
+	require.NoError(t, repo.DeleteJob(deleted.ID))
+
+	jobs, err := repo.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, kept.ID, jobs[0].ID)
+
+	count, err := repo.CountJobs(models.JobFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	summary, err := repo.GetJobSummary()
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.TotalJobs)
+
+	jobs, err = repo.GetJobs(models.JobFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	count, err = repo.CountJobs(models.JobFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	retrieved, err := repo.GetJob(deleted.ID)
+	require.NoError(t, err)
+	require.NotNil(t, retrieved.DeletedAt)
+
+	require.NoError(t, repo.RestoreJob(deleted.ID))
+	retrieved, err = repo.GetJob(deleted.ID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved.DeletedAt)
+	assert.Error(t, repo.RestoreJob(deleted.ID), "restoring a live job should fail")
+}
+
+func TestRepository_PurgeDeletedJobs(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	create := func(name string) int64 {
+		job := &models.Job{Name: name, RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusCompleted, MaxRetries: 3}
+		require.NoError(t, repo.CreateJob(job))
+		return job.ID
+	}
+	live := create("live")
+	recent := create("recently-deleted")
+	old := create("old-deleted")
+
+	require.NoError(t, repo.DeleteJob(recent))
+	require.NoError(t, repo.DeleteJob(old))
+	_, err := repo.db.Exec("UPDATE jobs SET deleted_at = ? WHERE id = ?", time.Now().Add(-72*time.Hour), old)
+	require.NoError(t, err)
+
+	count, err := repo.PurgeDeletedJobs(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	remaining, err := repo.GetJobs(models.JobFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+	var ids []int64
+	for _, job := range remaining {
+		ids = append(ids, job.ID)
+	}
+	assert.ElementsMatch(t, []int64{live, recent}, ids)
+}
+
+func TestRepository_CleanupOldJobs_ExcludeCategories(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	oldTime := time.Now().Add(-48 * time.Hour)
+	createOldCompleted := func(name, category string) int64 {
+		job := &models.Job{
+			Name:       name,
+			RemotePath: "/path",
+			LocalPath:  "/local",
+			Status:     models.JobStatusCompleted,
+			MaxRetries: 3,
+			Metadata:   models.JobMetadata{Category: category},
+		}
+		require.NoError(t, repo.CreateJob(job))
+		_, err := repo.db.Exec("UPDATE jobs SET completed_at = ? WHERE id = ?", oldTime.Format(time.RFC3339), job.ID)
+		require.NoError(t, err)
+		return job.ID
+	}
+
+	archivalID := createOldCompleted("archival", "archival")
+	documentaryID := createOldCompleted("documentary", "documentaries")
+	moviesID := createOldCompleted("movies", "movies")
+	uncategorizedID := createOldCompleted("uncategorized", "")
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	count, err := repo.CleanupOldJobs(cutoff, cutoff, []string{"archival", "documentaries"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Excluded categories survive
+	_, err = repo.GetJob(archivalID)
+	assert.NoError(t, err)
+	_, err = repo.GetJob(documentaryID)
+	assert.NoError(t, err)
+
+	// Other categories, and jobs without one, are removed
+	_, err = repo.GetJob(moviesID)
+	assert.Error(t, err)
+	_, err = repo.GetJob(uncategorizedID)
+	assert.Error(t, err)
+}
+
+func TestRepository_SetAndGetConfig(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	err := repo.SetConfig("test_key", "test_value")
+	require.NoError(t, err)
+
+	value, err := repo.GetConfig("test_key")
+	require.NoError(t, err)
+	assert.Equal(t, "test_value", value)
+
+	// Test non-existent key
+	_, err = repo.GetConfig("non_existent")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+}
+
+func TestRepository_DeleteConfig(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	require.NoError(t, repo.SetConfig("test_key", "test_value"))
+	require.NoError(t, repo.DeleteConfig("test_key"))
+
+	_, err := repo.GetConfig("test_key")
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+
+	// Deleting again is a no-op
+	assert.NoError(t, repo.DeleteConfig("test_key"))
+}
+
+func TestRepository_GetJobsByIDs(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	var ids []int64
+	for _, name := range []string{"a", "b", "c"} {
+		job := &models.Job{Name: name, RemotePath: "/path", LocalPath: "/local", Status: models.JobStatusQueued, MaxRetries: 3}
+		require.NoError(t, repo.CreateJob(job))
+		ids = append(ids, job.ID)
+	}
+	require.NoError(t, repo.DeleteJob(ids[2]))
+
+	jobs, err := repo.GetJobsByIDs([]int64{ids[0], 9999, ids[2]})
+	require.NoError(t, err)
+	var found []int64
+	for _, job := range jobs {
+		found = append(found, job.ID)
+	}
+	// Missing IDs are skipped; soft-deleted jobs are still returned
+	assert.ElementsMatch(t, []int64{ids[0], ids[2]}, found)
+
+	jobs, err = repo.GetJobsByIDs(nil)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestRepository_JobAttempts(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	// Create a job
+	job := &models.Job{
 		Name:       "test-job",
 		RemotePath: "/path",
 		LocalPath:  "/local",
@@ -412,6 +1298,54 @@ func TestRepository_JobAttempts(t *testing.T) {
 	assert.Equal(t, models.JobStatusCompleted, attempts[0].Status)
 }
 
+func TestRepository_Audit(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{
+		Name:       "test-job",
+		RemotePath: "/path",
+		LocalPath:  "/local",
+		Status:     models.JobStatusCompleted,
+		MaxRetries: 3,
+	}
+	require.NoError(t, repo.CreateJob(job))
+	other := &models.Job{Name: "other", RemotePath: "/other", LocalPath: "/local", Status: models.JobStatusQueued, MaxRetries: 3}
+	require.NoError(t, repo.CreateJob(other))
+
+	// Entries share a timestamp; insertion order still decides retrieval order
+	at := time.Now().UTC().Truncate(time.Second)
+	events := []models.AuditEvent{models.AuditEventCreated, models.AuditEventStarted, models.AuditEventRetried, models.AuditEventStarted, models.AuditEventCompleted}
+	for _, event := range events {
+		entry := &models.AuditEntry{JobID: job.ID, Event: event, CreatedAt: at}
+		require.NoError(t, repo.AppendAudit(entry))
+		assert.NotZero(t, entry.ID)
+	}
+	require.NoError(t, repo.AppendAudit(&models.AuditEntry{JobID: other.ID, Event: models.AuditEventCreated}))
+	require.NoError(t, repo.AppendAudit(&models.AuditEntry{JobID: job.ID, Event: models.AuditEventCancelled, Message: "late"}))
+
+	entries, err := repo.GetAudit(job.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	for i, event := range events {
+		assert.Equal(t, event, entries[i].Event)
+		assert.True(t, entries[i].CreatedAt.Equal(at))
+	}
+	assert.Equal(t, models.AuditEventCancelled, entries[5].Event)
+	assert.Equal(t, "late", entries[5].Message)
+	assert.False(t, entries[5].CreatedAt.IsZero())
+
+	// The audit log outlives purged jobs
+	_, err = repo.PurgeJobs([]models.JobStatus{models.JobStatusCompleted}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	entries, err = repo.GetAudit(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, entries, 6)
+
+	entries, err = repo.GetAudit(9999)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
 func TestRepository_JobWithDownloadConfig(t *testing.T) {
 	repo := setupTestRepo(t)
 
@@ -560,3 +1494,296 @@ func TestRepository_MigrationAddsDownloadConfig(t *testing.T) {
 	assert.NotNil(t, retrieved.DownloadConfig)
 	assert.Equal(t, 2, *retrieved.DownloadConfig.Transfers)
 }
+
+func TestRepository_GetJobs_GroupFilter(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for i, groupID := range []string{"season-1", "season-1", "season-2", ""} {
+		job := &models.Job{
+			Name:       fmt.Sprintf("episode-%d", i),
+			RemotePath: "/path",
+			LocalPath:  "/local",
+			Status:     models.JobStatusQueued,
+			MaxRetries: 3,
+			GroupID:    groupID,
+		}
+		require.NoError(t, repo.CreateJob(job))
+	}
+
+	jobs, err := repo.GetJobs(models.JobFilter{GroupID: "season-1"})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	for _, job := range jobs {
+		assert.Equal(t, "season-1", job.GroupID)
+	}
+
+	count, err := repo.CountJobs(models.JobFilter{GroupID: "season-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Ungrouped jobs read back with an empty group ID
+	all, err := repo.GetJobs(models.JobFilter{})
+	require.NoError(t, err)
+	ungrouped := 0
+	for _, job := range all {
+		if job.GroupID == "" {
+			ungrouped++
+		}
+	}
+	assert.Equal(t, 1, ungrouped)
+}
+
+func TestRepository_GetJobByHash(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	for _, hash := range []string{"aaaa1111", "bbbb2222", "AAAA1111"} {
+		require.NoError(t, repo.CreateJob(&models.Job{
+			Name:       "job-" + hash,
+			RemotePath: "/remote/" + hash,
+			LocalPath:  "/local/" + hash,
+			Status:     models.JobStatusQueued,
+			Metadata:   models.JobMetadata{QBittorrentHash: hash},
+		}))
+	}
+
+	// Matches ignore case and return the newest job
+	job, err := repo.GetJobByHash("aaaa1111")
+	require.NoError(t, err)
+	assert.Equal(t, "job-AAAA1111", job.Name)
+
+	jobs, err := repo.GetJobs(models.JobFilter{Hash: "AAAA1111"})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	count, err := repo.CountJobs(models.JobFilter{Hash: "bbbb2222"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = repo.GetJobByHash("cccc3333")
+	assert.Error(t, err)
+}
+
+func TestRepository_GetActiveJobByRemotePath(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	create := func(name, remotePath string, status models.JobStatus) *models.Job {
+		job := &models.Job{Name: name, RemotePath: remotePath, LocalPath: "/local", Status: status}
+		require.NoError(t, repo.CreateJob(job))
+		return job
+	}
+
+	create("finished", "/remote/file.mkv", models.JobStatusCompleted)
+	running := create("running", "/remote/file.mkv", models.JobStatusRunning)
+	create("other", "/remote/other.mkv", models.JobStatusQueued)
+
+	job, err := repo.GetActiveJobByRemotePath("/remote/file.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, running.ID, job.ID)
+
+	_, err = repo.GetActiveJobByRemotePath("/remote/missing.mkv")
+	assert.Error(t, err)
+
+	create("done", "/remote/done.mkv", models.JobStatusCancelled)
+	_, err = repo.GetActiveJobByRemotePath("/remote/done.mkv")
+	assert.Error(t, err, "finished jobs are not active")
+}
+
+func TestRepository_MigrationAddsGroupID(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	// Create a jobs table from before group_id existed
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		remote_path TEXT NOT NULL,
+		local_path TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'queued',
+		priority INTEGER NOT NULL DEFAULT 0,
+		retries INTEGER NOT NULL DEFAULT 0,
+		max_retries INTEGER NOT NULL DEFAULT 3,
+		error_message TEXT,
+		progress TEXT,
+		metadata TEXT,
+		download_config TEXT,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		started_at DATETIME,
+		completed_at DATETIME,
+		file_size INTEGER DEFAULT 0,
+		transferred_bytes INTEGER DEFAULT 0,
+		transfer_speed INTEGER DEFAULT 0
+	)`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	repo, err := New(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	var columnExists int
+	err = repo.db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('jobs') WHERE name='group_id'").Scan(&columnExists)
+	require.NoError(t, err)
+	assert.Equal(t, 1, columnExists, "group_id column should exist after migration")
+
+	job := &models.Job{
+		Name:       "test-migration",
+		RemotePath: "/remote",
+		LocalPath:  "/local",
+		Status:     models.JobStatusQueued,
+		MaxRetries: 3,
+		GroupID:    "season-1",
+	}
+	require.NoError(t, repo.CreateJob(job))
+
+	retrieved, err := repo.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "season-1", retrieved.GroupID)
+}
+
+func TestRepository_MigrationsRecorded(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	var versions []int
+	rows, err := repo.db.Query("SELECT version FROM schema_migrations ORDER BY version")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		require.NoError(t, rows.Scan(&v))
+		versions = append(versions, v)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Len(t, versions, len(migrations))
+	for i, m := range migrations {
+		assert.Equal(t, m.version, versions[i])
+	}
+}
+
+func TestRepository_MigrationsRunOnce(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "grabarr.db")
+
+	repo, err := New(dbPath)
+	require.NoError(t, err)
+	var firstAppliedAt string
+	require.NoError(t, repo.db.QueryRow("SELECT applied_at FROM schema_migrations WHERE version = 1").Scan(&firstAppliedAt))
+	require.NoError(t, repo.Close())
+
+	// A migration that would fail if it ran again
+	calls := 0
+	migrations = append(migrations, migration{
+		version:     len(migrations) + 1,
+		description: "test-only",
+		apply: func(tx *sql.Tx) error {
+			calls++
+			_, err := tx.Exec("CREATE TABLE migration_probe (id INTEGER)")
+			return err
+		},
+	})
+	t.Cleanup(func() { migrations = migrations[:len(migrations)-1] })
+
+	for i := 0; i < 2; i++ {
+		repo, err = New(dbPath)
+		require.NoError(t, err)
+		require.NoError(t, repo.Close())
+	}
+	assert.Equal(t, 1, calls)
+
+	repo, err = New(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	var count int
+	require.NoError(t, repo.db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	assert.Equal(t, len(migrations), count)
+
+	// Earlier migrations keep their original record
+	var appliedAt string
+	require.NoError(t, repo.db.QueryRow("SELECT applied_at FROM schema_migrations WHERE version = 1").Scan(&appliedAt))
+	assert.Equal(t, firstAppliedAt, appliedAt)
+}
+
+func TestRepository_LifetimeStats(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	createCompleted := func(bytes int64) {
+		job := &models.Job{
+			Name:             "completed",
+			RemotePath:       "/path",
+			LocalPath:        "/local",
+			Status:           models.JobStatusCompleted,
+			MaxRetries:       3,
+			TransferredBytes: bytes,
+		}
+		require.NoError(t, repo.CreateJob(job))
+		require.NoError(t, repo.UpdateJob(job))
+	}
+
+	// Existing completed jobs seed the totals on first read
+	createCompleted(1000)
+	createCompleted(2000)
+
+	stats, err := repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), stats.BytesTransferred)
+	assert.Equal(t, int64(2), stats.CompletedJobs)
+
+	// Completions increment the running totals
+	createCompleted(500)
+	require.NoError(t, repo.RecordJobCompletion(500))
+
+	stats, err = repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3500), stats.BytesTransferred)
+	assert.Equal(t, int64(3), stats.CompletedJobs)
+
+	// Totals survive cleanup of old jobs
+	_, err = repo.CleanupOldJobs(time.Now().Add(time.Hour), time.Now().Add(time.Hour), nil)
+	require.NoError(t, err)
+
+	stats, err = repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3500), stats.BytesTransferred)
+	assert.Equal(t, int64(3), stats.CompletedJobs)
+}
+
+func TestRepository_RecordJobCompletion_SeedsFromJobs(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	job := &models.Job{
+		Name:             "completed",
+		RemotePath:       "/path",
+		LocalPath:        "/local",
+		Status:           models.JobStatusCompleted,
+		MaxRetries:       3,
+		TransferredBytes: 750,
+	}
+	require.NoError(t, repo.CreateJob(job))
+	require.NoError(t, repo.UpdateJob(job))
+
+	// No totals stored yet, so recording rebuilds them (including this job)
+	require.NoError(t, repo.RecordJobCompletion(750))
+
+	stats, err := repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(750), stats.BytesTransferred)
+	assert.Equal(t, int64(1), stats.CompletedJobs)
+}
+
+func TestRepository_GetLifetimeStats_RepairsCorruptTotals(t *testing.T) {
+	repo := setupTestRepo(t)
+
+	require.NoError(t, repo.SetConfig(lifetimeBytesKey, "garbage"))
+	require.NoError(t, repo.SetConfig(lifetimeJobsKey, "7"))
+
+	stats, err := repo.GetLifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stats.BytesTransferred)
+	assert.Equal(t, int64(0), stats.CompletedJobs)
+
+	value, err := repo.GetConfig(lifetimeBytesKey)
+	require.NoError(t, err)
+	assert.Equal(t, "0", value)
+}
@@ -22,7 +22,12 @@ CREATE TABLE IF NOT EXISTS jobs (
     completed_at DATETIME,
     file_size INTEGER DEFAULT 0,
     transferred_bytes INTEGER DEFAULT 0,
-    transfer_speed INTEGER DEFAULT 0
+    transfer_speed INTEGER DEFAULT 0,
+    group_id TEXT,
+    failure_category TEXT, -- auth, disk, network, notfound, unknown
+    deleted_at DATETIME, -- set when soft-deleted; NULL for live jobs
+    evicted_at DATETIME, -- set when cache eviction removed the local files
+    source_url TEXT NOT NULL DEFAULT '' -- HTTP(S) source for copyurl jobs; remote_path is empty
 );
 
 -- Job attempts table for tracking retry history
@@ -38,6 +43,16 @@ CREATE TABLE IF NOT EXISTS job_attempts (
     FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
 );
 
+-- Append-only audit of job state transitions. No foreign key, so entries
+-- outlive jobs removed by cleanup or purge.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    job_id INTEGER NOT NULL,
+    event TEXT NOT NULL, -- created, started, completed, failed, cancelled, retried, evicted
+    message TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- System configuration table for runtime settings
 CREATE TABLE IF NOT EXISTS system_config (
     key TEXT PRIMARY KEY,
@@ -46,6 +61,12 @@ CREATE TABLE IF NOT EXISTS system_config (
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Applied schema migrations (see migrations.go)
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Indexes for performance
 CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
 CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs(created_at);
@@ -53,6 +74,7 @@ CREATE INDEX IF NOT EXISTS idx_jobs_priority ON jobs(priority);
 CREATE INDEX IF NOT EXISTS idx_jobs_status_priority ON jobs(status, priority DESC);
 CREATE INDEX IF NOT EXISTS idx_job_attempts_job_id ON job_attempts(job_id);
 CREATE INDEX IF NOT EXISTS idx_job_attempts_attempt_num ON job_attempts(job_id, attempt_num);
+CREATE INDEX IF NOT EXISTS idx_audit_log_job_id ON audit_log(job_id);
 
 -- Triggers to automatically update updated_at timestamp
 CREATE TRIGGER IF NOT EXISTS jobs_updated_at
@@ -4,15 +4,19 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"grabarr/internal/config"
+	"grabarr/internal/httpclient"
 	"grabarr/internal/models"
 )
 
@@ -31,6 +35,7 @@ type Client struct {
 	sshHost    string
 	sshUser    string
 	sshKeyFile string
+	httpClient *http.Client // used by CopyURL; no overall timeout since downloads can be large
 }
 
 // NewClient creates a new rsync client
@@ -39,9 +44,17 @@ func NewClient(sshHost, sshUser, sshKeyFile string) *Client {
 		sshHost:    sshHost,
 		sshUser:    sshUser,
 		sshKeyFile: sshKeyFile,
+		httpClient: httpclient.New(config.OutboundHTTPConfig{}, httpclient.WithoutTimeout()),
 	}
 }
 
+// SetHTTPClient replaces the client CopyURL downloads with, e.g. one built by
+// httpclient.New from the configured outbound settings. It should not have an
+// overall timeout, since downloads can be large.
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
 // Transfer represents a running rsync transfer
 type Transfer struct {
 	cmd          *exec.Cmd
@@ -50,19 +63,17 @@ type Transfer struct {
 	cancel       context.CancelFunc
 }
 
-// Copy starts an rsync transfer in the background
-func (c *Client) Copy(ctx context.Context, remotePath, localPath string) (*Transfer, error) {
-	// Build rsync command with enhanced options for large file transfers
-	// --partial-dir=.rsync-partial: store partial files in dedicated directory for reliable resume
-	// --timeout=600: abort transfer if no data transferred for 10 minutes (prevents infinite hangs during verification)
-	// --mkpath: automatically create parent directories for destination path
-	// SSH options: UserKnownHostsFile=/dev/null prevents permission issues with .ssh directory
-	// ServerAliveCountMax=30: Allow 30 minutes (60s * 30) of no SSH response during intensive verification phase
-	sshCmd := fmt.Sprintf("ssh -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o ConnectTimeout=10 -o ServerAliveInterval=60 -o ServerAliveCountMax=30 -i %s", c.sshKeyFile)
-	remoteSource := fmt.Sprintf("%s@%s:%s", c.sshUser, c.sshHost, remotePath)
+// CopyOptions adjusts a single transfer.
+type CopyOptions struct {
+	// BwLimit is passed to rsync --bwlimit (e.g. "10M", "512K"; a bare number
+	// is KiB/s). Empty means unlimited.
+	BwLimit string
+}
 
+// Copy starts an rsync transfer in the background
+func (c *Client) Copy(ctx context.Context, remotePath, localPath string, opts CopyOptions) (*Transfer, error) {
 	cmdCtx, cancel := context.WithCancel(ctx)
-	cmd := exec.CommandContext(cmdCtx, "rsync", "-avz", "--info=progress2", "--partial-dir=.rsync-partial", "--mkpath", "--timeout=600", "-e", sshCmd, remoteSource, localPath)
+	cmd := exec.CommandContext(cmdCtx, "rsync", c.copyArgs(remotePath, localPath, opts)...)
 
 	// Get stdout pipe for progress parsing
 	stdout, err := cmd.StdoutPipe()
@@ -110,6 +121,24 @@ func (c *Client) Copy(ctx context.Context, remotePath, localPath string) (*Trans
 	return transfer, nil
 }
 
+// copyArgs builds the rsync arguments for copying remotePath into localPath.
+func (c *Client) copyArgs(remotePath, localPath string, opts CopyOptions) []string {
+	// Build rsync command with enhanced options for large file transfers
+	// --partial-dir=.rsync-partial: store partial files in dedicated directory for reliable resume
+	// --timeout=600: abort transfer if no data transferred for 10 minutes (prevents infinite hangs during verification)
+	// --mkpath: automatically create parent directories for destination path
+	// SSH options: UserKnownHostsFile=/dev/null prevents permission issues with .ssh directory
+	// ServerAliveCountMax=30: Allow 30 minutes (60s * 30) of no SSH response during intensive verification phase
+	sshCmd := fmt.Sprintf("ssh -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o ConnectTimeout=10 -o ServerAliveInterval=60 -o ServerAliveCountMax=30 -i %s", c.sshKeyFile)
+	remoteSource := fmt.Sprintf("%s@%s:%s", c.sshUser, c.sshHost, remotePath)
+
+	args := []string{"-avz", "--info=progress2", "--partial-dir=.rsync-partial", "--mkpath", "--timeout=600"}
+	if opts.BwLimit != "" {
+		args = append(args, "--bwlimit="+opts.BwLimit)
+	}
+	return append(args, "-e", sshCmd, remoteSource, localPath)
+}
+
 // ProgressChan returns the channel for receiving progress updates
 func (t *Transfer) ProgressChan() <-chan *models.JobProgress {
 	return t.progressChan
@@ -152,65 +181,196 @@ func (t *Transfer) parseProgress(stdout io.Reader) {
 		return 0, nil, nil
 	})
 
+	// With -v, rsync prints each file's path on its own line before transferring
+	// it, so the most recent one is the file currently in flight.
+	var currentFile string
+
 	for scanner.Scan() {
 		line := scanner.Text()
 
 		// Try to parse progress line
 		matches := progressRegex.FindStringSubmatch(line)
-		if len(matches) == 8 {
-			// Parse transferred bytes
-			bytesStr := strings.ReplaceAll(matches[1], ",", "")
-			bytes, err := strconv.ParseInt(bytesStr, 10, 64)
-			if err != nil {
-				continue
+		if len(matches) != 8 {
+			if name, ok := fileNameFromLine(line); ok {
+				currentFile = name
 			}
+			continue
+		}
 
-			// Parse percentage
-			percentage, err := strconv.Atoi(matches[2])
-			if err != nil {
-				continue
-			}
+		// Parse transferred bytes
+		bytesStr := strings.ReplaceAll(matches[1], ",", "")
+		bytes, err := strconv.ParseInt(bytesStr, 10, 64)
+		if err != nil {
+			continue
+		}
 
-			// Parse speed
-			speedVal, err := strconv.ParseFloat(matches[3], 64)
-			if err != nil {
-				continue
-			}
+		// Parse percentage
+		percentage, err := strconv.Atoi(matches[2])
+		if err != nil {
+			continue
+		}
 
-			// Convert speed to bytes/sec
-			speedUnit := matches[4]
-			var speed float64
-			switch speedUnit {
-			case "K":
-				speed = speedVal * 1024
-			case "M":
-				speed = speedVal * 1024 * 1024
-			case "G":
-				speed = speedVal * 1024 * 1024 * 1024
-			}
+		// Parse speed
+		speedVal, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			continue
+		}
 
-			// Parse ETA
-			hours, _ := strconv.Atoi(matches[5])
-			minutes, _ := strconv.Atoi(matches[6])
-			seconds, _ := strconv.Atoi(matches[7])
-			etaDuration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
-			eta := time.Now().Add(etaDuration)
-
-			progress := &models.JobProgress{
-				Percentage:       float64(percentage),
-				TransferredBytes: bytes,
-				TransferSpeed:    int64(speed),
-				ETA:              &eta,
-				LastUpdateTime:   time.Now(),
-			}
+		// Convert speed to bytes/sec
+		speedUnit := matches[4]
+		var speed float64
+		switch speedUnit {
+		case "K":
+			speed = speedVal * 1024
+		case "M":
+			speed = speedVal * 1024 * 1024
+		case "G":
+			speed = speedVal * 1024 * 1024 * 1024
+		}
 
-			// Send progress update (non-blocking)
-			select {
-			case t.progressChan <- progress:
-			default:
-				// Channel full, skip this update
-			}
+		// Parse ETA
+		hours, _ := strconv.Atoi(matches[5])
+		minutes, _ := strconv.Atoi(matches[6])
+		seconds, _ := strconv.Atoi(matches[7])
+		etaDuration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		eta := time.Now().Add(etaDuration)
+
+		progress := &models.JobProgress{
+			Percentage:       float64(percentage),
+			TransferredBytes: bytes,
+			TransferSpeed:    int64(speed),
+			ETA:              &eta,
+			CurrentFile:      currentFile,
+			LastUpdateTime:   time.Now(),
 		}
+
+		// Send progress update (non-blocking)
+		select {
+		case t.progressChan <- progress:
+		default:
+			// Channel full, skip this update
+		}
+	}
+
+}
+
+// rsyncInfoPrefixes are non-file lines rsync -v writes to stdout.
+var rsyncInfoPrefixes = []string{
+	"sending incremental file list",
+	"receiving incremental file list",
+	"receiving file list",
+	"created directory",
+	"sent ",
+	"total size is",
+	"delta-transmission",
+}
+
+// fileNameFromLine reports whether an rsync -v output line names a file being
+// transferred, returning its path. Progress lines are indented and directory
+// entries end in a slash, so neither is treated as a file.
+func fileNameFromLine(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || strings.HasSuffix(line, "/") {
+		return "", false
+	}
+	for _, prefix := range rsyncInfoPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return "", false
+		}
+	}
+	return line, true
+}
+
+// Size sums the sizes of all regular files under remotePath on the seedbox.
+func (c *Client) Size(ctx context.Context, remotePath string) (*models.RemoteSize, error) {
+	findCmd := fmt.Sprintf("find %s -type f -printf '%%s\\n'", shellQuote(remotePath))
+
+	cmd := exec.CommandContext(ctx, "ssh", c.sshArgs(findCmd)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("ssh find failed: %w (stderr: %s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	size := &models.RemoteSize{Path: remotePath}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		n, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			continue
+		}
+		size.TotalBytes += n
+		size.FileCount++
 	}
 
+	return size, nil
+}
+
+// Ping checks that the seedbox is reachable over SSH and that remotePath is a
+// directory there. An empty remotePath checks the login directory.
+func (c *Client) Ping(ctx context.Context, remotePath string) error {
+	if remotePath == "" {
+		remotePath = "."
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", c.sshArgs("test -d "+shellQuote(remotePath))...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("ssh check failed: %w (stderr: %s)", err, msg)
+		}
+		// test -d exits 1 with no output when the directory is missing
+		return fmt.Errorf("ssh check failed: %s is not a directory on the remote: %w", remotePath, err)
+	}
+	return nil
+}
+
+// Stat reports whether remotePath exists on the seedbox, as a file or a
+// directory. A missing path is not an error; failing to reach the seedbox is.
+func (c *Client) Stat(ctx context.Context, remotePath string) (bool, error) {
+	cmd := exec.CommandContext(ctx, "ssh", c.sshArgs("test -e "+shellQuote(remotePath))...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
+
+	// test -e exits 1 with no output when the path is missing; ssh itself exits 255
+	msg := strings.TrimSpace(stderr.String())
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && msg == "" {
+		return false, nil
+	}
+	return false, fmt.Errorf("ssh stat failed: %w (stderr: %s)", err, msg)
+}
+
+// sshArgs builds the ssh arguments for running remoteCmd on the seedbox.
+func (c *Client) sshArgs(remoteCmd string) []string {
+	return []string{
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "ConnectTimeout=15",
+		"-i", c.sshKeyFile,
+		fmt.Sprintf("%s@%s", c.sshUser, c.sshHost),
+		remoteCmd,
+	}
+}
+
+// shellQuote quotes s for safe use as a single argument in a remote shell command.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
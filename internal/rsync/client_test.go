@@ -0,0 +1,131 @@
+package rsync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"grabarr/internal/models"
+)
+
+func collectProgress(output string) []*models.JobProgress {
+	transfer := &Transfer{progressChan: make(chan *models.JobProgress, 10)}
+	transfer.parseProgress(strings.NewReader(output))
+	close(transfer.progressChan)
+
+	var updates []*models.JobProgress
+	for p := range transfer.progressChan {
+		updates = append(updates, p)
+	}
+	return updates
+}
+
+func TestParseProgress_CurrentFile(t *testing.T) {
+	output := "receiving incremental file list\n" +
+		"Show.S01/\n" +
+		"Show.S01/E01.mkv\n" +
+		"    104,857,600  10%   10.00MB/s    0:01:30\r" +
+		"    524,288,000  50%   10.00MB/s    0:00:50 (xfr#1, to-chk=1/3)\n" +
+		"Show.S01/E02.mkv\n" +
+		"    786,432,000  75%   10.00MB/s    0:00:25\r" +
+		"\n" +
+		"sent 1,234 bytes  received 1,048,576,000 bytes  10,485,760.00 bytes/sec\n" +
+		"total size is 1,048,576,000  speedup is 1.00\n"
+
+	updates := collectProgress(output)
+	require.Len(t, updates, 3)
+
+	assert.Equal(t, "Show.S01/E01.mkv", updates[0].CurrentFile)
+	assert.Equal(t, 10.0, updates[0].Percentage)
+	assert.Equal(t, int64(104857600), updates[0].TransferredBytes)
+	assert.Equal(t, int64(10*1024*1024), updates[0].TransferSpeed)
+
+	assert.Equal(t, "Show.S01/E01.mkv", updates[1].CurrentFile)
+	assert.Equal(t, "Show.S01/E02.mkv", updates[2].CurrentFile)
+	assert.Equal(t, 75.0, updates[2].Percentage)
+}
+
+func TestFileNameFromLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"Movie.2024.mkv", "Movie.2024.mkv", true},
+		{"Show.S01/E01.mkv", "Show.S01/E01.mkv", true},
+		{"Show.S01/", "", false},
+		{"", "", false},
+		{"    8,745,341,265  21%   10.26MB/s    0:51:13", "", false},
+		{"receiving incremental file list", "", false},
+		{"sent 1,234 bytes  received 5,678 bytes  1.00 bytes/sec", "", false},
+		{"total size is 5,678  speedup is 1.00", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := fileNameFromLine(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_SSHArgs(t *testing.T) {
+	c := NewClient("seedbox.example.com", "user", "/keys/id_ed25519")
+
+	args := c.sshArgs("test -d " + shellQuote("/home/user/it's here"))
+
+	assert.Equal(t, []string{
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "ConnectTimeout=15",
+		"-i", "/keys/id_ed25519",
+		"user@seedbox.example.com",
+		`test -d '/home/user/it'\''s here'`,
+	}, args)
+}
+
+func TestClient_CopyArgs(t *testing.T) {
+	c := NewClient("seedbox.example.com", "user", "/keys/id_ed25519")
+
+	args := c.copyArgs("/home/user/Movie.mkv", "/downloads/movies", CopyOptions{})
+	assert.NotContains(t, strings.Join(args, " "), "--bwlimit")
+	assert.Equal(t, []string{"user@seedbox.example.com:/home/user/Movie.mkv", "/downloads/movies"}, args[len(args)-2:])
+
+	args = c.copyArgs("/home/user/Movie.mkv", "/downloads/movies", CopyOptions{BwLimit: "5M"})
+	assert.Contains(t, args, "--bwlimit=5M")
+}
+
+// fakeSSH puts an ssh script on PATH that prints stderr and exits with code.
+func fakeSSH(t *testing.T, code int, stderr string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nprintf '%%s' '%s' >&2\nexit %d\n", stderr, code)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ssh"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestClient_Stat(t *testing.T) {
+	client := NewClient("seedbox.example.com", "user", "")
+
+	fakeSSH(t, 0, "")
+	exists, err := client.Stat(context.Background(), "/downloads/Movie.mkv")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	fakeSSH(t, 1, "")
+	exists, err = client.Stat(context.Background(), "/downloads/Missing.mkv")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	fakeSSH(t, 255, "Permission denied (publickey).")
+	_, err = client.Stat(context.Background(), "/downloads/Movie.mkv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Permission denied")
+}
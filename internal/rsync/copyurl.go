@@ -0,0 +1,160 @@
+package rsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"grabarr/internal/models"
+)
+
+// urlProgressInterval is how often a CopyURL transfer reports progress.
+const urlProgressInterval = time.Second
+
+// HTTPStatusError is returned by a CopyURL transfer when the server answers
+// with a non-2xx status.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string { return fmt.Sprintf("HTTP %s", e.Status) }
+
+// CopyURL downloads sourceURL over HTTP(S) into the localPath directory in the
+// background. It is the copyurl counterpart of Copy for sources that aren't on
+// the seedbox, and reports progress and completion through the same Transfer.
+// The file is named after the URL's last path element and written to a
+// ".part" file that is renamed once the body has been read completely.
+func (c *Client) CopyURL(ctx context.Context, sourceURL, localPath string) (*Transfer, error) {
+	name, err := models.SourceURLFileName(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	transfer := &Transfer{
+		progressChan: make(chan *models.JobProgress, 10),
+		doneChan:     make(chan error, 1),
+		cancel:       cancel,
+	}
+
+	go func() {
+		err := c.fetchURL(reqCtx, transfer, sourceURL, filepath.Join(localPath, name))
+		close(transfer.progressChan)
+		transfer.doneChan <- err
+		close(transfer.doneChan)
+	}()
+
+	return transfer, nil
+}
+
+// fetchURL streams sourceURL into target, publishing progress on t.
+func (c *Client) fetchURL(ctx context.Context, t *Transfer, sourceURL, target string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The error becomes the job's error message; keep credentials out of it
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = models.RedactURL(urlErr.URL)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create local directory: %w", err)
+	}
+
+	partial := target + ".part"
+	f, err := os.Create(partial)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+
+	progress := &urlProgress{
+		transfer:   t,
+		name:       filepath.Base(target),
+		total:      resp.ContentLength,
+		lastReport: time.Now(),
+	}
+	_, copyErr := io.Copy(io.MultiWriter(f, progress), resp.Body)
+	closeErr := f.Close()
+	if copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr != nil {
+		os.Remove(partial)
+		return copyErr
+	}
+
+	if err := os.Rename(partial, target); err != nil {
+		return fmt.Errorf("failed to move download into place: %w", err)
+	}
+	progress.report(time.Now(), true)
+	return nil
+}
+
+// urlProgress counts bytes written by a CopyURL transfer and publishes a
+// JobProgress at most once per urlProgressInterval.
+type urlProgress struct {
+	transfer   *Transfer
+	name       string
+	total      int64 // -1 when the server sent no Content-Length
+	written    int64
+	lastReport time.Time
+	lastBytes  int64
+}
+
+func (p *urlProgress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if now := time.Now(); now.Sub(p.lastReport) >= urlProgressInterval {
+		p.report(now, false)
+	}
+	return len(b), nil
+}
+
+func (p *urlProgress) report(now time.Time, done bool) {
+	progress := &models.JobProgress{
+		TransferredBytes: p.written,
+		CurrentFile:      p.name,
+		LastUpdateTime:   now,
+	}
+
+	if elapsed := now.Sub(p.lastReport).Seconds(); elapsed > 0 {
+		progress.TransferSpeed = int64(float64(p.written-p.lastBytes) / elapsed)
+	}
+	if p.total > 0 {
+		progress.Percentage = float64(p.written) / float64(p.total) * 100
+		if progress.TransferSpeed > 0 && !done {
+			eta := now.Add(time.Duration(float64(p.total-p.written) / float64(progress.TransferSpeed) * float64(time.Second)))
+			progress.ETA = &eta
+		}
+	}
+	if done {
+		progress.Percentage = 100
+	}
+
+	p.lastReport = now
+	p.lastBytes = p.written
+
+	select {
+	case p.transfer.progressChan <- progress:
+	default:
+		// Channel full, skip this update
+	}
+}
@@ -0,0 +1,139 @@
+package rsync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"grabarr/internal/config"
+	"grabarr/internal/httpclient"
+	"grabarr/internal/models"
+)
+
+// waitTransfer drains a transfer's progress and returns its result.
+func waitTransfer(t *testing.T, transfer *Transfer) ([]*models.JobProgress, error) {
+	t.Helper()
+	var updates []*models.JobProgress
+	for p := range transfer.ProgressChan() {
+		updates = append(updates, p)
+	}
+	return updates, <-transfer.Done()
+}
+
+func TestClient_CopyURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/files/movie.mkv", r.URL.Path)
+		assert.Contains(t, r.Header.Get("User-Agent"), "grabarr/")
+		w.Write([]byte("movie contents"))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "movies")
+	client := NewClient("host", "user", "/key")
+
+	transfer, err := client.CopyURL(context.Background(), server.URL+"/files/movie.mkv?token=abc", dir)
+	require.NoError(t, err)
+
+	updates, err := waitTransfer(t, transfer)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "movie.mkv"))
+	require.NoError(t, err)
+	assert.Equal(t, "movie contents", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "movie.mkv.part"))
+
+	// The final update reports the whole file
+	require.NotEmpty(t, updates)
+	last := updates[len(updates)-1]
+	assert.Equal(t, int64(len("movie contents")), last.TransferredBytes)
+	assert.Equal(t, 100.0, last.Percentage)
+	assert.Equal(t, "movie.mkv", last.CurrentFile)
+}
+
+func TestClient_CopyURL_ConfiguredUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "grabarr/2.3.4 (+test)", r.Header.Get("User-Agent"))
+		w.Write([]byte("movie contents"))
+	}))
+	defer server.Close()
+
+	client := NewClient("host", "user", "/key")
+	client.SetHTTPClient(httpclient.New(config.OutboundHTTPConfig{UserAgent: "grabarr/2.3.4 (+test)"}, httpclient.WithoutTimeout()))
+
+	transfer, err := client.CopyURL(context.Background(), server.URL+"/movie.mkv", t.TempDir())
+	require.NoError(t, err)
+	_, err = waitTransfer(t, transfer)
+	require.NoError(t, err)
+}
+
+func TestClient_CopyURL_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	transfer, err := NewClient("host", "user", "/key").CopyURL(context.Background(), server.URL+"/missing.mkv", dir)
+	require.NoError(t, err)
+
+	_, err = waitTransfer(t, transfer)
+	var statusErr *HTTPStatusError
+	require.True(t, errors.As(err, &statusErr), "got %v", err)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.NoFileExists(t, filepath.Join(dir, "missing.mkv"))
+}
+
+func TestClient_CopyURL_Stop(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	transfer, err := NewClient("host", "user", "/key").CopyURL(context.Background(), server.URL+"/big.iso", dir)
+	require.NoError(t, err)
+
+	<-started
+	transfer.Stop()
+	_, err = waitTransfer(t, transfer)
+	assert.Error(t, err)
+
+	// An interrupted download leaves nothing behind
+	assert.NoFileExists(t, filepath.Join(dir, "big.iso"))
+	assert.NoFileExists(t, filepath.Join(dir, "big.iso.part"))
+}
+
+func TestClient_CopyURL_InvalidURL(t *testing.T) {
+	client := NewClient("host", "user", "/key")
+
+	for _, raw := range []string{"ftp://host/file", "https://host/", "/just/a/path"} {
+		_, err := client.CopyURL(context.Background(), raw, t.TempDir())
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestClient_CopyURL_ConnectionErrorRedactsURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient("host", "user", "/key")
+	transfer, err := client.CopyURL(context.Background(), server.URL+"/movie.mkv?token=abc", t.TempDir())
+	require.NoError(t, err)
+
+	_, err = waitTransfer(t, transfer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), server.URL+"/movie.mkv")
+	assert.NotContains(t, err.Error(), "token")
+}